@@ -0,0 +1,86 @@
+package memeduck
+
+import (
+	"github.com/cloudspannerecosystem/memefish/ast"
+
+	"github.com/abyssparanoia/memeduck/internal"
+)
+
+// AggregateExpr is an aggregate function call such as COUNT(x) or ARRAY_AGG(x).
+type AggregateExpr struct {
+	name     string
+	args     []interface{}
+	distinct bool
+	as       string
+}
+
+func newAggregate(name string, args ...interface{}) *AggregateExpr {
+	return &AggregateExpr{
+		name: name,
+		args: args,
+	}
+}
+
+// Count creates `COUNT(x)` aggregate function call.
+func Count(x interface{}) *AggregateExpr {
+	return newAggregate("COUNT", x)
+}
+
+// ArrayAgg creates `ARRAY_AGG(x)` aggregate function call.
+func ArrayAgg(x interface{}) *AggregateExpr {
+	return newAggregate("ARRAY_AGG", x)
+}
+
+// Sum creates `SUM(x)` aggregate function call.
+func Sum(x interface{}) *AggregateExpr {
+	return newAggregate("SUM", x)
+}
+
+// Avg creates `AVG(x)` aggregate function call.
+func Avg(x interface{}) *AggregateExpr {
+	return newAggregate("AVG", x)
+}
+
+// Min creates `MIN(x)` aggregate function call.
+func Min(x interface{}) *AggregateExpr {
+	return newAggregate("MIN", x)
+}
+
+// Max creates `MAX(x)` aggregate function call.
+func Max(x interface{}) *AggregateExpr {
+	return newAggregate("MAX", x)
+}
+
+// Distinct adds a DISTINCT modifier to the aggregate function call, e.g. `COUNT(DISTINCT x)`.
+func (e *AggregateExpr) Distinct() *AggregateExpr {
+	var t = *e
+	t.distinct = true
+	return &t
+}
+
+// As sets an alias used when the aggregate appears in SELECT results.
+func (e *AggregateExpr) As(as string) *AggregateExpr {
+	var t = *e
+	t.as = as
+	return &t
+}
+
+func (e *AggregateExpr) ToASTExpr() (ast.Expr, error) {
+	args := make([]ast.Arg, 0, len(e.args))
+	for _, a := range e.args {
+		arg, err := internal.ToExpr(a)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, &ast.ExprArg{Expr: arg})
+	}
+	return internal.Call(e.name, e.distinct, args), nil
+}
+
+func (e *AggregateExpr) ToAST() (ast.SelectItem, error) {
+	expr, err := e.ToASTExpr()
+	if err != nil {
+		return nil, err
+	}
+	return toASTSelectItem(expr, e.as), nil
+}
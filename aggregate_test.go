@@ -0,0 +1,37 @@
+package memeduck_test
+
+import (
+	"testing"
+
+	"github.com/abyssparanoia/memeduck"
+)
+
+func TestAggregate(t *testing.T) {
+	testExpr(t, memeduck.Count(memeduck.Ident("a")), `COUNT(a)`)
+	testExpr(t, memeduck.ArrayAgg(memeduck.Ident("a")), `ARRAY_AGG(a)`)
+	testExpr(t, memeduck.Sum(memeduck.Ident("a")), `SUM(a)`)
+	testExpr(t, memeduck.Avg(memeduck.Ident("a")), `AVG(a)`)
+	testExpr(t, memeduck.Min(memeduck.Ident("a")), `MIN(a)`)
+	testExpr(t, memeduck.Max(memeduck.Ident("a")), `MAX(a)`)
+}
+
+func TestAggregateDistinct(t *testing.T) {
+	testExpr(t, memeduck.Count(memeduck.Ident("user_id")).Distinct(), `COUNT(DISTINCT user_id)`)
+	testExpr(t, memeduck.ArrayAgg(memeduck.Ident("x")).Distinct(), `ARRAY_AGG(DISTINCT x)`)
+}
+
+func TestSelectWithAggregate(t *testing.T) {
+	testSelect(t,
+		memeduck.Select("hoge", nil).Items(
+			memeduck.Count(memeduck.Ident("a")).Distinct().As("cnt"),
+			memeduck.Max(memeduck.Ident("b")),
+		),
+		`SELECT COUNT(DISTINCT a) AS cnt, MAX(b) FROM hoge`,
+	)
+	testSelect(t,
+		memeduck.Select("hoge", []string{"a"}).Items(
+			memeduck.ArrayAgg(memeduck.Ident("b")).Distinct(),
+		),
+		`SELECT a, ARRAY_AGG(DISTINCT b) FROM hoge`,
+	)
+}
@@ -0,0 +1,101 @@
+package memeduck
+
+import (
+	stderrors "errors"
+	"reflect"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// MaxMutationsPerCommit is the maximum number of mutations Spanner accepts in a commit.
+	MaxMutationsPerCommit = 80000
+	// MaxStatementBytes is the maximum length of a SQL statement Spanner accepts.
+	MaxStatementBytes = 1 << 20
+)
+
+// SplitByMutations splits the rows of the INSERT statement into statements each of which can be committed
+// within maxMutations mutations. A row counts as one mutation per column plus indexWrites,
+// the number of index columns written for a row by the secondary indexes of the table.
+func (s *InsertStmt) SplitByMutations(maxMutations, indexWrites int) ([]*InsertStmt, error) {
+	if s.query != nil {
+		return nil, errors.New("INSERT ... SELECT can't be split")
+	}
+	rowsV := reflect.ValueOf(s.values)
+	if rowsV.Kind() != reflect.Slice {
+		return nil, errors.Errorf("can't split %T into rows", s.values)
+	}
+	perRow := len(s.cols) + indexWrites
+	if perRow <= 0 || perRow > maxMutations {
+		return nil, errors.Errorf("a row needs %d mutations, which exceeds the limit %d", perRow, maxMutations)
+	}
+	return s.splitRows(rowsV, maxMutations/perRow), nil
+}
+
+// splitRows splits rowsV, the values of the INSERT statement, into statements of at most size rows.
+func (s *InsertStmt) splitRows(rowsV reflect.Value, size int) []*InsertStmt {
+	var stmts []*InsertStmt
+	for i := 0; i < rowsV.Len(); i += size {
+		end := i + size
+		if end > rowsV.Len() {
+			end = rowsV.Len()
+		}
+		stmts = append(stmts, s.Values(rowsV.Slice(i, end).Interface()))
+	}
+	return stmts
+}
+
+// BatchSQL splits the rows of the INSERT statement into statements of at most maxRowsPerStmt rows,
+// and fewer if needed to keep each statement within MaxMutationsPerCommit, and renders them.
+// A statement longer than MaxStatementBytes is split in halves until it fits, down to a single row.
+// Errors of all statements, including rows which don't fit in MaxStatementBytes by themselves,
+// are combined into the returned error.
+func (s *InsertStmt) BatchSQL(maxRowsPerStmt int) ([]string, error) {
+	if maxRowsPerStmt <= 0 {
+		return nil, errors.Errorf("rows per statement must be positive, but got %d", maxRowsPerStmt)
+	}
+	rowsV := reflect.ValueOf(s.values)
+	if s.query != nil || rowsV.Kind() != reflect.Slice {
+		return nil, errors.New("only INSERT statements with values can be batched")
+	}
+	if rowsV.Len() == 0 {
+		return nil, ErrEmptyValues
+	}
+	size := maxRowsPerStmt
+	if len(s.cols) > 0 && size > MaxMutationsPerCommit/len(s.cols) {
+		size = MaxMutationsPerCommit / len(s.cols)
+	}
+	var sqls []string
+	var errs []error
+	for i := 0; i*size < rowsV.Len(); i++ {
+		end := (i + 1) * size
+		if end > rowsV.Len() {
+			end = rowsV.Len()
+		}
+		batch, err := s.batchSQL(rowsV.Slice(i*size, end), i*size)
+		sqls = append(sqls, batch...)
+		if err != nil {
+			errs = append(errs, errors.WithMessagef(err, "statement %d", i))
+		}
+	}
+	return sqls, stderrors.Join(errs...)
+}
+
+// batchSQL renders the INSERT statement of rowsV, the rows from the offset-th row of the values,
+// splitting it in halves while it is longer than MaxStatementBytes.
+func (s *InsertStmt) batchSQL(rowsV reflect.Value, offset int) ([]string, error) {
+	sql, err := s.Values(rowsV.Interface()).SQL()
+	if err != nil {
+		return nil, err
+	}
+	if len(sql) <= MaxStatementBytes {
+		return []string{sql}, nil
+	}
+	if rowsV.Len() == 1 {
+		return nil, errors.Errorf("row %d: %d bytes exceeds the limit of %d bytes", offset, len(sql), MaxStatementBytes)
+	}
+	mid := rowsV.Len() / 2
+	head, headErr := s.batchSQL(rowsV.Slice(0, mid), offset)
+	tail, tailErr := s.batchSQL(rowsV.Slice(mid, rowsV.Len()), offset+mid)
+	return append(head, tail...), stderrors.Join(headErr, tailErr)
+}
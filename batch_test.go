@@ -0,0 +1,76 @@
+package memeduck_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/abyssparanoia/memeduck"
+)
+
+func TestInsertSplitByMutations(t *testing.T) {
+	stmt := memeduck.Insert("hoge", []string{"a", "b"}).OrUpdate().Values([][]interface{}{{1, "a"}, {2, "b"}, {3, "c"}})
+	stmts, err := stmt.SplitByMutations(7, 1)
+	assert.Nil(t, err)
+	assert.Len(t, stmts, 2)
+	testInsert(t, stmts[0], `INSERT OR UPDATE INTO hoge (a, b) VALUES (1, "a"), (2, "b")`)
+	testInsert(t, stmts[1], `INSERT OR UPDATE INTO hoge (a, b) VALUES (3, "c")`)
+
+	stmts, err = stmt.SplitByMutations(memeduck.MaxMutationsPerCommit, 0)
+	assert.Nil(t, err)
+	assert.Len(t, stmts, 1)
+
+	_, err = stmt.SplitByMutations(2, 1)
+	assert.EqualError(t, err, "a row needs 3 mutations, which exceeds the limit 2")
+	_, err = memeduck.Insert("hoge", []string{"a"}).Select(memeduck.Select("fuga", []string{"a"})).SplitByMutations(10, 0)
+	assert.Error(t, err)
+}
+
+func TestInsertBatchSQL(t *testing.T) {
+	rows := make([][]interface{}, 5)
+	for i := range rows {
+		rows[i] = []interface{}{i, "x"}
+	}
+	sqls, err := memeduck.Insert("hoge", []string{"a", "b"}).Values(rows).BatchSQL(2)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{
+		`INSERT INTO hoge (a, b) VALUES (0, "x"), (1, "x")`,
+		`INSERT INTO hoge (a, b) VALUES (2, "x"), (3, "x")`,
+		`INSERT INTO hoge (a, b) VALUES (4, "x")`,
+	}, sqls)
+
+	rows[1] = []interface{}{func() {}, "x"}
+	rows[4] = []interface{}{func() {}, "x"}
+	sqls, err = memeduck.Insert("hoge", []string{"a", "b"}).Values(rows).BatchSQL(2)
+	assert.Equal(t, []string{`INSERT INTO hoge (a, b) VALUES (2, "x"), (3, "x")`}, sqls)
+	assert.EqualError(t, err, "statement 0: can't convert []interface {} into SQL row: can't convert func() into SQL expr\n"+
+		"statement 2: can't convert []interface {} into SQL row: can't convert func() into SQL expr")
+
+	_, err = memeduck.Insert("hoge", []string{"a"}).Values([][]interface{}{{1}}).BatchSQL(0)
+	assert.Error(t, err)
+	_, err = memeduck.Insert("hoge", []string{"a"}).Values([][]interface{}{}).BatchSQL(2)
+	assert.ErrorIs(t, err, memeduck.ErrEmptyValues)
+}
+
+func TestInsertBatchSQLSplitsLongStatements(t *testing.T) {
+	large := strings.Repeat("x", memeduck.MaxStatementBytes/3)
+	rows := make([][]interface{}, 5)
+	for i := range rows {
+		rows[i] = []interface{}{i, large}
+	}
+	sqls, err := memeduck.Insert("hoge", []string{"a", "b"}).Values(rows).BatchSQL(5)
+	assert.Nil(t, err)
+	assert.Len(t, sqls, 3)
+	for i, sql := range sqls {
+		assert.LessOrEqual(t, len(sql), memeduck.MaxStatementBytes)
+		assert.True(t, strings.HasPrefix(sql, `INSERT INTO hoge (a, b) VALUES (`+[]string{"0", "2", "3"}[i]+`, "`))
+	}
+
+	rows[3] = []interface{}{3, large + large + large}
+	sqls, err = memeduck.Insert("hoge", []string{"a", "b"}).Values(rows).BatchSQL(5)
+	assert.Len(t, sqls, 3)
+	assert.EqualError(t, err, fmt.Sprintf("statement 0: row 3: %d bytes exceeds the limit of %d bytes",
+		len(`INSERT INTO hoge (a, b) VALUES (3, "")`)+len(large)*3, memeduck.MaxStatementBytes))
+}
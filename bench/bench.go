@@ -0,0 +1,114 @@
+// Package bench provides reusable benchmark scenarios of rendering memeduck statements,
+// so that rendering performance regressions can be detected.
+package bench
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/abyssparanoia/memeduck"
+)
+
+// Stmt is a statement which can be rendered as SQL.
+type Stmt interface {
+	SQL() (string, error)
+}
+
+// Scenario is a statement to be rendered repeatedly in a benchmark.
+type Scenario struct {
+	Name string
+	Stmt Stmt
+}
+
+// Run renders the statement of the scenario b.N times, reporting allocations.
+func Run(b *testing.B, s *Scenario) {
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := s.Stmt.SQL(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// RunAll runs all the scenarios as sub-benchmarks.
+func RunAll(b *testing.B, scenarios []*Scenario) {
+	for _, s := range scenarios {
+		s := s
+		b.Run(s.Name, func(b *testing.B) {
+			Run(b, s)
+		})
+	}
+}
+
+// Scenarios returns the default scenarios.
+func Scenarios() []*Scenario {
+	return []*Scenario{
+		WideStructInsert(100),
+		DeepConditionTree(100),
+		LargeInList(1000),
+		PointLookup(),
+	}
+}
+
+type wideRow struct {
+	C00, C01, C02, C03, C04, C05, C06, C07, C08, C09 string
+	C10, C11, C12, C13, C14, C15, C16, C17, C18, C19 int64
+	C20, C21, C22, C23, C24, C25, C26, C27, C28, C29 bool
+}
+
+// WideStructInsert is an INSERT of rows of a struct with 30 fields.
+func WideStructInsert(rows int) *Scenario {
+	cols := make([]string, 0, 30)
+	for i := 0; i < 30; i++ {
+		cols = append(cols, fmt.Sprintf("C%02d", i))
+	}
+	values := make([]wideRow, rows)
+	for i := range values {
+		values[i] = wideRow{C00: fmt.Sprint(i), C10: int64(i), C20: i%2 == 0}
+	}
+	return &Scenario{
+		Name: fmt.Sprintf("WideStructInsert/%d", rows),
+		Stmt: memeduck.Insert("wide", cols).Values(values),
+	}
+}
+
+// DeepConditionTree is a SELECT whose WHERE clause nests AND and OR depth times.
+func DeepConditionTree(depth int) *Scenario {
+	var cond memeduck.WhereCond = memeduck.Eq(memeduck.Ident("c0"), 0)
+	for i := 1; i < depth; i++ {
+		c := memeduck.Eq(memeduck.Ident(fmt.Sprintf("c%d", i)), i)
+		if i%2 == 0 {
+			cond = memeduck.And(cond, c)
+		} else {
+			cond = memeduck.Or(cond, c)
+		}
+	}
+	return &Scenario{
+		Name: fmt.Sprintf("DeepConditionTree/%d", depth),
+		Stmt: memeduck.Select("deep", []string{"id"}).Where(cond),
+	}
+}
+
+// LargeInList is a SELECT with `IN UNNEST` of n values.
+func LargeInList(n int) *Scenario {
+	ids := make([]int64, n)
+	for i := range ids {
+		ids[i] = int64(i)
+	}
+	return &Scenario{
+		Name: fmt.Sprintf("LargeInList/%d", n),
+		Stmt: memeduck.Select("large", []string{"id"}).Where(memeduck.In(memeduck.Ident("id"), memeduck.Unnest(ids))),
+	}
+}
+
+// PointLookup is a small SELECT by key, which services build thousands of times per second.
+// It consists of common identifiers, booleans and small integers.
+func PointLookup() *Scenario {
+	return &Scenario{
+		Name: "PointLookup",
+		Stmt: memeduck.Select("user", []string{"id", "name", "status"}).
+			Where(memeduck.Eq(memeduck.Ident("id"), 42), memeduck.Eq(memeduck.Ident("deleted"), false)).
+			Limit(1),
+	}
+}
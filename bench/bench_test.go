@@ -0,0 +1,20 @@
+package bench_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/abyssparanoia/memeduck/bench"
+)
+
+func TestScenarios(t *testing.T) {
+	for _, s := range bench.Scenarios() {
+		_, err := s.Stmt.SQL()
+		assert.Nil(t, err, s.Name)
+	}
+}
+
+func BenchmarkScenarios(b *testing.B) {
+	bench.RunAll(b, bench.Scenarios())
+}
@@ -0,0 +1,132 @@
+// Package bindgen generates Go structs binding query parameters of memeduck statements.
+package bindgen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Stmt is a statement whose query parameters can be inferred.
+// All statements built by memeduck implement this.
+type Stmt interface {
+	ParamTypes() (map[string]string, error)
+}
+
+// Generate emits Go source code of package pkg declaring a struct named typeName,
+// which has a field per query parameter of stmt and a Bind method producing the parameter map
+// for spanner.Statement.Params.
+// Field types are derived from the inferred Spanner types; parameters whose type can't be inferred become interface{}.
+func Generate(pkg, typeName string, stmt Stmt) ([]byte, error) {
+	types, err := stmt.ParamTypes()
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(types))
+	for name := range types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	imports := make(map[string]bool)
+	fields := make([]string, 0, len(names))
+	goTypes := make([]string, 0, len(names))
+	seen := make(map[string]string, len(names))
+	for _, name := range names {
+		field := fieldName(name)
+		if other, ok := seen[field]; ok {
+			return nil, errors.Errorf("parameters @%s and @%s are mapped to the same field %s", other, name, field)
+		}
+		seen[field] = name
+		goType, err := goTypeOf(types[name], imports)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "parameter @%s", name)
+		}
+		fields = append(fields, field)
+		goTypes = append(goTypes, goType)
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by memeduck/bindgen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package %s\n\n", pkg)
+	if len(imports) > 0 {
+		paths := make([]string, 0, len(imports))
+		for path := range imports {
+			paths = append(paths, path)
+		}
+		sort.Strings(paths)
+		fmt.Fprintf(&buf, "import (\n")
+		for _, path := range paths {
+			fmt.Fprintf(&buf, "%q\n", path)
+		}
+		fmt.Fprintf(&buf, ")\n\n")
+	}
+	fmt.Fprintf(&buf, "// %s is a set of query parameters.\n", typeName)
+	fmt.Fprintf(&buf, "type %s struct {\n", typeName)
+	for i := range names {
+		fmt.Fprintf(&buf, "%s %s\n", fields[i], goTypes[i])
+	}
+	fmt.Fprintf(&buf, "}\n\n")
+	fmt.Fprintf(&buf, "// Bind returns the query parameters as a map for spanner.Statement.Params.\n")
+	fmt.Fprintf(&buf, "func (p *%s) Bind() map[string]interface{} {\n", typeName)
+	fmt.Fprintf(&buf, "return map[string]interface{}{\n")
+	for i, name := range names {
+		fmt.Fprintf(&buf, "%q: p.%s,\n", name, fields[i])
+	}
+	fmt.Fprintf(&buf, "}\n}\n")
+	return format.Source(buf.Bytes())
+}
+
+// fieldName converts a parameter name like user_id into an exported field name like UserId.
+func fieldName(name string) string {
+	var b strings.Builder
+	for _, part := range strings.Split(name, "_") {
+		if part == "" {
+			continue
+		}
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	field := b.String()
+	if field == "" || !('A' <= field[0] && field[0] <= 'Z') {
+		field = "P" + field
+	}
+	return field
+}
+
+func goTypeOf(spannerType string, imports map[string]bool) (string, error) {
+	if strings.HasPrefix(spannerType, "ARRAY<") && strings.HasSuffix(spannerType, ">") {
+		elem, err := goTypeOf(spannerType[len("ARRAY<"):len(spannerType)-1], imports)
+		if err != nil {
+			return "", err
+		}
+		return "[]" + elem, nil
+	}
+	switch spannerType {
+	case "":
+		return "interface{}", nil
+	case "BOOL":
+		return "bool", nil
+	case "INT64":
+		return "int64", nil
+	case "FLOAT64":
+		return "float64", nil
+	case "STRING":
+		return "string", nil
+	case "BYTES":
+		return "[]byte", nil
+	case "DATE":
+		imports["cloud.google.com/go/civil"] = true
+		return "civil.Date", nil
+	case "TIMESTAMP":
+		imports["time"] = true
+		return "time.Time", nil
+	case "NUMERIC":
+		imports["math/big"] = true
+		return "big.Rat", nil
+	}
+	return "", errors.Errorf("unsupported type %s", spannerType)
+}
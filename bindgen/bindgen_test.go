@@ -0,0 +1,68 @@
+package bindgen_test
+
+import (
+	"testing"
+	"time"
+
+	"cloud.google.com/go/civil"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/abyssparanoia/memeduck"
+	"github.com/abyssparanoia/memeduck/bindgen"
+)
+
+func TestGenerate(t *testing.T) {
+	stmt := memeduck.Select("user", []string{"name"}).Where(
+		memeduck.Eq(memeduck.Ident("id"), memeduck.Param("user_id")),
+		memeduck.Gt(memeduck.Param("min_age"), 18),
+		memeduck.In(memeduck.Param("name"), memeduck.Unnest([]string{"a"})),
+		memeduck.Eq(memeduck.Param("created_at"), memeduck.Param("created_at")),
+	)
+	src, err := bindgen.Generate("query", "FindUserParams", stmt)
+	assert.Nil(t, err)
+	assert.Equal(t, `// Code generated by memeduck/bindgen. DO NOT EDIT.
+
+package query
+
+// FindUserParams is a set of query parameters.
+type FindUserParams struct {
+	CreatedAt interface{}
+	MinAge    int64
+	Name      string
+	UserId    interface{}
+}
+
+// Bind returns the query parameters as a map for spanner.Statement.Params.
+func (p *FindUserParams) Bind() map[string]interface{} {
+	return map[string]interface{}{
+		"created_at": p.CreatedAt,
+		"min_age":    p.MinAge,
+		"name":       p.Name,
+		"user_id":    p.UserId,
+	}
+}
+`, string(src))
+}
+
+func TestGenerateWithImports(t *testing.T) {
+	stmt := memeduck.Delete("user").Where(
+		memeduck.In(memeduck.Param("id"), memeduck.Unnest([]int64{1})),
+		memeduck.Lt(memeduck.Param("before"), time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)),
+		memeduck.Eq(memeduck.Param("day"), civil.Date{Year: 2020, Month: 1, Day: 1}),
+	)
+	src, err := bindgen.Generate("query", "DeleteUsersParams", stmt)
+	assert.Nil(t, err)
+	assert.Contains(t, string(src), "import (\n\t\"cloud.google.com/go/civil\"\n\t\"time\"\n)\n")
+	assert.Contains(t, string(src), "Before time.Time\n")
+	assert.Contains(t, string(src), "Day    civil.Date\n")
+	assert.Contains(t, string(src), "Id     int64\n")
+}
+
+func TestGenerateWithConflictingNames(t *testing.T) {
+	stmt := memeduck.Delete("user").Where(
+		memeduck.Eq(memeduck.Ident("a"), memeduck.Param("user_id")),
+		memeduck.Eq(memeduck.Ident("b"), memeduck.Param("userId")),
+	)
+	_, err := bindgen.Generate("query", "Params", stmt)
+	assert.Error(t, err)
+}
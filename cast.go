@@ -0,0 +1,56 @@
+package memeduck
+
+import (
+	"github.com/cloudspannerecosystem/memefish/ast"
+
+	"github.com/abyssparanoia/memeduck/internal"
+)
+
+// CastExpr is a type conversion expression.
+type CastExpr struct {
+	x        interface{}
+	typeName string
+	safe     bool
+	as       string
+}
+
+// Cast creates `CAST(x AS typeName)` expression.
+// It is useful to give an explicit type to untyped parameters, e.g. Cast(Param("p"), "INT64").
+func Cast(x interface{}, typeName string) *CastExpr {
+	return &CastExpr{x: x, typeName: typeName}
+}
+
+// SafeCast creates `SAFE_CAST(x AS typeName)` expression, which returns NULL instead of an error on failure.
+func SafeCast(x interface{}, typeName string) *CastExpr {
+	return &CastExpr{x: x, typeName: typeName, safe: true}
+}
+
+// As sets an alias used when the expression appears in SELECT results.
+func (e *CastExpr) As(as string) *CastExpr {
+	var t = *e
+	t.as = as
+	return &t
+}
+
+func (e *CastExpr) ToASTExpr() (ast.Expr, error) {
+	x, err := internal.ToExpr(e.x)
+	if err != nil {
+		return nil, err
+	}
+	typ, err := internal.ParseType(e.typeName)
+	if err != nil {
+		return nil, err
+	}
+	if e.safe {
+		return &internal.SafeCastExpr{Expr: x, Type: typ}, nil
+	}
+	return &ast.CastExpr{Expr: x, Type: typ}, nil
+}
+
+func (e *CastExpr) ToAST() (ast.SelectItem, error) {
+	expr, err := e.ToASTExpr()
+	if err != nil {
+		return nil, err
+	}
+	return toASTSelectItem(expr, e.as), nil
+}
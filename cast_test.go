@@ -0,0 +1,30 @@
+package memeduck_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/abyssparanoia/memeduck"
+)
+
+func TestCast(t *testing.T) {
+	testExpr(t, memeduck.Cast(memeduck.Param("p"), "INT64"), `CAST(@p AS INT64)`)
+	testExpr(t, memeduck.Cast("1.5", "numeric"), `CAST("1.5" AS NUMERIC)`)
+	testExpr(t, memeduck.Cast(memeduck.Param("ids"), "ARRAY<STRING>"), `CAST(@ids AS ARRAY<STRING>)`)
+	testExpr(t, memeduck.SafeCast(memeduck.Ident("s"), "INT64"), `SAFE_CAST(s AS INT64)`)
+	testWhere(t,
+		memeduck.Eq(memeduck.Ident("id"), memeduck.Cast(memeduck.Param("id"), "INT64")),
+		`id = CAST(@id AS INT64)`,
+	)
+	testWhere(t,
+		memeduck.Eq(memeduck.SafeCast(memeduck.Ident("s"), "INT64"), 1),
+		`(SAFE_CAST(s AS INT64)) = 1`,
+	)
+	testSelect(t,
+		memeduck.Select("hoge", []string{}).Items(memeduck.Cast(memeduck.Ident("a"), "STRING").As("a_str")),
+		`SELECT CAST(a AS STRING) AS a_str FROM hoge`,
+	)
+	_, err := memeduck.Cast(memeduck.Param("p"), "NOT A TYPE").ToASTExpr()
+	assert.Error(t, err)
+}
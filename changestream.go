@@ -0,0 +1,297 @@
+package memeduck
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cloudspannerecosystem/memefish/ast"
+	"github.com/pkg/errors"
+
+	"github.com/abyssparanoia/memeduck/internal"
+)
+
+// ValueCaptureType decides which values of modified rows are recorded by a change stream.
+type ValueCaptureType string
+
+const (
+	OldAndNewValues    ValueCaptureType = "OLD_AND_NEW_VALUES"
+	NewValues          ValueCaptureType = "NEW_VALUES"
+	NewRow             ValueCaptureType = "NEW_ROW"
+	NewRowAndOldValues ValueCaptureType = "NEW_ROW_AND_OLD_VALUES"
+)
+
+// changeStreamFor is the watch list of a change stream, either FOR ALL or tables with optional columns.
+type changeStreamFor struct {
+	all    bool
+	tables []*changeStreamTable
+}
+
+type changeStreamTable struct {
+	name string
+	cols []string
+}
+
+func (f changeStreamFor) forAll() changeStreamFor {
+	return changeStreamFor{all: true, tables: f.tables}
+}
+
+func (f changeStreamFor) forTable(table string, cols []string) changeStreamFor {
+	tables := append(append([]*changeStreamTable(nil), f.tables...), &changeStreamTable{name: table, cols: append([]string(nil), cols...)})
+	return changeStreamFor{all: f.all, tables: tables}
+}
+
+func (f changeStreamFor) isEmpty() bool {
+	return !f.all && len(f.tables) == 0
+}
+
+func (f changeStreamFor) toAST() (ast.ChangeStreamFor, error) {
+	if f.all {
+		if len(f.tables) > 0 {
+			return nil, errors.New("change stream can't watch both all tables and specific tables")
+		}
+		return &ast.ChangeStreamForAll{}, nil
+	}
+	var errs errorList
+	tables := &ast.ChangeStreamForTables{}
+	for _, t := range f.tables {
+		name, err := toASTIdent(t.name)
+		errs.add(err)
+		table := &ast.ChangeStreamForTable{TableName: name}
+		for _, c := range t.cols {
+			col, err := toASTIdent(c)
+			errs.add(err)
+			table.Columns = append(table.Columns, col)
+		}
+		tables.Tables = append(tables.Tables, table)
+	}
+	if err := errs.err(); err != nil {
+		return nil, err
+	}
+	return tables, nil
+}
+
+type changeStreamOptions struct {
+	retentionPeriod  time.Duration
+	valueCaptureType ValueCaptureType
+}
+
+func (o changeStreamOptions) isEmpty() bool {
+	return o.retentionPeriod == 0 && o.valueCaptureType == ""
+}
+
+func (o changeStreamOptions) toAST() (*ast.ChangeStreamOptions, error) {
+	var records []*ast.ChangeStreamOptionsRecord
+	if o.retentionPeriod != 0 {
+		period, err := formatRetentionPeriod(o.retentionPeriod)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, &ast.ChangeStreamOptionsRecord{Key: &ast.Ident{Name: "retention_period"}, Value: internal.StringLit(period)})
+	}
+	switch o.valueCaptureType {
+	case "":
+	case OldAndNewValues, NewValues, NewRow, NewRowAndOldValues:
+		records = append(records, &ast.ChangeStreamOptionsRecord{Key: &ast.Ident{Name: "value_capture_type"}, Value: internal.StringLit(string(o.valueCaptureType))})
+	default:
+		return nil, errors.Errorf("unknown value capture type %q", string(o.valueCaptureType))
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+	return &ast.ChangeStreamOptions{Records: records}, nil
+}
+
+// formatRetentionPeriod formats d in the largest unit of the ones Spanner accepts, e.g. "7d" or "36h".
+func formatRetentionPeriod(d time.Duration) (string, error) {
+	if d <= 0 || d%time.Second != 0 {
+		return "", errors.Errorf("retention period must be a positive number of seconds, but got %s", d)
+	}
+	for _, u := range []struct {
+		unit time.Duration
+		name string
+	}{{24 * time.Hour, "d"}, {time.Hour, "h"}, {time.Minute, "m"}} {
+		if d%u.unit == 0 {
+			return fmt.Sprintf("%d%s", d/u.unit, u.name), nil
+		}
+	}
+	return fmt.Sprintf("%ds", d/time.Second), nil
+}
+
+// CreateChangeStreamStmt is a CREATE CHANGE STREAM statement.
+type CreateChangeStreamStmt struct {
+	name    string
+	watch   changeStreamFor
+	options changeStreamOptions
+}
+
+// CreateChangeStream creates a new CreateChangeStreamStmt with given change stream name.
+// The change stream watches nothing unless ForAll or ForTable is specified.
+func CreateChangeStream(name string) *CreateChangeStreamStmt {
+	return &CreateChangeStreamStmt{name: name}
+}
+
+// ForAll makes the change stream watch all tables and columns.
+func (s *CreateChangeStreamStmt) ForAll() *CreateChangeStreamStmt {
+	var t = *s
+	t.watch = t.watch.forAll()
+	return &t
+}
+
+// ForTable appends table to the tables watched by the change stream.
+// Only the key columns and cols are watched if cols are given, or all columns of table otherwise.
+func (s *CreateChangeStreamStmt) ForTable(table string, cols ...string) *CreateChangeStreamStmt {
+	var t = *s
+	t.watch = t.watch.forTable(table, cols)
+	return &t
+}
+
+// RetentionPeriod sets retention_period option, for how long change records are kept.
+func (s *CreateChangeStreamStmt) RetentionPeriod(d time.Duration) *CreateChangeStreamStmt {
+	var t = *s
+	t.options.retentionPeriod = d
+	return &t
+}
+
+// ValueCaptureType sets value_capture_type option.
+func (s *CreateChangeStreamStmt) ValueCaptureType(typ ValueCaptureType) *CreateChangeStreamStmt {
+	var t = *s
+	t.options.valueCaptureType = typ
+	return &t
+}
+
+func (s *CreateChangeStreamStmt) SQL() (string, error) {
+	stmt, err := s.toAST()
+	if err != nil {
+		return "", err
+	}
+	return renderSQL(stmt)
+}
+
+func (s *CreateChangeStreamStmt) toAST() (*ast.CreateChangeStream, error) {
+	name, err := toASTIdent(s.name)
+	if err != nil {
+		return nil, err
+	}
+	cs := &ast.CreateChangeStream{Name: name}
+	var errs errorList
+	if !s.watch.isEmpty() {
+		cs.For, err = s.watch.toAST()
+		errs.add(err)
+	}
+	cs.Options, err = s.options.toAST()
+	errs.add(err)
+	if err := errs.err(); err != nil {
+		return nil, err
+	}
+	return cs, nil
+}
+
+// AlterChangeStreamStmt is an ALTER CHANGE STREAM statement. Spanner applies one alteration per statement,
+// so building the statement fails unless exactly one of the watch list, DropForAll and the options is specified.
+type AlterChangeStreamStmt struct {
+	name       string
+	watch      changeStreamFor
+	dropForAll bool
+	options    changeStreamOptions
+}
+
+// AlterChangeStream creates a new AlterChangeStreamStmt with given change stream name.
+func AlterChangeStream(name string) *AlterChangeStreamStmt {
+	return &AlterChangeStreamStmt{name: name}
+}
+
+// SetForAll makes the change stream watch all tables and columns.
+func (s *AlterChangeStreamStmt) SetForAll() *AlterChangeStreamStmt {
+	var t = *s
+	t.watch = t.watch.forAll()
+	return &t
+}
+
+// SetForTable appends table to the new watch list of the change stream, which replaces the current one.
+// See CreateChangeStreamStmt.ForTable.
+func (s *AlterChangeStreamStmt) SetForTable(table string, cols ...string) *AlterChangeStreamStmt {
+	var t = *s
+	t.watch = t.watch.forTable(table, cols)
+	return &t
+}
+
+// DropForAll makes the change stream watch nothing.
+func (s *AlterChangeStreamStmt) DropForAll() *AlterChangeStreamStmt {
+	var t = *s
+	t.dropForAll = true
+	return &t
+}
+
+// RetentionPeriod sets retention_period option. See CreateChangeStreamStmt.RetentionPeriod.
+func (s *AlterChangeStreamStmt) RetentionPeriod(d time.Duration) *AlterChangeStreamStmt {
+	var t = *s
+	t.options.retentionPeriod = d
+	return &t
+}
+
+// ValueCaptureType sets value_capture_type option.
+func (s *AlterChangeStreamStmt) ValueCaptureType(typ ValueCaptureType) *AlterChangeStreamStmt {
+	var t = *s
+	t.options.valueCaptureType = typ
+	return &t
+}
+
+func (s *AlterChangeStreamStmt) SQL() (string, error) {
+	stmt, err := s.toAST()
+	if err != nil {
+		return "", err
+	}
+	return renderSQL(stmt)
+}
+
+func (s *AlterChangeStreamStmt) toAST() (*ast.AlterChangeStream, error) {
+	name, err := toASTIdent(s.name)
+	if err != nil {
+		return nil, err
+	}
+	n := 0
+	for _, set := range []bool{!s.watch.isEmpty(), s.dropForAll, !s.options.isEmpty()} {
+		if set {
+			n++
+		}
+	}
+	if n != 1 {
+		return nil, errors.Errorf("ALTER CHANGE STREAM must have exactly one alteration, but %d are specified", n)
+	}
+	cs := &ast.AlterChangeStream{Name: name}
+	switch {
+	case s.dropForAll:
+		cs.ChangeStreamAlteration = &ast.ChangeStreamDropForAll{}
+	case !s.watch.isEmpty():
+		watch, err := s.watch.toAST()
+		if err != nil {
+			return nil, err
+		}
+		cs.ChangeStreamAlteration = &ast.ChangeStreamSetFor{For: watch}
+	default:
+		options, err := s.options.toAST()
+		if err != nil {
+			return nil, err
+		}
+		cs.ChangeStreamAlteration = &internal.ChangeStreamSetOptions{ChangeStreamSetOptions: ast.ChangeStreamSetOptions{Options: options}}
+	}
+	return cs, nil
+}
+
+// DropChangeStreamStmt is a DROP CHANGE STREAM statement.
+type DropChangeStreamStmt struct {
+	name string
+}
+
+// DropChangeStream creates a new DropChangeStreamStmt with given change stream name.
+func DropChangeStream(name string) *DropChangeStreamStmt {
+	return &DropChangeStreamStmt{name: name}
+}
+
+func (s *DropChangeStreamStmt) SQL() (string, error) {
+	name, err := toASTIdent(s.name)
+	if err != nil {
+		return "", err
+	}
+	return renderSQL(&ast.DropChangeStream{Name: name})
+}
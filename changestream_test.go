@@ -0,0 +1,84 @@
+package memeduck_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/abyssparanoia/memeduck"
+)
+
+func TestCreateChangeStream(t *testing.T) {
+	for _, c := range []struct {
+		stmt     *memeduck.CreateChangeStreamStmt
+		expected string
+	}{
+		{
+			memeduck.CreateChangeStream("everything").ForAll(),
+			"CREATE CHANGE STREAM everything FOR ALL",
+		},
+		{
+			memeduck.CreateChangeStream("user_changes").
+				ForTable("user").
+				ForTable("user_item", "count", "updated_at").
+				RetentionPeriod(36 * time.Hour).
+				ValueCaptureType(memeduck.NewRow),
+			`CREATE CHANGE STREAM user_changes FOR user, user_item(count, updated_at) OPTIONS (retention_period="36h", value_capture_type="NEW_ROW")`,
+		},
+		{
+			memeduck.CreateChangeStream("nothing").RetentionPeriod(7 * 24 * time.Hour),
+			`CREATE CHANGE STREAM nothing OPTIONS (retention_period="7d")`,
+		},
+	} {
+		sql, err := c.stmt.SQL()
+		assert.Nil(t, err)
+		assert.Equal(t, c.expected, sql)
+	}
+
+	_, err := memeduck.CreateChangeStream("s").ForAll().ForTable("user").SQL()
+	assert.EqualError(t, err, "change stream can't watch both all tables and specific tables")
+	_, err = memeduck.CreateChangeStream("s").RetentionPeriod(time.Millisecond).ValueCaptureType("ALL").SQL()
+	assert.EqualError(t, err, "retention period must be a positive number of seconds, but got 1ms")
+	_, err = memeduck.CreateChangeStream("s").ValueCaptureType("ALL").SQL()
+	assert.EqualError(t, err, `unknown value capture type "ALL"`)
+}
+
+func TestAlterChangeStream(t *testing.T) {
+	for _, c := range []struct {
+		stmt     *memeduck.AlterChangeStreamStmt
+		expected string
+	}{
+		{
+			memeduck.AlterChangeStream("user_changes").SetForAll(),
+			"ALTER CHANGE STREAM user_changes SET FOR ALL",
+		},
+		{
+			memeduck.AlterChangeStream("user_changes").SetForTable("user", "name"),
+			"ALTER CHANGE STREAM user_changes SET FOR user(name)",
+		},
+		{
+			memeduck.AlterChangeStream("user_changes").DropForAll(),
+			"ALTER CHANGE STREAM user_changes DROP FOR ALL",
+		},
+		{
+			memeduck.AlterChangeStream("user_changes").RetentionPeriod(90 * time.Minute).ValueCaptureType(memeduck.OldAndNewValues),
+			`ALTER CHANGE STREAM user_changes SET OPTIONS (retention_period="90m", value_capture_type="OLD_AND_NEW_VALUES")`,
+		},
+	} {
+		sql, err := c.stmt.SQL()
+		assert.Nil(t, err)
+		assert.Equal(t, c.expected, sql)
+	}
+
+	_, err := memeduck.AlterChangeStream("user_changes").SQL()
+	assert.EqualError(t, err, "ALTER CHANGE STREAM must have exactly one alteration, but 0 are specified")
+	_, err = memeduck.AlterChangeStream("user_changes").DropForAll().RetentionPeriod(time.Hour).SQL()
+	assert.EqualError(t, err, "ALTER CHANGE STREAM must have exactly one alteration, but 2 are specified")
+}
+
+func TestDropChangeStream(t *testing.T) {
+	sql, err := memeduck.DropChangeStream("user_changes").SQL()
+	assert.Nil(t, err)
+	assert.Equal(t, "DROP CHANGE STREAM user_changes", sql)
+}
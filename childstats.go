@@ -0,0 +1,140 @@
+package memeduck
+
+import (
+	"github.com/cloudspannerecosystem/memefish/ast"
+	"github.com/pkg/errors"
+
+	"github.com/abyssparanoia/memeduck/internal"
+)
+
+// ChildStat is an aggregate over a column of the child table in ChildStatsStmt, like `SUM(Album.Duration) AS total`.
+type ChildStat struct {
+	// Func is the name of the aggregate function, e.g. "COUNT", "SUM" or "MAX".
+	Func string
+	// Column is the column of the child table to be aggregated.
+	// If it is empty for COUNT, the last primary key column of the child table is counted.
+	Column string
+	As     string
+}
+
+// ChildStatsStmt builds a query of parent rows with aggregates of their child rows in an interleaved table, i.e.
+//
+//	SELECT Parent.Key, ..., COUNT(Child.ChildKey) AS n, ...
+//	FROM Parent LEFT OUTER JOIN Child ON Parent.Key = Child.Key
+//	GROUP BY Parent.Key, ...
+//
+// Parents without children are also returned, with COUNT of 0 and other aggregates of NULL.
+type ChildStatsStmt struct {
+	parent *Table
+	child  *Table
+	stats  []*ChildStat
+	cols   []string
+	conds  []WhereCond
+}
+
+// ChildStats creates a new ChildStatsStmt aggregating stats of the child table for each row of the parent table.
+// The primary key of child must start with the primary key of parent, like interleaved tables.
+func ChildStats(parent, child *Table, stats ...*ChildStat) *ChildStatsStmt {
+	return &ChildStatsStmt{
+		parent: parent,
+		child:  child,
+		stats:  stats,
+	}
+}
+
+// Columns adds non-key columns of the parent table to the results, which are also grouped by.
+func (s *ChildStatsStmt) Columns(cols ...string) *ChildStatsStmt {
+	var t = *s
+	t.cols = append(append([]string(nil), s.cols...), cols...)
+	return &t
+}
+
+// Where adds WHERE clauses to the query. Columns should be qualified by table names like IdentPath("Singer.Genre").
+func (s *ChildStatsStmt) Where(conds ...WhereCond) *ChildStatsStmt {
+	var t = *s
+	t.conds = append(append([]WhereCond(nil), s.conds...), conds...)
+	return &t
+}
+
+func (s *ChildStatsStmt) SQL() (string, error) {
+	stmt, err := s.toAST()
+	if err != nil {
+		return "", err
+	}
+	return renderSQL(stmt)
+}
+
+func (s *ChildStatsStmt) toAST() (*ast.Select, error) {
+	pk, ck := s.parent.PrimaryKey, s.child.PrimaryKey
+	if len(pk) == 0 || len(ck) <= len(pk) {
+		return nil, errors.Errorf("table %s is not a child of table %s", s.child.Name, s.parent.Name)
+	}
+	var on ast.Expr
+	var groups []ast.Expr
+	var items []ast.SelectItem
+	for i, k := range pk {
+		if ck[i].Column != k.Column {
+			return nil, errors.Errorf("table %s is not a child of table %s", s.child.Name, s.parent.Name)
+		}
+		cond := &ast.BinaryExpr{
+			Op:    ast.OpEqual,
+			Left:  qualified(s.parent.Name, k.Column),
+			Right: qualified(s.child.Name, k.Column),
+		}
+		if on == nil {
+			on = cond
+		} else {
+			on = &ast.BinaryExpr{Op: ast.OpAnd, Left: on, Right: cond}
+		}
+		groups = append(groups, qualified(s.parent.Name, k.Column))
+	}
+	for _, c := range s.cols {
+		if s.parent.Column(c) == nil {
+			return nil, errors.Errorf("table %s does not have column %s", s.parent.Name, c)
+		}
+		groups = append(groups, qualified(s.parent.Name, c))
+	}
+	for _, g := range groups {
+		items = append(items, &ast.ExprSelectItem{Expr: g})
+	}
+	if len(s.stats) == 0 {
+		return nil, errors.New("no stats specified")
+	}
+	for _, st := range s.stats {
+		col := st.Column
+		if col == "" {
+			col = ck[len(ck)-1].Column
+		} else if s.child.Column(col) == nil {
+			return nil, errors.Errorf("table %s does not have column %s", s.child.Name, col)
+		}
+		item, err := newAggregate(st.Func, IdentPath(s.child.Name+"."+col)).As(st.As).ToAST()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	sel := &ast.Select{
+		Results: items,
+		From: &ast.From{
+			Source: &ast.Join{
+				Op:    ast.LeftOuterJoin,
+				Left:  &ast.TableName{Table: internal.Ident(s.parent.Name)},
+				Right: &ast.TableName{Table: internal.Ident(s.child.Name)},
+				Cond:  &ast.On{Expr: on},
+			},
+		},
+		GroupBy: &ast.GroupBy{Exprs: groups},
+	}
+	if len(s.conds) > 0 {
+		where, err := And(s.conds...).ToASTWhere()
+		if err != nil {
+			return nil, err
+		}
+		sel.Where = where
+	}
+	return sel, nil
+}
+
+func qualified(table, col string) *ast.Path {
+	return &ast.Path{Idents: []*ast.Ident{internal.Ident(table), internal.Ident(col)}}
+}
@@ -0,0 +1,47 @@
+package memeduck_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/abyssparanoia/memeduck"
+)
+
+var (
+	testSingerTable = &memeduck.Table{
+		Name:       "Singer",
+		Columns:    []*memeduck.Column{{Name: "SingerId", Type: "INT64"}, {Name: "Name", Type: "STRING(MAX)"}},
+		PrimaryKey: []*memeduck.KeyPart{{Column: "SingerId"}},
+	}
+	testAlbumTable = &memeduck.Table{
+		Name:       "Album",
+		Columns:    []*memeduck.Column{{Name: "SingerId", Type: "INT64"}, {Name: "AlbumId", Type: "INT64"}, {Name: "Duration", Type: "INT64"}},
+		PrimaryKey: []*memeduck.KeyPart{{Column: "SingerId"}, {Column: "AlbumId"}},
+	}
+)
+
+func ExampleChildStats() {
+	query, _ := memeduck.ChildStats(testSingerTable, testAlbumTable,
+		&memeduck.ChildStat{Func: "COUNT", As: "album_count"},
+		&memeduck.ChildStat{Func: "SUM", Column: "Duration", As: "total_duration"},
+	).Columns("Name").SQL()
+	fmt.Println(query)
+	// Output: SELECT Singer.SingerId, Singer.Name, COUNT(Album.AlbumId) AS album_count, SUM(Album.Duration) AS total_duration FROM Singer LEFT OUTER JOIN Album ON Singer.SingerId = Album.SingerId GROUP BY Singer.SingerId, Singer.Name
+}
+
+func TestChildStats(t *testing.T) {
+	sql, err := memeduck.ChildStats(testSingerTable, testAlbumTable, &memeduck.ChildStat{Func: "MAX", Column: "Duration", As: "longest"}).
+		Where(memeduck.Eq(memeduck.IdentPath("Singer.Name"), "foo")).
+		SQL()
+	assert.Nil(t, err)
+	assert.Equal(t, `SELECT Singer.SingerId, MAX(Album.Duration) AS longest FROM Singer LEFT OUTER JOIN Album ON Singer.SingerId = Album.SingerId WHERE Singer.Name = "foo" GROUP BY Singer.SingerId`, sql)
+
+	_, err = memeduck.ChildStats(testAlbumTable, testSingerTable, &memeduck.ChildStat{Func: "COUNT", As: "n"}).SQL()
+	assert.EqualError(t, err, "table Singer is not a child of table Album")
+	_, err = memeduck.ChildStats(testSingerTable, testAlbumTable, &memeduck.ChildStat{Func: "SUM", Column: "Price", As: "n"}).SQL()
+	assert.EqualError(t, err, "table Album does not have column Price")
+	_, err = memeduck.ChildStats(testSingerTable, testAlbumTable).SQL()
+	assert.EqualError(t, err, "no stats specified")
+}
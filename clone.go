@@ -0,0 +1,40 @@
+package memeduck
+
+// Clone returns a copy of the SELECT statement which shares nothing mutable with it.
+// Builder methods already return copies, so Clone is only needed to make the reuse of a base query explicit.
+func (s *SelectStmt) Clone() *SelectStmt {
+	var t = *s
+	t.cols = append([]string(nil), s.cols...)
+	t.conds = append([]WhereCond(nil), s.conds...)
+	t.ords = append([]*ordering(nil), s.ords...)
+	t.items = append([]SelectItem(nil), s.items...)
+	t.windows = append([]*namedWindow(nil), s.windows...)
+	t.hints = append([]*hint(nil), s.hints...)
+	return &t
+}
+
+// Clone returns a copy of the INSERT statement. See SelectStmt.Clone.
+// Values are shared, as the statement never modifies them.
+func (s *InsertStmt) Clone() *InsertStmt {
+	var t = *s
+	t.cols = append([]string(nil), s.cols...)
+	t.returning = s.returning.withCols(nil).withItems(nil)
+	return &t
+}
+
+// Clone returns a copy of the UPDATE statement. See SelectStmt.Clone.
+func (s *UpdateStmt) Clone() *UpdateStmt {
+	var t = *s
+	t.items = append([]*updateItem(nil), s.items...)
+	t.conds = append([]WhereCond(nil), s.conds...)
+	t.returning = s.returning.withCols(nil).withItems(nil)
+	return &t
+}
+
+// Clone returns a copy of the DELETE statement. See SelectStmt.Clone.
+func (s *DeleteStmt) Clone() *DeleteStmt {
+	var t = *s
+	t.conds = append([]WhereCond(nil), s.conds...)
+	t.returning = s.returning.withCols(nil).withItems(nil)
+	return &t
+}
@@ -0,0 +1,39 @@
+package memeduck_test
+
+import (
+	"testing"
+
+	"github.com/abyssparanoia/memeduck"
+)
+
+func TestCopyOnWrite(t *testing.T) {
+	// Make the base have spare capacity, so that naive appends from siblings would share it.
+	base := memeduck.Select("user", []string{"id"}).
+		Where(memeduck.Eq(memeduck.Ident("a"), 1), memeduck.Eq(memeduck.Ident("b"), 2), memeduck.Eq(memeduck.Ident("c"), 3)).
+		OrderBy("a", memeduck.ASC).OrderBy("b", memeduck.ASC).OrderBy("c", memeduck.ASC)
+	x := base.Where(memeduck.Eq(memeduck.Ident("x"), 1)).OrderBy("x", memeduck.ASC)
+	y := base.Where(memeduck.Eq(memeduck.Ident("y"), 1)).OrderBy("y", memeduck.ASC)
+	testSelect(t, x, "SELECT id FROM user WHERE a = 1 AND b = 2 AND c = 3 AND x = 1 ORDER BY a ASC, b ASC, c ASC, x ASC")
+	testSelect(t, y, "SELECT id FROM user WHERE a = 1 AND b = 2 AND c = 3 AND y = 1 ORDER BY a ASC, b ASC, c ASC, y ASC")
+	testSelect(t, base.Clone().Limit(1), "SELECT id FROM user WHERE a = 1 AND b = 2 AND c = 3 ORDER BY a ASC, b ASC, c ASC LIMIT 1")
+
+	update := memeduck.Update("user").Set(memeduck.Ident("a"), 1).Set(memeduck.Ident("b"), 2).Set(memeduck.Ident("c"), 3).
+		Where(memeduck.Eq(memeduck.Ident("id"), 1), memeduck.Eq(memeduck.Ident("v"), 1), memeduck.Eq(memeduck.Ident("w"), 1))
+	ux := update.Set(memeduck.Ident("x"), 1).Where(memeduck.Eq(memeduck.Ident("x"), 1))
+	uy := update.Set(memeduck.Ident("y"), 1).Where(memeduck.Eq(memeduck.Ident("y"), 1))
+	testUpdate(t, ux, "UPDATE user SET a = 1, b = 2, c = 3, x = 1 WHERE id = 1 AND v = 1 AND w = 1 AND x = 1")
+	testUpdate(t, uy, "UPDATE user SET a = 1, b = 2, c = 3, y = 1 WHERE id = 1 AND v = 1 AND w = 1 AND y = 1")
+	testUpdate(t, update.Clone(), "UPDATE user SET a = 1, b = 2, c = 3 WHERE id = 1 AND v = 1 AND w = 1")
+
+	del := memeduck.Delete("user").Where(memeduck.Eq(memeduck.Ident("a"), 1), memeduck.Eq(memeduck.Ident("b"), 1), memeduck.Eq(memeduck.Ident("c"), 1))
+	dx := del.Where(memeduck.Eq(memeduck.Ident("x"), 1))
+	dy := del.Where(memeduck.Eq(memeduck.Ident("y"), 1))
+	testDelete(t, dx, "DELETE FROM user WHERE a = 1 AND b = 1 AND c = 1 AND x = 1")
+	testDelete(t, dy, "DELETE FROM user WHERE a = 1 AND b = 1 AND c = 1 AND y = 1")
+	testDelete(t, del.Clone(), "DELETE FROM user WHERE a = 1 AND b = 1 AND c = 1")
+
+	cols := []string{"id", "name"}
+	insert := memeduck.Insert("user", cols).Values([][]interface{}{{1, "a"}})
+	cols[1] = "changed"
+	testInsert(t, insert.Clone(), `INSERT INTO user (id, name) VALUES (1, "a")`)
+}
@@ -0,0 +1,107 @@
+package memeduck
+
+import (
+	"github.com/cloudspannerecosystem/memefish/ast"
+	"github.com/pkg/errors"
+)
+
+// TableConstraint is a constraint of CREATE TABLE and ALTER TABLE ADD CONSTRAINT, created by ForeignKey or Check.
+type TableConstraint interface {
+	toASTTableConstraint() (*ast.TableConstraint, error)
+}
+
+// ForeignKeyConstraint is a FOREIGN KEY constraint.
+type ForeignKeyConstraint struct {
+	name     string
+	cols     []string
+	refTable string
+	refCols  []string
+	onDelete OnDeleteAction
+}
+
+// ForeignKey creates a new FOREIGN KEY constraint referencing the columns refCols of the table refTable by the columns cols.
+func ForeignKey(cols []string, refTable string, refCols []string) *ForeignKeyConstraint {
+	return &ForeignKeyConstraint{
+		cols:     append([]string(nil), cols...),
+		refTable: refTable,
+		refCols:  append([]string(nil), refCols...),
+	}
+}
+
+// Name names the constraint, so that it can be dropped by DropConstraint.
+func (c *ForeignKeyConstraint) Name(name string) *ForeignKeyConstraint {
+	var t = *c
+	t.name = name
+	return &t
+}
+
+// OnDelete sets the action on the referencing rows when the referenced row is deleted.
+func (c *ForeignKeyConstraint) OnDelete(action OnDeleteAction) *ForeignKeyConstraint {
+	var t = *c
+	t.onDelete = action
+	return &t
+}
+
+func (c *ForeignKeyConstraint) toASTTableConstraint() (*ast.TableConstraint, error) {
+	if len(c.cols) == 0 || len(c.cols) != len(c.refCols) {
+		return nil, errors.Errorf("foreign key must have the same number of columns as the referenced ones, but got %d and %d", len(c.cols), len(c.refCols))
+	}
+	var errs errorList
+	fk := &ast.ForeignKey{}
+	for _, col := range c.cols {
+		ident, err := toASTIdent(col)
+		errs.add(err)
+		fk.Columns = append(fk.Columns, ident)
+	}
+	table, err := toASTIdent(c.refTable)
+	errs.add(err)
+	fk.ReferenceTable = table
+	for _, col := range c.refCols {
+		ident, err := toASTIdent(col)
+		errs.add(err)
+		fk.ReferenceColumns = append(fk.ReferenceColumns, ident)
+	}
+	fk.OnDelete, err = c.onDelete.toAST()
+	errs.add(err)
+	tc := &ast.TableConstraint{Constraint: fk}
+	if c.name != "" {
+		tc.Name, err = toASTIdent(c.name)
+		errs.add(err)
+	}
+	if err := errs.err(); err != nil {
+		return nil, err
+	}
+	return tc, nil
+}
+
+// CheckConstraint is a CHECK constraint.
+type CheckConstraint struct {
+	name string
+	cond WhereCond
+}
+
+// Check creates a new CHECK constraint which rows must satisfy cond.
+func Check(cond WhereCond) *CheckConstraint {
+	return &CheckConstraint{cond: cond}
+}
+
+// Name names the constraint, so that it can be dropped by DropConstraint.
+func (c *CheckConstraint) Name(name string) *CheckConstraint {
+	var t = *c
+	t.name = name
+	return &t
+}
+
+func (c *CheckConstraint) toASTTableConstraint() (*ast.TableConstraint, error) {
+	where, err := c.cond.ToASTWhere()
+	if err != nil {
+		return nil, err
+	}
+	tc := &ast.TableConstraint{Constraint: &ast.Check{Expr: where.Expr}}
+	if c.name != "" {
+		if tc.Name, err = toASTIdent(c.name); err != nil {
+			return nil, err
+		}
+	}
+	return tc, nil
+}
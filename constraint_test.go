@@ -0,0 +1,47 @@
+package memeduck_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/abyssparanoia/memeduck"
+)
+
+func TestConstraints(t *testing.T) {
+	sql, err := memeduck.CreateTable("user_item").
+		Columns(
+			memeduck.Col("id", "STRING(36)").NotNull(),
+			memeduck.Col("user_id", "STRING(36)").NotNull(),
+			memeduck.Col("count", "INT64").NotNull(),
+		).
+		Constraints(
+			memeduck.ForeignKey([]string{"user_id"}, "user", []string{"id"}).Name("fk_user_item_user").OnDelete(memeduck.OnDeleteCascade),
+			memeduck.Check(memeduck.And(memeduck.Ge(memeduck.Ident("count"), 0), memeduck.Le(memeduck.Ident("count"), 100))),
+		).
+		PrimaryKey("id", "").
+		SQL()
+	assert.Nil(t, err)
+	assert.Equal(t, "CREATE TABLE user_item (id STRING(36) NOT NULL, user_id STRING(36) NOT NULL, count INT64 NOT NULL, "+
+		"CONSTRAINT fk_user_item_user FOREIGN KEY (user_id) REFERENCES user (id) ON DELETE CASCADE, "+
+		"CHECK (count >= 0 AND count <= 100)) PRIMARY KEY (id)", sql)
+
+	sql, err = memeduck.AlterTable("user_item").
+		AddConstraint(memeduck.Check(memeduck.Ge(memeduck.Ident("count"), 0)).Name("positive_count")).
+		SQL()
+	assert.Nil(t, err)
+	assert.Equal(t, "ALTER TABLE user_item ADD CONSTRAINT positive_count CHECK (count >= 0)", sql)
+	sql, err = memeduck.AlterTable("user_item").
+		AddConstraint(memeduck.ForeignKey([]string{"user_id"}, "user", []string{"id"})).
+		SQL()
+	assert.Nil(t, err)
+	assert.Equal(t, "ALTER TABLE user_item ADD FOREIGN KEY (user_id) REFERENCES user (id)", sql)
+	sql, err = memeduck.AlterTable("user_item").DropConstraint("positive_count").SQL()
+	assert.Nil(t, err)
+	assert.Equal(t, "ALTER TABLE user_item DROP CONSTRAINT positive_count", sql)
+
+	_, err = memeduck.AlterTable("user_item").
+		AddConstraint(memeduck.ForeignKey([]string{"user_id", "x"}, "user", []string{"id"})).
+		SQL()
+	assert.EqualError(t, err, "foreign key must have the same number of columns as the referenced ones, but got 2 and 1")
+}
@@ -0,0 +1,13 @@
+package memeduck
+
+import (
+	"github.com/cloudspannerecosystem/memefish/ast"
+)
+
+// ExprConverter is implemented by values which convert themselves into SQL expressions.
+// Domain types like UUID wrappers, enums or money types can implement it to be used as values
+// in Where, Set, Values and so on. Expressions built by memeduck implement it as well, so ToASTExpr
+// can simply delegate to them, e.g. `return memeduck.Cast(m.String(), "NUMERIC").ToASTExpr()`.
+type ExprConverter interface {
+	ToASTExpr() (ast.Expr, error)
+}
@@ -0,0 +1,40 @@
+package memeduck_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cloudspannerecosystem/memefish/ast"
+
+	"github.com/abyssparanoia/memeduck"
+	"github.com/abyssparanoia/memeduck/internal"
+)
+
+type testUUID string
+
+func (u testUUID) ToASTExpr() (ast.Expr, error) {
+	return internal.StringLit(strings.ToLower(string(u))), nil
+}
+
+type testStatus int
+
+func (s testStatus) ToASTExpr() (ast.Expr, error) {
+	return memeduck.Cast([]string{"ACTIVE", "DELETED"}[s], "STRING").ToASTExpr()
+}
+
+var _ memeduck.ExprConverter = testUUID("")
+
+func TestExprConverter(t *testing.T) {
+	testWhere(t,
+		memeduck.Eq(memeduck.Ident("id"), testUUID("0123-ABCD")),
+		`id = "0123-abcd"`,
+	)
+	testUpdate(t,
+		memeduck.Update("user").Set(memeduck.Ident("status"), testStatus(1)).Where(memeduck.Eq(memeduck.Ident("id"), testUUID("0123-ABCD"))),
+		`UPDATE user SET status = CAST("DELETED" AS STRING) WHERE id = "0123-abcd"`,
+	)
+	testInsert(t,
+		memeduck.Insert("user", []string{"id", "status"}).Values([][]interface{}{{testUUID("0123-ABCD"), testStatus(0)}}),
+		`INSERT INTO user (id, status) VALUES ("0123-abcd", CAST("ACTIVE" AS STRING))`,
+	)
+}
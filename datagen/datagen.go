@@ -0,0 +1,177 @@
+// Package datagen generates deterministic pseudo-random rows for memeduck tables, for load testing schemas.
+package datagen
+
+import (
+	"fmt"
+	"math/big"
+	"math/rand"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/civil"
+	"cloud.google.com/go/spanner"
+	"github.com/pkg/errors"
+
+	"github.com/abyssparanoia/memeduck"
+)
+
+// maxGenLength is the maximum length of generated STRING and BYTES values.
+const maxGenLength = 16
+
+// maxGenArrayLength is the maximum number of elements of generated arrays.
+const maxGenArrayLength = 4
+
+// nullRatio is the ratio of NULLs generated for nullable columns.
+const nullRatio = 0.1
+
+// baseTime is the origin of generated TIMESTAMP and DATE values.
+var baseTime = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+var columnTypePattern = regexp.MustCompile(`^(?i)(ARRAY<)?\s*([A-Z0-9]+)\s*(?:\(\s*([0-9]+|MAX)\s*\))?\s*(>)?$`)
+
+type columnType struct {
+	array bool
+	name  string
+	size  int
+}
+
+func parseColumnType(s string) (*columnType, error) {
+	m := columnTypePattern.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil || (m[1] == "") != (m[4] == "") {
+		return nil, errors.Errorf("unsupported column type %q", s)
+	}
+	t := &columnType{
+		array: m[1] != "",
+		name:  strings.ToUpper(m[2]),
+		size:  maxGenLength,
+	}
+	if m[3] != "" && !strings.EqualFold(m[3], "MAX") {
+		size, err := strconv.Atoi(m[3])
+		if err != nil {
+			return nil, errors.WithMessagef(err, "invalid size of column type %q", s)
+		}
+		if size < t.size {
+			t.size = size
+		}
+	}
+	return t, nil
+}
+
+// Insert generates an INSERT statement of n rows into table.
+// The same seed always generates the same rows.
+// Primary key columns are derived from the row index so that rows don't collide,
+// and nullable columns are NULL at random.
+func Insert(table *memeduck.Table, n int, seed int64) (*memeduck.InsertStmt, error) {
+	if n <= 0 {
+		return nil, errors.New("no rows to generate")
+	}
+	types := make([]*columnType, 0, len(table.Columns))
+	for _, c := range table.Columns {
+		t, err := parseColumnType(c.Type)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "column %s", c.Name)
+		}
+		types = append(types, t)
+	}
+	rnd := rand.New(rand.NewSource(seed))
+	rows := make([][]interface{}, 0, n)
+	for i := 0; i < n; i++ {
+		row := make([]interface{}, 0, len(table.Columns))
+		for j, c := range table.Columns {
+			var v interface{}
+			var err error
+			switch {
+			case table.IsKey(c.Name):
+				v, err = keyValue(types[j], i)
+			case !c.NotNull && rnd.Float64() < nullRatio:
+				v = nil
+			default:
+				v, err = randomValue(rnd, types[j])
+			}
+			if err != nil {
+				return nil, errors.WithMessagef(err, "column %s", c.Name)
+			}
+			row = append(row, v)
+		}
+		rows = append(rows, row)
+	}
+	return memeduck.Insert(table.Name, table.ColumnNames()).Values(rows), nil
+}
+
+func keyValue(t *columnType, i int) (interface{}, error) {
+	if t.array {
+		return nil, errors.New("ARRAY can't be a key")
+	}
+	switch t.name {
+	case "INT64":
+		return int64(i + 1), nil
+	case "FLOAT64":
+		return float64(i + 1), nil
+	case "NUMERIC":
+		return numeric(big.NewRat(int64(i+1), 1)), nil
+	case "STRING":
+		return fmt.Sprintf("%0*d", t.size, i), nil
+	case "BYTES":
+		return []byte(fmt.Sprintf("%0*d", t.size, i)), nil
+	case "DATE":
+		return civil.DateOf(baseTime.AddDate(0, 0, i)), nil
+	case "TIMESTAMP":
+		return baseTime.Add(time.Duration(i) * time.Second), nil
+	}
+	return nil, errors.Errorf("%s can't be generated as a unique key", t.name)
+}
+
+func randomValue(rnd *rand.Rand, t *columnType) (interface{}, error) {
+	if !t.array {
+		return randomScalar(rnd, t)
+	}
+	n := rnd.Intn(maxGenArrayLength + 1)
+	values := make([]interface{}, 0, n)
+	for i := 0; i < n; i++ {
+		v, err := randomScalar(rnd, t)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+	return memeduck.ArrayOf(t.name, values...), nil
+}
+
+func randomScalar(rnd *rand.Rand, t *columnType) (interface{}, error) {
+	switch t.name {
+	case "BOOL":
+		return rnd.Intn(2) == 1, nil
+	case "INT64":
+		return rnd.Int63(), nil
+	case "FLOAT64":
+		return rnd.Float64(), nil
+	case "NUMERIC":
+		return numeric(big.NewRat(rnd.Int63n(1e12), 1000)), nil
+	case "STRING":
+		return randomString(rnd, t.size), nil
+	case "BYTES":
+		return []byte(randomString(rnd, t.size)), nil
+	case "DATE":
+		return civil.DateOf(baseTime.AddDate(0, 0, rnd.Intn(3650))), nil
+	case "TIMESTAMP":
+		return baseTime.Add(time.Duration(rnd.Int63n(int64(3650 * 24 * time.Hour)))).Truncate(time.Microsecond), nil
+	case "JSON":
+		return memeduck.JSON(map[string]interface{}{"n": rnd.Intn(1000)}), nil
+	}
+	return nil, errors.Errorf("%s can't be generated", t.name)
+}
+
+func numeric(r *big.Rat) spanner.NullNumeric {
+	return spanner.NullNumeric{Numeric: *r, Valid: true}
+}
+
+func randomString(rnd *rand.Rand, size int) string {
+	const letters = "abcdefghijklmnopqrstuvwxyz"
+	b := make([]byte, rnd.Intn(size+1))
+	for i := range b {
+		b[i] = letters[rnd.Intn(len(letters))]
+	}
+	return string(b)
+}
@@ -0,0 +1,62 @@
+package datagen_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/abyssparanoia/memeduck"
+	"github.com/abyssparanoia/memeduck/datagen"
+)
+
+var testTable = &memeduck.Table{
+	Name: "user",
+	Columns: []*memeduck.Column{
+		{Name: "id", Type: "STRING(8)", NotNull: true},
+		{Name: "age", Type: "INT64", NotNull: true},
+		{Name: "nickname", Type: "STRING(MAX)"},
+		{Name: "balance", Type: "NUMERIC", NotNull: true},
+		{Name: "tags", Type: "ARRAY<STRING(4)>"},
+		{Name: "active", Type: "BOOL", NotNull: true},
+		{Name: "born", Type: "DATE"},
+		{Name: "created_at", Type: "TIMESTAMP", NotNull: true},
+	},
+	PrimaryKey: []*memeduck.KeyPart{{Column: "id"}},
+}
+
+func TestInsert(t *testing.T) {
+	stmt, err := datagen.Insert(testTable, 3, 42)
+	assert.Nil(t, err)
+	sql, err := stmt.SQL()
+	assert.Nil(t, err)
+	assert.Contains(t, sql, `INSERT INTO user (id, age, nickname, balance, tags, active, born, created_at) VALUES ("00000000", `)
+	assert.Contains(t, sql, `("00000002", `)
+
+	again, err := datagen.Insert(testTable, 3, 42)
+	assert.Nil(t, err)
+	againSQL, err := again.SQL()
+	assert.Nil(t, err)
+	assert.Equal(t, sql, againSQL)
+
+	other, err := datagen.Insert(testTable, 3, 43)
+	assert.Nil(t, err)
+	otherSQL, err := other.SQL()
+	assert.Nil(t, err)
+	assert.NotEqual(t, sql, otherSQL)
+}
+
+func TestInsertError(t *testing.T) {
+	_, err := datagen.Insert(testTable, 0, 42)
+	assert.Error(t, err)
+	_, err = datagen.Insert(&memeduck.Table{
+		Name:    "t",
+		Columns: []*memeduck.Column{{Name: "p", Type: "PROTO<a.B>"}},
+	}, 1, 42)
+	assert.Error(t, err)
+	_, err = datagen.Insert(&memeduck.Table{
+		Name:       "t",
+		Columns:    []*memeduck.Column{{Name: "k", Type: "BOOL"}},
+		PrimaryKey: []*memeduck.KeyPart{{Column: "k"}},
+	}, 1, 42)
+	assert.Error(t, err)
+}
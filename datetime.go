@@ -0,0 +1,138 @@
+package memeduck
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/cloudspannerecosystem/memefish/ast"
+	"github.com/pkg/errors"
+
+	"github.com/abyssparanoia/memeduck/internal"
+)
+
+var datePartPattern = regexp.MustCompile(`^[A-Za-z_]+$`)
+
+// datePart is a date part like DAY or HOUR used in function arguments.
+type datePart string
+
+func (p datePart) ToASTExpr() (ast.Expr, error) {
+	if !datePartPattern.MatchString(string(p)) {
+		return nil, errors.Errorf("invalid date part %q", string(p))
+	}
+	return &ast.Ident{Name: strings.ToUpper(string(p))}, nil
+}
+
+// IntervalExpr is an interval `INTERVAL n unit`.
+type IntervalExpr struct {
+	n    interface{}
+	unit string
+}
+
+// Interval creates `INTERVAL n unit` expression, e.g. Interval(30, "MINUTE").
+// unit is a date part such as "SECOND", "MINUTE", "HOUR", "DAY", "MONTH" or "YEAR".
+func Interval(n interface{}, unit string) *IntervalExpr {
+	return &IntervalExpr{n: n, unit: unit}
+}
+
+func (e *IntervalExpr) ToASTExpr() (ast.Expr, error) {
+	if !datePartPattern.MatchString(e.unit) {
+		return nil, errors.Errorf("invalid interval unit %q", e.unit)
+	}
+	n, err := internal.ToExpr(e.n)
+	if err != nil {
+		return nil, err
+	}
+	return &internal.IntervalExpr{
+		Value: n,
+		Unit:  strings.ToUpper(e.unit),
+	}, nil
+}
+
+// CurrentTimestamp creates `CURRENT_TIMESTAMP()` function call.
+func CurrentTimestamp() *FuncExpr {
+	return Func("CURRENT_TIMESTAMP")
+}
+
+// CurrentDate creates `CURRENT_DATE(tz)` function call.
+// If tz is empty, `CURRENT_DATE()` is created, which uses the default time zone.
+func CurrentDate(tz string) *FuncExpr {
+	if tz == "" {
+		return Func("CURRENT_DATE")
+	}
+	return Func("CURRENT_DATE", tz)
+}
+
+// TimestampAdd creates `TIMESTAMP_ADD(ts, INTERVAL n unit)` function call.
+// unit is a date part such as "SECOND", "MINUTE", "HOUR" or "DAY".
+func TimestampAdd(ts, n interface{}, unit string) *FuncExpr {
+	return Func("TIMESTAMP_ADD", ts, Interval(n, unit))
+}
+
+// TimestampSub creates `TIMESTAMP_SUB(ts, INTERVAL n unit)` function call.
+func TimestampSub(ts, n interface{}, unit string) *FuncExpr {
+	return Func("TIMESTAMP_SUB", ts, Interval(n, unit))
+}
+
+// DateAdd creates `DATE_ADD(date, INTERVAL n unit)` function call.
+// unit is a date part such as "DAY", "WEEK", "MONTH" or "YEAR".
+func DateAdd(date, n interface{}, unit string) *FuncExpr {
+	return Func("DATE_ADD", date, Interval(n, unit))
+}
+
+// DateSub creates `DATE_SUB(date, INTERVAL n unit)` function call.
+func DateSub(date, n interface{}, unit string) *FuncExpr {
+	return Func("DATE_SUB", date, Interval(n, unit))
+}
+
+// TimestampTrunc creates `TIMESTAMP_TRUNC(ts, part)` function call.
+func TimestampTrunc(ts interface{}, part string) *FuncExpr {
+	return Func("TIMESTAMP_TRUNC", ts, datePart(part))
+}
+
+// ExtractExpr is `EXTRACT(part FROM x)` expression.
+type ExtractExpr struct {
+	part string
+	x    interface{}
+	as   string
+}
+
+// Extract creates `EXTRACT(part FROM x)` expression, e.g. Extract("YEAR", Ident("created_at")).
+func Extract(part string, x interface{}) *ExtractExpr {
+	return &ExtractExpr{part: part, x: x}
+}
+
+// As sets an alias used when the expression appears in SELECT results.
+func (e *ExtractExpr) As(as string) *ExtractExpr {
+	var t = *e
+	t.as = as
+	return &t
+}
+
+func (e *ExtractExpr) ToASTExpr() (ast.Expr, error) {
+	part, err := datePart(e.part).ToASTExpr()
+	if err != nil {
+		return nil, err
+	}
+	x, err := internal.ToExpr(e.x)
+	if err != nil {
+		return nil, err
+	}
+	return &ast.ExtractExpr{
+		Part: part.(*ast.Ident),
+		Expr: x,
+	}, nil
+}
+
+func (e *ExtractExpr) ToAST() (ast.SelectItem, error) {
+	expr, err := e.ToASTExpr()
+	if err != nil {
+		return nil, err
+	}
+	return toASTSelectItem(expr, e.as), nil
+}
+
+// CommitTimestamp creates `PENDING_COMMIT_TIMESTAMP()` function call, which writes the commit timestamp
+// into a column with allow_commit_timestamp=true. spanner.CommitTimestamp is converted into it as well.
+func CommitTimestamp() *FuncExpr {
+	return Func("PENDING_COMMIT_TIMESTAMP")
+}
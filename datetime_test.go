@@ -0,0 +1,64 @@
+package memeduck_test
+
+import (
+	"testing"
+
+	"cloud.google.com/go/spanner"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/abyssparanoia/memeduck"
+)
+
+func TestDateTimeFuncs(t *testing.T) {
+	testExpr(t, memeduck.CurrentTimestamp(), `CURRENT_TIMESTAMP()`)
+	testExpr(t, memeduck.CurrentDate(""), `CURRENT_DATE()`)
+	testExpr(t, memeduck.CurrentDate("Asia/Tokyo"), `CURRENT_DATE("Asia/Tokyo")`)
+	testExpr(t, memeduck.TimestampAdd(memeduck.Ident("t"), 10, "minute"), `TIMESTAMP_ADD(t, INTERVAL 10 MINUTE)`)
+	testExpr(t, memeduck.DateAdd(memeduck.Ident("d"), memeduck.Param("n"), "DAY"), `DATE_ADD(d, INTERVAL @n DAY)`)
+	testExpr(t, memeduck.DateSub(memeduck.CurrentDate(""), 1, "MONTH"), `DATE_SUB(CURRENT_DATE(), INTERVAL 1 MONTH)`)
+	testExpr(t, memeduck.TimestampTrunc(memeduck.Ident("t"), "day"), `TIMESTAMP_TRUNC(t, DAY)`)
+	testExpr(t, memeduck.Extract("YEAR", memeduck.Ident("created_at")), `EXTRACT(YEAR FROM created_at)`)
+	testWhere(t,
+		memeduck.Gt(memeduck.Ident("created_at"), memeduck.TimestampSub(memeduck.CurrentTimestamp(), 1, "DAY")),
+		`created_at > TIMESTAMP_SUB(CURRENT_TIMESTAMP(), INTERVAL 1 DAY)`,
+	)
+	testSelect(t,
+		memeduck.Select("hoge", []string{}).Items(memeduck.Extract("MONTH", memeduck.Ident("d")).As("m")),
+		`SELECT EXTRACT(MONTH FROM d) AS m FROM hoge`,
+	)
+
+	_, err := memeduck.TimestampAdd(memeduck.Ident("t"), 1, "DAY) --").ToASTExpr()
+	assert.Error(t, err)
+	_, err = memeduck.Extract("", memeduck.Ident("t")).ToASTExpr()
+	assert.Error(t, err)
+}
+
+func TestCommitTimestamp(t *testing.T) {
+	testExpr(t, memeduck.CommitTimestamp(), `PENDING_COMMIT_TIMESTAMP()`)
+	testInsert(t,
+		memeduck.Insert("hoge", []string{"id", "updated_at"}).Values([][]interface{}{
+			{"a", spanner.CommitTimestamp},
+			{"b", spanner.NullTime{Time: spanner.CommitTimestamp, Valid: true}},
+		}),
+		`INSERT INTO hoge (id, updated_at) VALUES ("a", PENDING_COMMIT_TIMESTAMP()), ("b", PENDING_COMMIT_TIMESTAMP())`,
+	)
+	testUpdate(t,
+		memeduck.Update("hoge").
+			Set(memeduck.Ident("updated_at"), memeduck.CommitTimestamp()).
+			Where(memeduck.Eq(memeduck.Ident("id"), "a")),
+		`UPDATE hoge SET updated_at = PENDING_COMMIT_TIMESTAMP() WHERE id = "a"`,
+	)
+}
+
+func TestInterval(t *testing.T) {
+	testExpr(t, memeduck.Interval(30, "minute"), `INTERVAL 30 MINUTE`)
+	testExpr(t, memeduck.Interval(memeduck.Param("days"), "DAY"), `INTERVAL @days DAY`)
+	testExpr(t, memeduck.Cast(memeduck.Param("i"), "INTERVAL"), `CAST(@i AS INTERVAL)`)
+	testExpr(t, memeduck.ArrayOf("INTERVAL"), `ARRAY<INTERVAL>[]`)
+	testWhere(t,
+		memeduck.Lt(memeduck.Ident("duration"), memeduck.Interval(1, "HOUR")),
+		`duration < (INTERVAL 1 HOUR)`,
+	)
+	_, err := memeduck.Interval(1, "").ToASTExpr()
+	assert.Error(t, err)
+}
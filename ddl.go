@@ -0,0 +1,369 @@
+package memeduck
+
+import (
+	"github.com/cloudspannerecosystem/memefish/ast"
+	"github.com/pkg/errors"
+
+	"github.com/abyssparanoia/memeduck/internal"
+)
+
+// ColumnDef is a column definition of CREATE TABLE and ALTER TABLE statements.
+type ColumnDef struct {
+	name                 string
+	typ                  string
+	notNull              bool
+	def                  interface{}
+	hasDefault           bool
+	allowCommitTimestamp bool
+}
+
+// Col creates a new ColumnDef with given column name and type as written in DDL, e.g. "INT64" or "STRING(MAX)".
+func Col(name, typ string) *ColumnDef {
+	return &ColumnDef{name: name, typ: typ}
+}
+
+// NotNull makes the column NOT NULL.
+func (c *ColumnDef) NotNull() *ColumnDef {
+	var t = *c
+	t.notNull = true
+	return &t
+}
+
+// Default sets the default value of the column, which is any value accepted as an expression like Set of UpdateStmt.
+func (c *ColumnDef) Default(v interface{}) *ColumnDef {
+	var t = *c
+	t.def = v
+	t.hasDefault = true
+	return &t
+}
+
+// AllowCommitTimestamp sets `OPTIONS(allow_commit_timestamp = true)` to the column,
+// so that spanner.CommitTimestamp can be written to it. The column must be a TIMESTAMP column.
+func (c *ColumnDef) AllowCommitTimestamp() *ColumnDef {
+	var t = *c
+	t.allowCommitTimestamp = true
+	return &t
+}
+
+func (c *ColumnDef) toAST() (*ast.ColumnDef, error) {
+	name, err := toASTIdent(c.name)
+	if err != nil {
+		return nil, err
+	}
+	typ, err := internal.ParseSchemaType(c.typ)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "column %s", c.name)
+	}
+	def := &ast.ColumnDef{Name: name, Type: typ, NotNull: c.notNull}
+	if def.DefaultExpr, err = c.toASTDefault(); err != nil {
+		return nil, err
+	}
+	if c.allowCommitTimestamp {
+		if s, ok := typ.(*ast.ScalarSchemaType); !ok || s.Name != ast.TimestampTypeName {
+			return nil, errors.Errorf("allow_commit_timestamp can't be set to column %s of type %s", c.name, c.typ)
+		}
+		def.Options = &ast.ColumnDefOptions{AllowCommitTimestamp: true}
+	}
+	return def, nil
+}
+
+func (c *ColumnDef) toASTDefault() (*ast.ColumnDefaultExpr, error) {
+	if !c.hasDefault {
+		return nil, nil
+	}
+	expr, err := internal.ToExpr(c.def)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "default value of column %s", c.name)
+	}
+	return &ast.ColumnDefaultExpr{Expr: expr}, nil
+}
+
+// CreateTableStmt is a CREATE TABLE statement.
+type CreateTableStmt struct {
+	table       string
+	cols        []*ColumnDef
+	keys        []*KeyPart
+	constraints []TableConstraint
+	parent      string
+	onDelete    OnDeleteAction
+	ifNotExists bool
+}
+
+// CreateTable creates a new CreateTableStmt with given table name.
+func CreateTable(table string) *CreateTableStmt {
+	return &CreateTableStmt{table: table}
+}
+
+// Columns appends column definitions.
+func (s *CreateTableStmt) Columns(cols ...*ColumnDef) *CreateTableStmt {
+	var t = *s
+	t.cols = append(append([]*ColumnDef(nil), t.cols...), cols...)
+	return &t
+}
+
+// Constraints appends table constraints created by ForeignKey or Check.
+func (s *CreateTableStmt) Constraints(constraints ...TableConstraint) *CreateTableStmt {
+	var t = *s
+	t.constraints = append(append([]TableConstraint(nil), t.constraints...), constraints...)
+	return &t
+}
+
+// PrimaryKey appends a key part of the primary key. dir can be empty for the default ascending order.
+func (s *CreateTableStmt) PrimaryKey(col string, dir Direction) *CreateTableStmt {
+	var t = *s
+	t.keys = append(append([]*KeyPart(nil), t.keys...), &KeyPart{Column: col, Dir: dir})
+	return &t
+}
+
+// Interleave interleaves the table in the table parent, storing its rows physically with the parent rows.
+// onDelete can be empty for the default NO ACTION. The primary key must be prefixed by the one of parent.
+func (s *CreateTableStmt) Interleave(parent string, onDelete OnDeleteAction) *CreateTableStmt {
+	var t = *s
+	t.parent = parent
+	t.onDelete = onDelete
+	return &t
+}
+
+// IfNotExists makes the statement CREATE TABLE IF NOT EXISTS.
+func (s *CreateTableStmt) IfNotExists() *CreateTableStmt {
+	var t = *s
+	t.ifNotExists = true
+	return &t
+}
+
+func (s *CreateTableStmt) SQL() (string, error) {
+	stmt, err := s.toAST()
+	if err != nil {
+		return "", err
+	}
+	return renderSQL(stmt)
+}
+
+func (s *CreateTableStmt) toAST() (*ast.CreateTable, error) {
+	name, err := toASTIdent(s.table)
+	if err != nil {
+		return nil, err
+	}
+	if len(s.cols) == 0 {
+		return nil, ErrNoColumns
+	}
+	ct := &ast.CreateTable{Name: name, IfNotExists: s.ifNotExists}
+	var errs errorList
+	for _, c := range s.cols {
+		def, err := c.toAST()
+		errs.add(err)
+		if def != nil {
+			ct.Columns = append(ct.Columns, def)
+		}
+	}
+	for _, c := range s.constraints {
+		tc, err := c.toASTTableConstraint()
+		errs.add(err)
+		if tc != nil {
+			ct.TableConstraints = append(ct.TableConstraints, tc)
+		}
+	}
+	for _, k := range s.keys {
+		if !containsColumnDef(s.cols, k.Column) {
+			errs.add(errors.Errorf("primary key column %s is not defined", k.Column))
+			continue
+		}
+		key, err := toASTIdent(k.Column)
+		if err != nil {
+			errs.add(err)
+			continue
+		}
+		ct.PrimaryKeys = append(ct.PrimaryKeys, &ast.IndexKey{Name: key, Dir: ast.Direction(k.Dir)})
+	}
+	if s.parent != "" {
+		parent, err := toASTIdent(s.parent)
+		errs.add(err)
+		onDelete, err := s.onDelete.toAST()
+		errs.add(err)
+		ct.Cluster = &ast.Cluster{TableName: parent, OnDelete: onDelete}
+	}
+	if err := errs.err(); err != nil {
+		return nil, err
+	}
+	return ct, nil
+}
+
+func containsColumnDef(cols []*ColumnDef, name string) bool {
+	for _, c := range cols {
+		if c.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// OnDeleteAction is the action on child rows of an interleaved table when the parent row is deleted,
+// or on referencing rows of a foreign key when the referenced row is deleted.
+type OnDeleteAction ast.OnDeleteAction
+
+const (
+	OnDeleteCascade  OnDeleteAction = OnDeleteAction(ast.OnDeleteCascade)
+	OnDeleteNoAction OnDeleteAction = OnDeleteAction(ast.OnDeleteNoAction)
+)
+
+func (a OnDeleteAction) toAST() (ast.OnDeleteAction, error) {
+	switch a {
+	case "", OnDeleteCascade, OnDeleteNoAction:
+		return ast.OnDeleteAction(a), nil
+	}
+	return "", errors.Errorf("unknown ON DELETE action %q", string(a))
+}
+
+// AlterTableStmt is an ALTER TABLE statement. Spanner applies one alteration per statement,
+// so building the statement fails unless exactly one of the alterations is specified.
+type AlterTableStmt struct {
+	table       string
+	alterations []tableAlteration
+}
+
+type tableAlteration interface {
+	toASTTableAlteration() (ast.TableAlteration, error)
+}
+
+// AlterTable creates a new AlterTableStmt with given table name.
+func AlterTable(table string) *AlterTableStmt {
+	return &AlterTableStmt{table: table}
+}
+
+func (s *AlterTableStmt) alter(a tableAlteration) *AlterTableStmt {
+	var t = *s
+	t.alterations = append(append([]tableAlteration(nil), t.alterations...), a)
+	return &t
+}
+
+// AddColumn adds the column col.
+func (s *AlterTableStmt) AddColumn(col *ColumnDef) *AlterTableStmt {
+	return s.alter(&addColumn{col: col})
+}
+
+// DropColumn drops the column named col.
+func (s *AlterTableStmt) DropColumn(col string) *AlterTableStmt {
+	return s.alter(&dropColumn{name: col})
+}
+
+// AlterColumn changes the type, the nullability and the default value of the column to the ones of col.
+// Column options can't be changed with them, so col can't have AllowCommitTimestamp.
+func (s *AlterTableStmt) AlterColumn(col *ColumnDef) *AlterTableStmt {
+	return s.alter(&alterColumn{col: col})
+}
+
+// AddConstraint adds the table constraint c created by ForeignKey or Check.
+func (s *AlterTableStmt) AddConstraint(c TableConstraint) *AlterTableStmt {
+	return s.alter(&addConstraint{constraint: c})
+}
+
+// DropConstraint drops the constraint named name.
+func (s *AlterTableStmt) DropConstraint(name string) *AlterTableStmt {
+	return s.alter(&dropConstraint{name: name})
+}
+
+// SetOnDelete changes the ON DELETE action of the interleaved table.
+func (s *AlterTableStmt) SetOnDelete(action OnDeleteAction) *AlterTableStmt {
+	return s.alter(&setOnDelete{action: action})
+}
+
+type addColumn struct {
+	col *ColumnDef
+}
+
+func (a *addColumn) toASTTableAlteration() (ast.TableAlteration, error) {
+	def, err := a.col.toAST()
+	if err != nil {
+		return nil, err
+	}
+	return &ast.AddColumn{Column: def}, nil
+}
+
+type dropColumn struct {
+	name string
+}
+
+func (a *dropColumn) toASTTableAlteration() (ast.TableAlteration, error) {
+	name, err := toASTIdent(a.name)
+	if err != nil {
+		return nil, err
+	}
+	return &ast.DropColumn{Name: name}, nil
+}
+
+type alterColumn struct {
+	col *ColumnDef
+}
+
+func (a *alterColumn) toASTTableAlteration() (ast.TableAlteration, error) {
+	if a.col.allowCommitTimestamp {
+		return nil, errors.Errorf("options of column %s can't be altered with its type", a.col.name)
+	}
+	def, err := a.col.toAST()
+	if err != nil {
+		return nil, err
+	}
+	return &ast.AlterColumn{Name: def.Name, Type: def.Type, NotNull: def.NotNull, DefaultExpr: def.DefaultExpr}, nil
+}
+
+type addConstraint struct {
+	constraint TableConstraint
+}
+
+func (a *addConstraint) toASTTableAlteration() (ast.TableAlteration, error) {
+	tc, err := a.constraint.toASTTableConstraint()
+	if err != nil {
+		return nil, err
+	}
+	return &ast.AddTableConstraint{TableConstraint: tc}, nil
+}
+
+type dropConstraint struct {
+	name string
+}
+
+func (a *dropConstraint) toASTTableAlteration() (ast.TableAlteration, error) {
+	name, err := toASTIdent(a.name)
+	if err != nil {
+		return nil, err
+	}
+	return &ast.DropConstraint{Name: name}, nil
+}
+
+type setOnDelete struct {
+	action OnDeleteAction
+}
+
+func (a *setOnDelete) toASTTableAlteration() (ast.TableAlteration, error) {
+	if a.action == "" {
+		return nil, errors.New("ON DELETE action is not specified")
+	}
+	action, err := a.action.toAST()
+	if err != nil {
+		return nil, err
+	}
+	return &ast.SetOnDelete{OnDelete: action}, nil
+}
+
+func (s *AlterTableStmt) SQL() (string, error) {
+	stmt, err := s.toAST()
+	if err != nil {
+		return "", err
+	}
+	return renderSQL(stmt)
+}
+
+func (s *AlterTableStmt) toAST() (*ast.AlterTable, error) {
+	name, err := toASTIdent(s.table)
+	if err != nil {
+		return nil, err
+	}
+	if len(s.alterations) != 1 {
+		return nil, errors.Errorf("ALTER TABLE must have exactly one alteration, but %d are specified", len(s.alterations))
+	}
+	alteration, err := s.alterations[0].toASTTableAlteration()
+	if err != nil {
+		return nil, err
+	}
+	return &ast.AlterTable{Name: name, TableAlteration: alteration}, nil
+}
@@ -0,0 +1,100 @@
+package memeduck_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/abyssparanoia/memeduck"
+)
+
+func TestCreateTable(t *testing.T) {
+	sql, err := memeduck.CreateTable("user").
+		Columns(
+			memeduck.Col("id", "STRING(36)").NotNull(),
+			memeduck.Col("name", "STRING(MAX)"),
+			memeduck.Col("tags", "ARRAY<STRING(16)>"),
+			memeduck.Col("created_at", "TIMESTAMP").NotNull().AllowCommitTimestamp(),
+		).
+		PrimaryKey("id", "").
+		SQL()
+	assert.Nil(t, err)
+	assert.Equal(t, "CREATE TABLE user (id STRING(36) NOT NULL, name STRING(MAX), tags ARRAY<STRING(16)>, "+
+		"created_at TIMESTAMP NOT NULL OPTIONS(allow_commit_timestamp = true)) PRIMARY KEY (id)", sql)
+
+	sql, err = memeduck.CreateTable("user_log").IfNotExists().
+		Columns(memeduck.Col("user_id", "STRING(36)").NotNull(), memeduck.Col("seq", "INT64").NotNull()).
+		PrimaryKey("user_id", "").
+		PrimaryKey("seq", memeduck.DESC).
+		SQL()
+	assert.Nil(t, err)
+	assert.Equal(t, "CREATE TABLE IF NOT EXISTS user_log (user_id STRING(36) NOT NULL, seq INT64 NOT NULL) PRIMARY KEY (user_id, seq DESC)", sql)
+
+	stmt := memeduck.CreateTable("user_item").
+		Columns(memeduck.Col("user_id", "STRING(36)").NotNull(), memeduck.Col("item_id", "INT64").NotNull()).
+		PrimaryKey("user_id", "").
+		PrimaryKey("item_id", "")
+	sql, err = stmt.Interleave("user", memeduck.OnDeleteCascade).SQL()
+	assert.Nil(t, err)
+	assert.Equal(t, "CREATE TABLE user_item (user_id STRING(36) NOT NULL, item_id INT64 NOT NULL) PRIMARY KEY (user_id, item_id), "+
+		"INTERLEAVE IN PARENT user ON DELETE CASCADE", sql)
+	sql, err = stmt.Interleave("user", "").SQL()
+	assert.Nil(t, err)
+	assert.Equal(t, "CREATE TABLE user_item (user_id STRING(36) NOT NULL, item_id INT64 NOT NULL) PRIMARY KEY (user_id, item_id), "+
+		"INTERLEAVE IN PARENT user", sql)
+	_, err = stmt.Interleave("user", "ON DELETE SET NULL").SQL()
+	assert.EqualError(t, err, `unknown ON DELETE action "ON DELETE SET NULL"`)
+
+	_, err = memeduck.CreateTable("user").SQL()
+	assert.ErrorIs(t, err, memeduck.ErrNoColumns)
+	_, err = memeduck.CreateTable("user").
+		Columns(memeduck.Col("id", "INT"), memeduck.Col("at", "STRING(MAX)").AllowCommitTimestamp()).
+		PrimaryKey("uid", "").
+		SQL()
+	assert.EqualError(t, err, "column id: invalid column type \"INT\"\n"+
+		"allow_commit_timestamp can't be set to column at of type STRING(MAX)\n"+
+		"primary key column uid is not defined")
+}
+
+func TestAlterTable(t *testing.T) {
+	for _, c := range []struct {
+		stmt     *memeduck.AlterTableStmt
+		expected string
+	}{
+		{
+			memeduck.AlterTable("user").AddColumn(memeduck.Col("age", "INT64").NotNull().Default(0)),
+			"ALTER TABLE user ADD COLUMN age INT64 NOT NULL DEFAULT (0)",
+		},
+		{
+			memeduck.AlterTable("user").AddColumn(memeduck.Col("updated_at", "TIMESTAMP").AllowCommitTimestamp()),
+			"ALTER TABLE user ADD COLUMN updated_at TIMESTAMP OPTIONS(allow_commit_timestamp = true)",
+		},
+		{
+			memeduck.AlterTable("user").DropColumn("age"),
+			"ALTER TABLE user DROP COLUMN age",
+		},
+		{
+			memeduck.AlterTable("user").AlterColumn(memeduck.Col("name", "STRING(256)").NotNull().Default("")),
+			`ALTER TABLE user ALTER COLUMN name STRING(256) NOT NULL DEFAULT ("")`,
+		},
+		{
+			memeduck.AlterTable("user_item").SetOnDelete(memeduck.OnDeleteCascade),
+			"ALTER TABLE user_item SET ON DELETE CASCADE",
+		},
+	} {
+		sql, err := c.stmt.SQL()
+		assert.Nil(t, err)
+		assert.Equal(t, c.expected, sql)
+	}
+
+	_, err := memeduck.AlterTable("user").SQL()
+	assert.EqualError(t, err, "ALTER TABLE must have exactly one alteration, but 0 are specified")
+	_, err = memeduck.AlterTable("user").DropColumn("a").DropColumn("b").SQL()
+	assert.EqualError(t, err, "ALTER TABLE must have exactly one alteration, but 2 are specified")
+	_, err = memeduck.AlterTable("user").AlterColumn(memeduck.Col("at", "TIMESTAMP").AllowCommitTimestamp()).SQL()
+	assert.EqualError(t, err, "options of column at can't be altered with its type")
+	_, err = memeduck.AlterTable("user").SetOnDelete("").SQL()
+	assert.EqualError(t, err, "ON DELETE action is not specified")
+	_, err = memeduck.AlterTable("user").SetOnDelete("ON DELETE SET NULL").SQL()
+	assert.EqualError(t, err, `unknown ON DELETE action "ON DELETE SET NULL"`)
+}
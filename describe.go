@@ -0,0 +1,159 @@
+package memeduck
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cloudspannerecosystem/memefish/ast"
+
+	"github.com/abyssparanoia/memeduck/internal"
+)
+
+// Description is a structured summary of a statement, for showing what it does without parsing SQL.
+type Description struct {
+	Operation string
+	// Tables are the tables the statement reads or writes, the target table first,
+	// followed by tables of joins and subqueries in order of appearance.
+	Tables []string
+	// FilterColumns are the columns referenced in WHERE clauses in order of appearance.
+	FilterColumns []string
+	// Ordering is the ORDER BY items of a SELECT statement, like "a DESC".
+	Ordering []string
+	Limit    *int
+	Offset   *int
+	// Params maps the query parameters to their types inferred as ParamTypes does.
+	Params map[string]string
+}
+
+// String returns the description in a human-readable form, one line per item.
+func (d *Description) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "operation: %s\n", d.Operation)
+	fmt.Fprintf(&b, "tables: %s\n", strings.Join(d.Tables, ", "))
+	if len(d.FilterColumns) > 0 {
+		fmt.Fprintf(&b, "filter columns: %s\n", strings.Join(d.FilterColumns, ", "))
+	}
+	if len(d.Ordering) > 0 {
+		fmt.Fprintf(&b, "ordering: %s\n", strings.Join(d.Ordering, ", "))
+	}
+	if d.Limit != nil {
+		fmt.Fprintf(&b, "limit: %d\n", *d.Limit)
+	}
+	if d.Offset != nil {
+		fmt.Fprintf(&b, "offset: %d\n", *d.Offset)
+	}
+	if len(d.Params) > 0 {
+		names := make([]string, 0, len(d.Params))
+		for name := range d.Params {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		params := make([]string, 0, len(names))
+		for _, name := range names {
+			if t := d.Params[name]; t != "" {
+				params = append(params, "@"+name+" "+t)
+			} else {
+				params = append(params, "@"+name)
+			}
+		}
+		fmt.Fprintf(&b, "params: %s\n", strings.Join(params, ", "))
+	}
+	return b.String()
+}
+
+// Describe returns a summary of the SELECT statement.
+func (s *SelectStmt) Describe() (*Description, error) {
+	stmt, err := s.toASTStatement()
+	if err != nil {
+		return nil, err
+	}
+	d, err := describe("SELECT", s.table, stmt)
+	if err != nil {
+		return nil, err
+	}
+	for _, o := range s.ords {
+		d.Ordering = append(d.Ordering, s.withNullsDefault(o).toASTOrderByItem().SQL())
+	}
+	d.Limit, d.Offset = s.limit, s.offset
+	return d, nil
+}
+
+// Describe returns a summary of the INSERT statement.
+func (s *InsertStmt) Describe() (*Description, error) {
+	stmt, err := s.toASTStatement()
+	if err != nil {
+		return nil, err
+	}
+	return describe("INSERT", s.table, stmt)
+}
+
+// Describe returns a summary of the UPDATE statement.
+func (s *UpdateStmt) Describe() (*Description, error) {
+	stmt, err := s.toASTStatement()
+	if err != nil {
+		return nil, err
+	}
+	return describe("UPDATE", s.table, stmt)
+}
+
+// Describe returns a summary of the DELETE statement.
+func (s *DeleteStmt) Describe() (*Description, error) {
+	stmt, err := s.toASTStatement()
+	if err != nil {
+		return nil, err
+	}
+	return describe("DELETE", s.table, stmt)
+}
+
+func describe(op, table string, stmt ast.Node) (*Description, error) {
+	params, err := internal.ParamTypes(stmt)
+	if err != nil {
+		return nil, err
+	}
+	d := &Description{Operation: op, Params: params}
+	if table != "" {
+		d.Tables = append(d.Tables, table)
+	}
+	internal.Walk(stmt, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.TableName:
+			d.Tables = appendUnique(d.Tables, n.Table.Name)
+		case *ast.Where:
+			d.FilterColumns = filterColumns(d.FilterColumns, n.Expr)
+		}
+		return true
+	})
+	return d, nil
+}
+
+// filterColumns appends the columns referenced in expr to cols.
+// Subqueries are skipped, as their WHERE clauses are visited on their own.
+func filterColumns(cols []string, expr ast.Node) []string {
+	internal.Walk(expr, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.ScalarSubQuery, *ast.ExistsSubQuery, *ast.ArraySubQuery, *ast.SubQueryInCondition:
+			return false
+		case *ast.CallExpr:
+			// Skip the function name.
+			for _, arg := range n.Args {
+				cols = filterColumns(cols, arg)
+			}
+			return false
+		case *ast.Ident:
+			cols = appendUnique(cols, n.Name)
+		case *ast.Path:
+			cols = appendUnique(cols, n.SQL())
+			return false
+		}
+		return true
+	})
+	return cols
+}
+
+func appendUnique(ss []string, s string) []string {
+	if containsString(ss, s) {
+		return ss
+	}
+	return append(ss, s)
+}
@@ -0,0 +1,50 @@
+package memeduck_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/abyssparanoia/memeduck"
+)
+
+func TestDescribe(t *testing.T) {
+	d, err := memeduck.Select("user", []string{"id", "name"}).
+		Where(
+			memeduck.Eq(memeduck.Ident("name"), memeduck.Param("name")),
+			memeduck.Exists(memeduck.Select("group", []string{"id"}).Where(memeduck.Eq(memeduck.Ident("public"), true))),
+			memeduck.Gt(memeduck.Func("LENGTH", memeduck.Ident("name")), 3),
+		).
+		OrderBy("id", memeduck.DESC).
+		Limit(10).
+		Describe()
+	assert.Nil(t, err)
+	assert.Equal(t, &memeduck.Description{
+		Operation:     "SELECT",
+		Tables:        []string{"user", "group"},
+		FilterColumns: []string{"name", "public"},
+		Ordering:      []string{"id DESC"},
+		Limit:         intPtr(10),
+		Params:        map[string]string{"name": ""},
+	}, d)
+	assert.Equal(t, "operation: SELECT\ntables: user, group\nfilter columns: name, public\nordering: id DESC\nlimit: 10\nparams: @name\n", d.String())
+
+	d, err = memeduck.Update("user").
+		Set(memeduck.Ident("name"), "foo").
+		Where(memeduck.Eq(memeduck.Ident("id"), memeduck.Param("id")), memeduck.Eq(memeduck.Param("id"), int64(1))).
+		Describe()
+	assert.Nil(t, err)
+	assert.Equal(t, "operation: UPDATE\ntables: user\nfilter columns: id\nparams: @id INT64\n", d.String())
+
+	d, err = memeduck.Delete("user").Where(memeduck.Eq(memeduck.Ident("id"), 1)).Describe()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"id"}, d.FilterColumns)
+
+	d, err = memeduck.Insert("user", []string{"id"}).Values([][]interface{}{{1}}).Describe()
+	assert.Nil(t, err)
+	assert.Equal(t, &memeduck.Description{Operation: "INSERT", Tables: []string{"user"}, Params: map[string]string{}}, d)
+}
+
+func intPtr(n int) *int {
+	return &n
+}
@@ -0,0 +1,128 @@
+package memeduck
+
+import (
+	"github.com/cloudspannerecosystem/memefish/ast"
+
+	"github.com/abyssparanoia/memeduck/internal"
+)
+
+// Dialect is a SQL dialect of Spanner databases.
+type Dialect string
+
+const (
+	// DialectGoogleSQL is the GoogleSQL dialect. It is the default.
+	DialectGoogleSQL Dialect = internal.GoogleSQL
+	// DialectPostgreSQL is the PostgreSQL dialect.
+	DialectPostgreSQL Dialect = internal.PostgreSQL
+)
+
+func (d Dialect) orDefault() Dialect {
+	if d == "" {
+		return DialectGoogleSQL
+	}
+	return d
+}
+
+// check returns an error if the feature is used but not supported in the dialect.
+func (d Dialect) check(feature string, used bool) error {
+	if !used {
+		return nil
+	}
+	return internal.CheckFeature(string(d.orDefault()), feature)
+}
+
+// render renders the statement in the dialect.
+// It also returns the names of query parameters in order of $1, $2, ... in PostgreSQL dialect.
+func (d Dialect) render(stmt ast.Node) (string, []string, error) {
+	if d.orDefault() == DialectPostgreSQL {
+		return internal.RenderPostgreSQL(stmt)
+	}
+	sql, err := renderSQL(stmt)
+	return sql, nil, err
+}
+
+// bindParams renames the values of query parameters to the names Spanner binds them to in the dialect,
+// i.e. p1, p2, ... for $1, $2, ... in PostgreSQL dialect.
+func (d Dialect) bindParams(names []string, params map[string]interface{}) map[string]interface{} {
+	if d.orDefault() != DialectPostgreSQL {
+		return params
+	}
+	bound := make(map[string]interface{}, len(params))
+	for i, name := range names {
+		if v, ok := params[name]; ok {
+			bound[internal.PostgreSQLParamName(i+1)] = v
+		}
+	}
+	return bound
+}
+
+// dialectStmt is a statement built from the AST, which can be rendered in its dialect.
+type dialectStmt interface {
+	Stmt
+	toASTStatement() (ast.Node, error)
+	sqlDialect() Dialect
+}
+
+func (s *SelectStmt) sqlDialect() Dialect {
+	return s.dialect
+}
+
+func (s *InsertStmt) sqlDialect() Dialect {
+	return s.dialect
+}
+
+func (s *UpdateStmt) sqlDialect() Dialect {
+	return s.dialect
+}
+
+func (s *DeleteStmt) sqlDialect() Dialect {
+	return s.dialect
+}
+
+// Dialect sets the SQL dialect of the SELECT statement.
+// Building the statement fails if it uses features not supported in the dialect.
+func (s *SelectStmt) Dialect(d Dialect) *SelectStmt {
+	var t = *s
+	t.dialect = d
+	return &t
+}
+
+func (s *SelectStmt) checkDialect() error {
+	for _, f := range []struct {
+		name string
+		used bool
+	}{
+		{internal.FeatureAsStruct, s.asStruct},
+		{internal.FeatureWindow, len(s.windows) > 0},
+		{internal.FeatureWithOffset, s.withOffset},
+		{internal.FeatureForceIndex, s.forceIndex != ""},
+		{internal.FeatureStatementHints, len(s.hints) > 0},
+	} {
+		if err := s.dialect.check(f.name, f.used); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Dialect sets the SQL dialect of the INSERT statement.
+// In PostgreSQL dialect, identifiers are quoted by double quotes, and query parameters are rendered as $1, $2, ....
+func (s *InsertStmt) Dialect(d Dialect) *InsertStmt {
+	var t = *s
+	t.dialect = d
+	return &t
+}
+
+// Dialect sets the SQL dialect of the UPDATE statement. See InsertStmt.Dialect.
+func (s *UpdateStmt) Dialect(d Dialect) *UpdateStmt {
+	var t = *s
+	t.dialect = d
+	return &t
+}
+
+// Dialect sets the SQL dialect of the DELETE statement. See InsertStmt.Dialect.
+func (s *DeleteStmt) Dialect(d Dialect) *DeleteStmt {
+	var t = *s
+	t.dialect = d
+	return &t
+}
@@ -0,0 +1,81 @@
+package memeduck_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/abyssparanoia/memeduck"
+)
+
+func TestDialectCapabilities(t *testing.T) {
+	base := memeduck.Select("hoge", []string{"a"})
+	testSelect(t, base.Dialect(memeduck.DialectPostgreSQL), `SELECT a FROM hoge`)
+	testSelect(t, base.AsStruct().Dialect(memeduck.DialectGoogleSQL), `SELECT AS STRUCT a FROM hoge`)
+
+	for _, stmt := range []*memeduck.SelectStmt{
+		base.AsStruct(),
+		base.ForceIndex("hoge_idx"),
+		base.ApplyPreset(memeduck.AnalyticsPreset),
+		base.Window("w", memeduck.Window().OrderBy("a", memeduck.ASC)),
+		memeduck.SelectFromUnnest(memeduck.Param("ids"), "id", []string{"id"}).WithOffset("pos"),
+	} {
+		_, err := stmt.SQL()
+		assert.Nil(t, err)
+		_, err = stmt.Dialect(memeduck.DialectPostgreSQL).SQL()
+		assert.ErrorContains(t, err, "not supported in PostgreSQL dialect")
+	}
+}
+
+func TestPostgreSQLDialect(t *testing.T) {
+	pg := memeduck.DialectPostgreSQL
+	testSelect(t,
+		memeduck.Select("Singers", []string{"singer_id", "FirstName", "order"}).
+			Where(
+				memeduck.Eq(memeduck.Ident("name"), "O'Brien"),
+				memeduck.Ne(memeduck.Ident("status"), memeduck.Param("status")),
+				memeduck.In(memeduck.Ident("singer_id"), memeduck.Unnest(memeduck.Param("ids"))),
+				memeduck.Or(memeduck.IsNull(memeduck.Ident("deleted_at")), memeduck.Gt(memeduck.Ident("deleted_at"), memeduck.Param("status"))),
+			).
+			OrderBy("singer_id", memeduck.DESC).
+			Limit(10).
+			Dialect(pg),
+		`SELECT singer_id, "FirstName", "order" FROM "Singers" WHERE name = 'O''Brien' AND status <> $1 AND singer_id = ANY($2) AND (deleted_at IS NULL OR deleted_at > $1) ORDER BY singer_id DESC LIMIT 10`,
+	)
+	testInsert(t,
+		memeduck.Insert("singers", []string{"id", "data", "tags"}).
+			Values([][]interface{}{{1, []byte("ab"), []string{"x"}}}).
+			ThenReturn("id").
+			Dialect(pg),
+		`INSERT INTO singers (id, data, tags) VALUES (1, '\x6162'::bytea, ARRAY['x']) RETURNING id`,
+	)
+	testUpdate(t,
+		memeduck.Update("singers").Set(memeduck.Ident("name"), memeduck.Param("name")).Where(memeduck.Eq(memeduck.Ident("id"), 1)).Dialect(pg),
+		`UPDATE singers SET name = $1 WHERE id = 1`,
+	)
+	testDelete(t,
+		memeduck.Delete("singers").Where(memeduck.Between(memeduck.Ident("id"), 1, 10), memeduck.IsNotNull(memeduck.Ident("x"))).Dialect(pg),
+		`DELETE FROM singers WHERE id BETWEEN 1 AND 10 AND x IS NOT NULL`,
+	)
+
+	stmt, err := memeduck.Select("singers", []string{"id"}).
+		Where(
+			memeduck.Eq(memeduck.Ident("name"), memeduck.ParamValue("name", "foo")),
+			memeduck.Gt(memeduck.Ident("age"), memeduck.ParamValue("age", 20)),
+		).
+		Dialect(pg).
+		Statement()
+	assert.Nil(t, err)
+	assert.Equal(t, "SELECT id FROM singers WHERE name = $1 AND age > $2", stmt.SQL)
+	assert.Equal(t, map[string]interface{}{"p1": "foo", "p2": 20}, stmt.Params)
+
+	sql, params, err := memeduck.Delete("singers").Where(memeduck.Eq(memeduck.Ident("id"), 1)).Dialect(pg).SQLWithParams()
+	assert.Nil(t, err)
+	assert.Equal(t, "DELETE FROM singers WHERE id = $1", sql)
+	assert.Equal(t, map[string]interface{}{"p1": int64(1)}, params)
+
+	_, err = memeduck.Insert("singers", []string{"id"}).Values([][]interface{}{{1}}).OrUpdate().Dialect(pg).SQL()
+	assert.EqualError(t, err, "INSERT OR UPDATE is not supported in PostgreSQL dialect")
+	_, err = memeduck.Delete("singers").Where(memeduck.Eq(memeduck.SafeCast(memeduck.Ident("id"), "INT64"), 1)).Dialect(pg).SQL()
+	assert.EqualError(t, err, "SAFE_CAST is not supported in PostgreSQL dialect")
+}
@@ -22,5 +22,12 @@ Struct Tags
 
 You can add `spanner:"Name"` tag to struct fields to indicate which field in struct corresponds to which column, otherwise memeduck uses field name as column name.
 See examples section of Insert function for more details.
+Options can follow the column name, separated by commas:
+
+  * pii: the column holds personal data, so RedactedSQL masks its values.
+  * omitempty: a zero value is not written, i.e. DEFAULT in INSERT and not set in UPDATE.
+  * commit_timestamp: PENDING_COMMIT_TIMESTAMP() is written instead of the value.
+  * readonly: the field is never written.
+  * key: the column is a part of the primary key, so UpdateStmt.SetStruct doesn't set it.
 */
 package memeduck
@@ -0,0 +1,41 @@
+package memeduck
+
+import (
+	stderrors "errors"
+
+	"github.com/abyssparanoia/memeduck/internal"
+)
+
+var (
+	// ErrNoColumns is returned when a statement has no columns to select or insert.
+	ErrNoColumns = stderrors.New("no columns specified")
+	// ErrEmptyValues is returned when an INSERT statement has no rows to insert.
+	ErrEmptyValues = stderrors.New("empty values")
+	// ErrMissingWhere is returned when an UPDATE or DELETE statement has no WHERE clause, which Spanner requires.
+	// Use Where(Bool(true)) to update or delete all rows.
+	ErrMissingWhere = stderrors.New("no WHERE clause is specified")
+)
+
+// UnsupportedTypeError is returned when a Go value can't be converted into a SQL expression.
+// It can be checked by errors.As even if wrapped with other messages.
+type UnsupportedTypeError = internal.UnsupportedTypeError
+
+// errorList collects errors found while building a statement, so that all of them are reported at once.
+type errorList []error
+
+func (l *errorList) add(err error) {
+	if err != nil {
+		*l = append(*l, err)
+	}
+}
+
+// err returns the collected errors joined by errors.Join, or the error itself if there is only one.
+func (l errorList) err() error {
+	switch len(l) {
+	case 0:
+		return nil
+	case 1:
+		return l[0]
+	}
+	return stderrors.Join(l...)
+}
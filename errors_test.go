@@ -0,0 +1,55 @@
+package memeduck_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/abyssparanoia/memeduck"
+)
+
+func TestAggregatedErrors(t *testing.T) {
+	_, err := memeduck.Select("user", []string{"id", ""}).
+		Where(
+			memeduck.Eq(memeduck.Ident("a"), func() {}),
+			memeduck.Eq(memeduck.Ident(""), 1),
+		).
+		OrderBy("", memeduck.ASC).
+		SQL()
+	assert.EqualError(t, err, "can't convert func() into SQL expr\nempty identifier\nempty identifier\nempty identifier")
+
+	_, err = memeduck.Insert("user", []string{"id", "name"}).
+		Values([][]interface{}{{1, func() {}}, {2, "ok"}, {struct{}{}, "x"}}).
+		SQL()
+	assert.EqualError(t, err, "can't convert []interface {} into SQL row: can't convert func() into SQL expr\n"+
+		"can't convert []interface {} into SQL row: can't convert struct {} into SQL expr")
+
+	_, err = memeduck.Update("").
+		Set(memeduck.Ident("a"), func() {}).
+		Where(memeduck.Eq(memeduck.Ident("id"), func() {})).
+		SQL()
+	assert.EqualError(t, err, "can't convert func() into SQL expr\ncan't convert func() into SQL expr\nempty identifier")
+
+	_, err = memeduck.Delete("").Where(memeduck.Eq(memeduck.Ident("id"), 1)).SQL()
+	assert.EqualError(t, err, "empty identifier")
+}
+
+func TestSentinelErrors(t *testing.T) {
+	_, err := memeduck.Select("user", nil).SQL()
+	assert.ErrorIs(t, err, memeduck.ErrNoColumns)
+	_, err = memeduck.Insert("user", nil).Values([][]interface{}{{1}}).SQL()
+	assert.ErrorIs(t, err, memeduck.ErrNoColumns)
+	_, err = memeduck.Insert("user", []string{"id"}).Values([][]interface{}{}).SQL()
+	assert.ErrorIs(t, err, memeduck.ErrEmptyValues)
+	_, err = memeduck.Update("user").Set(memeduck.Ident("a"), 1).SQL()
+	assert.ErrorIs(t, err, memeduck.ErrMissingWhere)
+	_, err = memeduck.Delete("user").SQL()
+	assert.ErrorIs(t, err, memeduck.ErrMissingWhere)
+
+	_, err = memeduck.Insert("user", []string{"id", "f"}).Values([][]interface{}{{1, func() {}}}).SQL()
+	var typeErr *memeduck.UnsupportedTypeError
+	assert.True(t, errors.As(err, &typeErr))
+	assert.Equal(t, reflect.TypeOf(func() {}), typeErr.Type)
+}
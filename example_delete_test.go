@@ -31,3 +31,17 @@ func ExampleDelete_queryParameter() {
 	fmt.Println(query)
 	// Output: DELETE FROM user WHERE id = @id
 }
+
+func ExampleSelectStmt_WhereConds() {
+	preview := memeduck.Select("user", []string{"id", "name"}).Where(
+		memeduck.Lt(memeduck.Ident("last_login_at"), memeduck.Param("threshold")),
+		memeduck.Eq(memeduck.Ident("unused"), true),
+	)
+	previewQuery, _ := preview.SQL()
+	deleteQuery, _ := memeduck.Delete("user").Where(preview.WhereConds()...).SQL()
+	fmt.Println(previewQuery)
+	fmt.Println(deleteQuery)
+	// Output:
+	// SELECT id, name FROM user WHERE last_login_at < @threshold AND unused = TRUE
+	// DELETE FROM user WHERE last_login_at < @threshold AND unused = TRUE
+}
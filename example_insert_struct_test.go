@@ -18,3 +18,11 @@ func ExampleInsert_struct() {
 	fmt.Println(query)
 	// Output: INSERT INTO users (UserName, PapaName) VALUES ("Kiara", "huke")
 }
+
+func ExampleInsertStruct() {
+	query, _ := memeduck.InsertStruct("users", []ExampleUserStruct{
+		{Name: "Kiara", Papa: "huke"},
+	}).SQL()
+	fmt.Println(query)
+	// Output: INSERT INTO users (UserName, PapaName) VALUES ("Kiara", "huke")
+}
@@ -108,3 +108,45 @@ func ExampleSelect_queryMultiSubQuery() {
 	fmt.Println(query)
 	// Output: SELECT name, ARRAY(SELECT AS STRUCT item_id, count FROM user_item WHERE user_id = "user-id") AS user_item, ARRAY(SELECT AS STRUCT state FROM user_status WHERE user_id = "user-id") AS user_status FROM user WHERE user_id = "user-id"
 }
+
+func ExampleSelect_countDistinct() {
+	query, _ := memeduck.Select("user_item", nil).
+		Items(memeduck.Count(memeduck.Ident("user_id")).Distinct().As("users")).
+		Where(memeduck.Eq(memeduck.Ident("item_id"), memeduck.Param("item_id"))).
+		SQL()
+	fmt.Println(query)
+	// Output: SELECT COUNT(DISTINCT user_id) AS users FROM user_item WHERE item_id = @item_id
+}
+
+func ExampleOver() {
+	query, _ := memeduck.Select("score", []string{"user_id", "point"}).
+		Items(memeduck.Over(memeduck.Rank(), memeduck.Window().
+			PartitionBy("stage_id").
+			OrderBy("point", memeduck.DESC)).As("ranking")).
+		SQL()
+	fmt.Println(query)
+	// Output: SELECT user_id, point, RANK() OVER (PARTITION BY stage_id ORDER BY point DESC) AS ranking FROM score
+}
+
+func ExampleSelectStmt_WithTotal() {
+	page, total := memeduck.Select("user", []string{"name", "created_at"}).
+		Where(memeduck.Eq(memeduck.Ident("likes"), "alcohol")).
+		OrderBy("created_at", memeduck.DESC).
+		LimitOffset(10, 20).
+		WithTotal()
+	pageQuery, _ := page.SQL()
+	totalQuery, _ := total.SQL()
+	fmt.Println(pageQuery)
+	fmt.Println(totalQuery)
+	// Output:
+	// SELECT name, created_at FROM user WHERE likes = "alcohol" ORDER BY created_at DESC LIMIT 10 OFFSET 20
+	// SELECT COUNT(*) FROM user WHERE likes = "alcohol"
+}
+
+func ExampleSelectFromUnnest() {
+	query, _ := memeduck.SelectFromUnnest(memeduck.Param("ids"), "id", []string{"id"}).
+		Where(memeduck.NotIn(memeduck.Ident("id"), memeduck.Unnest(memeduck.Param("excluded_ids")))).
+		SQL()
+	fmt.Println(query)
+	// Output: SELECT id FROM UNNEST(@ids) AS id WHERE id NOT IN UNNEST(@excluded_ids)
+}
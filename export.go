@@ -0,0 +1,69 @@
+package memeduck
+
+import (
+	"strings"
+
+	"github.com/cloudspannerecosystem/memefish/ast"
+)
+
+// ExportColumn is a column of a table to be exported.
+type ExportColumn struct {
+	Name string
+	Type string
+}
+
+// ExportOptions configures ExportQuery.
+type ExportOptions struct {
+	// NumericAsString casts NUMERIC columns to STRING, since many export formats have no exact decimal type.
+	NumericAsString bool
+	// JSONAsString casts JSON columns to STRING.
+	JSONAsString bool
+}
+
+// ExportQuery creates a query selecting all the given columns of table for export jobs such as CSV or Avro exports,
+// with the shape of its results. Columns of types unsupported by the export format are cast according to opts,
+// keeping their column names. opts may be nil.
+func ExportQuery(table string, cols []*ExportColumn, opts *ExportOptions) (*SelectStmt, []*ResultColumn, error) {
+	if opts == nil {
+		opts = &ExportOptions{}
+	}
+	if len(cols) <= 0 {
+		return nil, nil, ErrNoColumns
+	}
+	stmt := Select(table, []string{})
+	shape := make([]*ResultColumn, 0, len(cols))
+	for _, col := range cols {
+		typ := strings.ToUpper(col.Type)
+		castTo := opts.exportType(typ)
+		if castTo == typ {
+			stmt = stmt.Items(columnItem(col.Name))
+		} else {
+			stmt = stmt.Items(Cast(Ident(col.Name), castTo).As(col.Name))
+		}
+		shape = append(shape, &ResultColumn{Name: col.Name, Type: castTo})
+	}
+	return stmt, shape, nil
+}
+
+// exportType returns the type which a column of typ is exported as.
+func (o *ExportOptions) exportType(typ string) string {
+	if elem := strings.TrimSuffix(strings.TrimPrefix(typ, "ARRAY<"), ">"); elem != typ {
+		return "ARRAY<" + o.exportType(elem) + ">"
+	}
+	switch {
+	case typ == "NUMERIC" && o.NumericAsString:
+		return "STRING"
+	case typ == "JSON" && o.JSONAsString:
+		return "STRING"
+	}
+	return typ
+}
+
+// columnItem is a column name appearing in SELECT results.
+type columnItem string
+
+func (c columnItem) ToAST() (ast.SelectItem, error) {
+	return &ast.ExprSelectItem{
+		Expr: &ast.Ident{Name: string(c)},
+	}, nil
+}
@@ -0,0 +1,41 @@
+package memeduck_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/abyssparanoia/memeduck"
+)
+
+func TestExportQuery(t *testing.T) {
+	cols := []*memeduck.ExportColumn{
+		{Name: "id", Type: "STRING"},
+		{Name: "price", Type: "NUMERIC"},
+		{Name: "history", Type: "ARRAY<NUMERIC>"},
+		{Name: "attrs", Type: "JSON"},
+	}
+
+	stmt, shape, err := memeduck.ExportQuery("item", cols, nil)
+	assert.Nil(t, err)
+	testSelect(t, stmt, `SELECT id, price, history, attrs FROM item`)
+	assert.Equal(t, []*memeduck.ResultColumn{
+		{Name: "id", Type: "STRING"},
+		{Name: "price", Type: "NUMERIC"},
+		{Name: "history", Type: "ARRAY<NUMERIC>"},
+		{Name: "attrs", Type: "JSON"},
+	}, shape)
+
+	stmt, shape, err = memeduck.ExportQuery("item", cols, &memeduck.ExportOptions{NumericAsString: true})
+	assert.Nil(t, err)
+	testSelect(t, stmt, `SELECT id, CAST(price AS STRING) AS price, CAST(history AS ARRAY<STRING>) AS history, attrs FROM item`)
+	assert.Equal(t, []*memeduck.ResultColumn{
+		{Name: "id", Type: "STRING"},
+		{Name: "price", Type: "STRING"},
+		{Name: "history", Type: "ARRAY<STRING>"},
+		{Name: "attrs", Type: "JSON"},
+	}, shape)
+
+	_, _, err = memeduck.ExportQuery("item", nil, nil)
+	assert.Error(t, err)
+}
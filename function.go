@@ -0,0 +1,143 @@
+package memeduck
+
+import (
+	"github.com/cloudspannerecosystem/memefish/ast"
+
+	"github.com/abyssparanoia/memeduck/internal"
+)
+
+// FuncExpr is a function call expression.
+type FuncExpr struct {
+	name string
+	args []interface{}
+	as   string
+}
+
+// Func creates `name(args...)` function call, e.g. Func("LOWER", Ident("name")).
+// It can be used to call any function which has no dedicated helper.
+// A dotted name calls the function by its path, e.g. Func("SAFE.LOWER", Ident("name")) or Func("NET.HOST", Ident("url")).
+func Func(name string, args ...interface{}) *FuncExpr {
+	return &FuncExpr{name: name, args: args}
+}
+
+// As sets an alias used when the function call appears in SELECT results.
+func (e *FuncExpr) As(as string) *FuncExpr {
+	var t = *e
+	t.as = as
+	return &t
+}
+
+func (e *FuncExpr) ToASTExpr() (ast.Expr, error) {
+	args := make([]ast.Arg, 0, len(e.args))
+	for _, a := range e.args {
+		arg, err := internal.ToExpr(a)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, &ast.ExprArg{Expr: arg})
+	}
+	return internal.Call(e.name, false, args), nil
+}
+
+func (e *FuncExpr) ToAST() (ast.SelectItem, error) {
+	expr, err := e.ToASTExpr()
+	if err != nil {
+		return nil, err
+	}
+	return toASTSelectItem(expr, e.as), nil
+}
+
+func (e *FuncExpr) ToASTWhere() (*ast.Where, error) {
+	expr, err := e.ToASTExpr()
+	if err != nil {
+		return nil, err
+	}
+	return &ast.Where{
+		Expr: expr,
+	}, nil
+}
+
+// Collate creates `COLLATE(x, spec)` function call, which attaches a collation specification like "und:ci" to a string.
+func Collate(x interface{}, spec string) *FuncExpr {
+	return Func("COLLATE", x, spec)
+}
+
+// CollateCompare(x, op, y, spec) compares x and y under the collation specification,
+// i.e. `COLLATE(x, spec) op COLLATE(y, spec)`.
+func CollateCompare(lhs interface{}, op BinaryOp, rhs interface{}, spec string) *OpCond {
+	return Op(Collate(lhs, spec), op, Collate(rhs, spec))
+}
+
+// CollateEq(x, y, spec) is a shorthand for CollateCompare(x, EQ, y, spec)
+func CollateEq(lhs, rhs interface{}, spec string) *OpCond {
+	return CollateCompare(lhs, EQ, rhs, spec)
+}
+
+// Coalesce creates `COALESCE(xs...)` function call, which returns the first non-NULL value.
+func Coalesce(xs ...interface{}) *FuncExpr {
+	return Func("COALESCE", xs...)
+}
+
+// IfNull creates `IFNULL(x, y)` function call, which returns y if x is NULL, otherwise x.
+func IfNull(x, y interface{}) *FuncExpr {
+	return Func("IFNULL", x, y)
+}
+
+// NullIf creates `NULLIF(x, y)` function call, which returns NULL if x = y, otherwise x.
+func NullIf(x, y interface{}) *FuncExpr {
+	return Func("NULLIF", x, y)
+}
+
+// If creates `IF(cond, then, els)` function call, which returns then if cond is true, otherwise els.
+func If(cond WhereCond, then, els interface{}) *FuncExpr {
+	return Func("IF", &condExpr{cond: cond}, then, els)
+}
+
+// condExpr converts WhereCond into an expression.
+type condExpr struct {
+	cond WhereCond
+}
+
+func (e *condExpr) ToASTExpr() (ast.Expr, error) {
+	where, err := e.cond.ToASTWhere()
+	if err != nil {
+		return nil, err
+	}
+	return where.Expr, nil
+}
+
+// Concat creates `CONCAT(xs...)` function call, which concatenates strings or bytes.
+// It can be used to build LIKE patterns from columns or parameters, e.g. Like(Ident("name"), Concat(Param("prefix"), "%")).
+func Concat(xs ...interface{}) *FuncExpr {
+	return Func("CONCAT", xs...)
+}
+
+// StartsWith creates `STARTS_WITH(x, prefix)` condition.
+func StartsWith(x, prefix interface{}) *FuncExpr {
+	return Func("STARTS_WITH", x, prefix)
+}
+
+// EndsWith creates `ENDS_WITH(x, suffix)` condition.
+func EndsWith(x, suffix interface{}) *FuncExpr {
+	return Func("ENDS_WITH", x, suffix)
+}
+
+// RegexpContains creates `REGEXP_CONTAINS(x, regexp)` condition.
+func RegexpContains(x, regexp interface{}) *FuncExpr {
+	return Func("REGEXP_CONTAINS", x, regexp)
+}
+
+// JSONValueAt creates `JSON_VALUE(x, path)` function call, which extracts a scalar value at the JSONPath as STRING.
+func JSONValueAt(x interface{}, path string) *FuncExpr {
+	return Func("JSON_VALUE", x, path)
+}
+
+// JSONQuery creates `JSON_QUERY(x, path)` function call, which extracts a JSON value at the JSONPath.
+func JSONQuery(x interface{}, path string) *FuncExpr {
+	return Func("JSON_QUERY", x, path)
+}
+
+// FromBase64 creates `FROM_BASE64(s)` function call, which decodes a base64-encoded string into BYTES.
+func FromBase64(s interface{}) *FuncExpr {
+	return Func("FROM_BASE64", s)
+}
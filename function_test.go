@@ -0,0 +1,95 @@
+package memeduck_test
+
+import (
+	"testing"
+
+	"github.com/abyssparanoia/memeduck"
+)
+
+func TestCollate(t *testing.T) {
+	testExpr(t, memeduck.Collate(memeduck.Ident("name"), "und:ci"), `COLLATE(name, "und:ci")`)
+	testWhere(t,
+		memeduck.CollateEq(memeduck.Ident("name"), memeduck.Param("name"), "und:ci"),
+		`(COLLATE(name, "und:ci")) = (COLLATE(@name, "und:ci"))`,
+	)
+	testWhere(t,
+		memeduck.CollateCompare(memeduck.Ident("name"), memeduck.LT, "b", "en"),
+		`(COLLATE(name, "en")) < (COLLATE("b", "en"))`,
+	)
+	testSelect(t,
+		memeduck.Select("user", []string{"name"}).
+			OrderByCollate("name", "und:ci", memeduck.ASC).
+			OrderBy("id", memeduck.ASC),
+		`SELECT name FROM user ORDER BY name COLLATE "und:ci" ASC, id ASC`,
+	)
+}
+
+func TestFunc(t *testing.T) {
+	testExpr(t, memeduck.Func("CURRENT_TIMESTAMP"), `CURRENT_TIMESTAMP()`)
+	testExpr(t, memeduck.Func("LOWER", memeduck.Ident("name")), `LOWER(name)`)
+	testExpr(t,
+		memeduck.Func("TIMESTAMP_TRUNC", memeduck.Ident("created_at"), memeduck.Ident("DAY")),
+		`TIMESTAMP_TRUNC(created_at, DAY)`,
+	)
+	testWhere(t,
+		memeduck.Eq(memeduck.Func("LOWER", memeduck.Ident("name")), memeduck.Param("name")),
+		`LOWER(name) = @name`,
+	)
+	testWhere(t, memeduck.Func("STARTS_WITH", memeduck.Ident("name"), "a"), `STARTS_WITH(name, "a")`)
+	testExpr(t, memeduck.Func("SAFE.LOWER", memeduck.Ident("name")), `SAFE.LOWER(name)`)
+	testExpr(t, memeduck.Func("NET.HOST", memeduck.Ident("url")), `NET.HOST(url)`)
+	testSelect(t,
+		memeduck.Select("page", []string{}).Items(memeduck.Func("NET.HOST", memeduck.Ident("url")).As("host")),
+		`SELECT NET.HOST(url) AS host FROM page`,
+	)
+	testSelect(t,
+		memeduck.Select("user", []string{}).Items(memeduck.Func("UPPER", memeduck.Ident("name")).As("upper_name")),
+		`SELECT UPPER(name) AS upper_name FROM user`,
+	)
+	testUpdate(t,
+		memeduck.Update("user").Set(memeduck.Ident("name"), memeduck.Func("TRIM", memeduck.Param("name"))).Where(memeduck.Bool(true)),
+		`UPDATE user SET name = TRIM(@name) WHERE TRUE`,
+	)
+}
+
+func TestNullHandlingFuncs(t *testing.T) {
+	testExpr(t, memeduck.Coalesce(memeduck.Param("name"), memeduck.Ident("name")), `COALESCE(@name, name)`)
+	testExpr(t, memeduck.IfNull(memeduck.Ident("count"), 0), `IFNULL(count, 0)`)
+	testExpr(t, memeduck.NullIf(memeduck.Ident("name"), ""), `NULLIF(name, "")`)
+	testExpr(t,
+		memeduck.If(memeduck.Gt(memeduck.Ident("count"), 0), "some", "none"),
+		`IF(count > 0, "some", "none")`,
+	)
+	testUpdate(t,
+		memeduck.Update("user").
+			Set(memeduck.Ident("name"), memeduck.Coalesce(memeduck.Param("name"), memeduck.Ident("name"))).
+			Where(memeduck.Eq(memeduck.Ident("id"), memeduck.Param("id"))),
+		`UPDATE user SET name = COALESCE(@name, name) WHERE id = @id`,
+	)
+}
+
+func TestConcat(t *testing.T) {
+	testExpr(t, memeduck.Concat(memeduck.Ident("first_name"), " ", memeduck.Ident("last_name")), `CONCAT(first_name, " ", last_name)`)
+	testWhere(t,
+		memeduck.Like(memeduck.Ident("name"), memeduck.Concat(memeduck.Param("prefix"), "%")),
+		`name LIKE CONCAT(@prefix, "%")`,
+	)
+	testSelect(t,
+		memeduck.Select("user", []string{}).Items(memeduck.Concat(memeduck.Ident("first_name"), memeduck.Ident("last_name")).As("full_name")),
+		`SELECT CONCAT(first_name, last_name) AS full_name FROM user`,
+	)
+}
+
+func TestStringPredicates(t *testing.T) {
+	testWhere(t, memeduck.StartsWith(memeduck.Ident("name"), "abc"), `STARTS_WITH(name, "abc")`)
+	testWhere(t, memeduck.StartsWith(memeduck.Ident("name"), memeduck.Param("prefix")), `STARTS_WITH(name, @prefix)`)
+	testWhere(t, memeduck.EndsWith(memeduck.Ident("email"), "@example.com"), `ENDS_WITH(email, "@example.com")`)
+	testWhere(t, memeduck.RegexpContains(memeduck.Ident("code"), `^[A-Z]{3}$`), `REGEXP_CONTAINS(code, "^[A-Z]{3}$")`)
+	testWhere(t,
+		memeduck.And(
+			memeduck.StartsWith(memeduck.Ident("name"), "_"),
+			memeduck.RegexpContains(memeduck.Ident("name"), memeduck.Param("re")),
+		),
+		`STARTS_WITH(name, "_") AND REGEXP_CONTAINS(name, @re)`,
+	)
+}
@@ -0,0 +1,90 @@
+package memeduck
+
+import (
+	"github.com/cloudspannerecosystem/memefish/ast"
+
+	"github.com/abyssparanoia/memeduck/internal"
+)
+
+type hint struct {
+	key   string
+	value interface{}
+}
+
+func hintsToAST(hints []*hint) (*ast.Hint, error) {
+	h := &ast.Hint{}
+	for _, hi := range hints {
+		value, err := internal.ToExpr(hi.value)
+		if err != nil {
+			return nil, err
+		}
+		h.Records = append(h.Records, &ast.HintRecord{
+			Key:   &ast.Ident{Name: hi.key},
+			Value: value,
+		})
+	}
+	return h, nil
+}
+
+func setHint(hints []*hint, key string, value interface{}) []*hint {
+	res := make([]*hint, 0, len(hints)+1)
+	for _, h := range hints {
+		if h.key != key {
+			res = append(res, h)
+		}
+	}
+	return append(res, &hint{key: key, value: value})
+}
+
+// Hint sets a statement hint like `@{USE_ADDITIONAL_PARALLELISM=TRUE}`.
+// Use Ident for keyword values, e.g. Hint("SCAN_METHOD", Ident("BATCH")).
+// It replaces an existing hint with the same key.
+func (s *SelectStmt) Hint(key string, value interface{}) *SelectStmt {
+	var t = *s
+	t.hints = setHint(s.hints, key, value)
+	return &t
+}
+
+// HintPreset is a named set of statement hints for a particular kind of workload.
+type HintPreset struct {
+	name  string
+	hints []*hint
+}
+
+// NewHintPreset creates a new HintPreset with no hints.
+func NewHintPreset(name string) *HintPreset {
+	return &HintPreset{name: name}
+}
+
+// Name returns the name of the preset.
+func (p *HintPreset) Name() string {
+	return p.name
+}
+
+// Hint adds a statement hint to the preset.
+func (p *HintPreset) Hint(key string, value interface{}) *HintPreset {
+	var t = *p
+	t.hints = setHint(p.hints, key, value)
+	return &t
+}
+
+// AnalyticsPreset is for large scans such as reporting queries.
+// It enables additional parallelism and the batch-oriented scan method.
+var AnalyticsPreset = NewHintPreset("analytics").
+	Hint("USE_ADDITIONAL_PARALLELISM", true).
+	Hint("SCAN_METHOD", Ident("BATCH"))
+
+// LowLatencyPreset is for small point reads on serving paths.
+// It uses the row-oriented scan method.
+var LowLatencyPreset = NewHintPreset("low_latency").
+	Hint("SCAN_METHOD", Ident("ROW"))
+
+// ApplyPreset sets all statement hints of the preset.
+// Hints already set with the same keys are replaced.
+func (s *SelectStmt) ApplyPreset(p *HintPreset) *SelectStmt {
+	var t = *s
+	for _, h := range p.hints {
+		t.hints = setHint(t.hints, h.key, h.value)
+	}
+	return &t
+}
@@ -0,0 +1,34 @@
+package memeduck_test
+
+import (
+	"testing"
+
+	"github.com/abyssparanoia/memeduck"
+)
+
+func TestSelectHint(t *testing.T) {
+	testSelect(t,
+		memeduck.Select("hoge", []string{"a"}).Hint("OPTIMIZER_VERSION", 5),
+		`@{OPTIMIZER_VERSION=5} SELECT a FROM hoge`,
+	)
+	testSelect(t,
+		memeduck.Select("hoge", []string{"a"}).ApplyPreset(memeduck.AnalyticsPreset),
+		`@{USE_ADDITIONAL_PARALLELISM=TRUE, SCAN_METHOD=BATCH} SELECT a FROM hoge`,
+	)
+	testSelect(t,
+		memeduck.Select("hoge", []string{"a"}).
+			ApplyPreset(memeduck.AnalyticsPreset).
+			ApplyPreset(memeduck.LowLatencyPreset).
+			ForceIndex("hoge_idx"),
+		`@{USE_ADDITIONAL_PARALLELISM=TRUE, SCAN_METHOD=ROW} SELECT a FROM hoge @{FORCE_INDEX=hoge_idx}`,
+	)
+	preset := memeduck.NewHintPreset("custom").Hint("ALLOW_DISTRIBUTED_MERGE", false)
+	testSelect(t,
+		memeduck.Select("hoge", []string{"a"}).ApplyPreset(preset),
+		`@{ALLOW_DISTRIBUTED_MERGE=FALSE} SELECT a FROM hoge`,
+	)
+	testExpr(t,
+		memeduck.Select("hoge", []string{"a"}).ApplyPreset(memeduck.LowLatencyPreset),
+		`(SELECT a FROM hoge)`,
+	)
+}
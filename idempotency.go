@@ -0,0 +1,107 @@
+package memeduck
+
+import (
+	"github.com/cloudspannerecosystem/memefish/ast"
+	"github.com/pkg/errors"
+
+	"github.com/abyssparanoia/memeduck/internal"
+)
+
+// IdempotencyKeyUnused creates a condition which holds only if key is not recorded in the keyCol column of the dedupe table,
+// i.e. `NOT EXISTS(SELECT keyCol FROM table WHERE keyCol = key)`.
+// Adding it to Where of UPDATE or DELETE statements makes them no-op when retried after the key is recorded.
+func IdempotencyKeyUnused(table, keyCol string, key interface{}) *ExistsCond {
+	return NotExists(Select(table, []string{keyCol}).Where(Eq(Ident(keyCol), key)))
+}
+
+// IdempotencyKeyUnused makes the INSERT statement insert the rows only if key is not recorded
+// in the keyCol column of the dedupe table, by wrapping them in a guarded SELECT like
+// `INSERT INTO t (a, b) SELECT * FROM (SELECT 1 AS a, "x" AS b UNION ALL SELECT 2, "y") WHERE NOT EXISTS(...)`.
+// Rows can't have DEFAULT, and it is not supported in PostgreSQL dialect. See IdempotencyKeyUnused.
+func (s *InsertStmt) IdempotencyKeyUnused(table, keyCol string, key interface{}) *InsertStmt {
+	var t = *s
+	t.keyUnused = IdempotencyKeyUnused(table, keyCol, key)
+	return &t
+}
+
+// guardInput converts input, VALUES rows or a SELECT query, into the SELECT query of its rows guarded by keyUnused.
+func (s *InsertStmt) guardInput(input ast.InsertInput) (ast.InsertInput, error) {
+	if err := s.dialect.check(internal.FeatureInsertGuard, true); err != nil {
+		return nil, err
+	}
+	var query ast.QueryExpr
+	switch input := input.(type) {
+	case *ast.SubQueryInput:
+		query = input.Query
+	case *ast.ValuesInput:
+		compound := &ast.CompoundQuery{Op: ast.SetOpUnion}
+		for i, row := range input.Rows {
+			sel := &ast.Select{}
+			for j, e := range row.Exprs {
+				if e.Default {
+					return nil, errors.New("DEFAULT can't be inserted with an idempotency key")
+				}
+				var item ast.SelectItem = &ast.ExprSelectItem{Expr: e.Expr}
+				if i == 0 && j < len(s.cols) {
+					item = &ast.Alias{Expr: e.Expr, As: &ast.AsAlias{Alias: internal.Ident(s.cols[j])}}
+				}
+				sel.Results = append(sel.Results, item)
+			}
+			compound.Queries = append(compound.Queries, sel)
+		}
+		query = compound
+		if len(compound.Queries) == 1 {
+			query = compound.Queries[0]
+		}
+	default:
+		return nil, errors.Errorf("%T can't be guarded by an idempotency key", input)
+	}
+	where, err := s.keyUnused.ToASTWhere()
+	if err != nil {
+		return nil, err
+	}
+	return &ast.SubQueryInput{Query: &ast.Select{
+		Results: []ast.SelectItem{&ast.Star{}},
+		From:    &ast.From{Source: &ast.SubQueryTableExpr{Query: query}},
+		Where:   where,
+	}}, nil
+}
+
+// Idempotent marks whether the INSERT statement is safe to be retried on at-least-once delivery.
+// It doesn't change the SQL.
+func (s *InsertStmt) Idempotent(idempotent bool) *InsertStmt {
+	var t = *s
+	t.idempotent = idempotent
+	return &t
+}
+
+// IsIdempotent reports whether the INSERT statement is marked idempotent.
+func (s *InsertStmt) IsIdempotent() bool {
+	return s.idempotent
+}
+
+// Idempotent marks whether the UPDATE statement is safe to be retried on at-least-once delivery.
+// It doesn't change the SQL.
+func (s *UpdateStmt) Idempotent(idempotent bool) *UpdateStmt {
+	var t = *s
+	t.idempotent = idempotent
+	return &t
+}
+
+// IsIdempotent reports whether the UPDATE statement is marked idempotent.
+func (s *UpdateStmt) IsIdempotent() bool {
+	return s.idempotent
+}
+
+// Idempotent marks whether the DELETE statement is safe to be retried on at-least-once delivery.
+// It doesn't change the SQL.
+func (s *DeleteStmt) Idempotent(idempotent bool) *DeleteStmt {
+	var t = *s
+	t.idempotent = idempotent
+	return &t
+}
+
+// IsIdempotent reports whether the DELETE statement is marked idempotent.
+func (s *DeleteStmt) IsIdempotent() bool {
+	return s.idempotent
+}
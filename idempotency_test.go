@@ -0,0 +1,70 @@
+package memeduck_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/abyssparanoia/memeduck"
+)
+
+func TestExists(t *testing.T) {
+	testWhere(t,
+		memeduck.Exists(memeduck.Select("fuga", []string{"id"}).Where(memeduck.Eq(memeduck.Ident("id"), 1))),
+		`EXISTS(SELECT id FROM fuga WHERE id = 1)`,
+	)
+	testWhere(t,
+		memeduck.NotExists(memeduck.Select("fuga", []string{"id"})),
+		`NOT EXISTS(SELECT id FROM fuga)`,
+	)
+}
+
+func TestIdempotency(t *testing.T) {
+	stmt := memeduck.Update("account").
+		Set(memeduck.Ident("balance"), memeduck.Param("balance")).
+		Where(
+			memeduck.Eq(memeduck.Ident("id"), memeduck.Param("id")),
+			memeduck.IdempotencyKeyUnused("processed_event", "event_id", memeduck.Param("event_id")),
+		).
+		Idempotent(true)
+	testUpdate(t, stmt,
+		`UPDATE account SET balance = @balance WHERE id = @id AND NOT EXISTS(SELECT event_id FROM processed_event WHERE event_id = @event_id)`,
+	)
+	assert.True(t, stmt.IsIdempotent())
+	assert.False(t, stmt.Idempotent(false).IsIdempotent())
+	assert.True(t, memeduck.Delete("hoge").Idempotent(true).IsIdempotent())
+	assert.False(t, memeduck.Delete("hoge").IsIdempotent())
+	assert.True(t, memeduck.Insert("hoge", []string{"a"}).Idempotent(true).IsIdempotent())
+}
+
+func TestInsertIdempotencyKeyUnused(t *testing.T) {
+	testInsert(t,
+		memeduck.Insert("account", []string{"id", "balance"}).
+			Values([][]interface{}{{1, 100}, {2, memeduck.Param("balance")}}).
+			IdempotencyKeyUnused("processed_event", "event_id", memeduck.Param("event_id")),
+		`INSERT INTO account (id, balance) SELECT * FROM (SELECT 1 AS id, 100 AS balance UNION ALL SELECT 2, @balance) WHERE NOT EXISTS(SELECT event_id FROM processed_event WHERE event_id = @event_id)`,
+	)
+	testInsert(t,
+		memeduck.Insert("account", []string{"id"}).
+			Values([][]interface{}{{1}}).
+			IdempotencyKeyUnused("processed_event", "event_id", "e1"),
+		`INSERT INTO account (id) SELECT * FROM (SELECT 1 AS id) WHERE NOT EXISTS(SELECT event_id FROM processed_event WHERE event_id = "e1")`,
+	)
+	testInsert(t,
+		memeduck.Insert("account", []string{"id"}).
+			Select(memeduck.Select("staging", []string{"id"})).
+			IdempotencyKeyUnused("processed_event", "event_id", "e1"),
+		`INSERT INTO account (id) SELECT * FROM (SELECT id FROM staging) WHERE NOT EXISTS(SELECT event_id FROM processed_event WHERE event_id = "e1")`,
+	)
+	_, err := memeduck.Insert("account", []string{"id"}).
+		Values([][]interface{}{{memeduck.Default()}}).
+		IdempotencyKeyUnused("processed_event", "event_id", "e1").
+		SQL()
+	assert.EqualError(t, err, "DEFAULT can't be inserted with an idempotency key")
+	_, err = memeduck.Insert("account", []string{"id"}).
+		Values([][]interface{}{{1}}).
+		IdempotencyKeyUnused("processed_event", "event_id", "e1").
+		Dialect(memeduck.DialectPostgreSQL).
+		SQL()
+	assert.EqualError(t, err, "IdempotencyKeyUnused of INSERT is not supported in PostgreSQL dialect")
+}
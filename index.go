@@ -0,0 +1,139 @@
+package memeduck
+
+import (
+	"github.com/cloudspannerecosystem/memefish/ast"
+	"github.com/pkg/errors"
+)
+
+// CreateIndexStmt is a CREATE INDEX statement.
+type CreateIndexStmt struct {
+	name         string
+	table        string
+	keys         []*KeyPart
+	storing      []string
+	interleave   string
+	unique       bool
+	nullFiltered bool
+	ifNotExists  bool
+}
+
+// CreateIndex creates a new CreateIndexStmt with given index name and table name.
+func CreateIndex(name, table string) *CreateIndexStmt {
+	return &CreateIndexStmt{name: name, table: table}
+}
+
+// Key appends a key part of the index. dir can be empty for the default ascending order.
+func (s *CreateIndexStmt) Key(col string, dir Direction) *CreateIndexStmt {
+	var t = *s
+	t.keys = append(append([]*KeyPart(nil), t.keys...), &KeyPart{Column: col, Dir: dir})
+	return &t
+}
+
+// Storing appends columns to the STORING clause, which are stored in the index to avoid joining the table.
+func (s *CreateIndexStmt) Storing(cols ...string) *CreateIndexStmt {
+	var t = *s
+	t.storing = append(append([]string(nil), t.storing...), cols...)
+	return &t
+}
+
+// InterleaveIn interleaves the index in the table parent, which must be an ancestor of the indexed table.
+func (s *CreateIndexStmt) InterleaveIn(parent string) *CreateIndexStmt {
+	var t = *s
+	t.interleave = parent
+	return &t
+}
+
+// Unique makes the index UNIQUE.
+func (s *CreateIndexStmt) Unique() *CreateIndexStmt {
+	var t = *s
+	t.unique = true
+	return &t
+}
+
+// NullFiltered makes the index NULL_FILTERED, which doesn't index rows with NULL in any of the key columns.
+func (s *CreateIndexStmt) NullFiltered() *CreateIndexStmt {
+	var t = *s
+	t.nullFiltered = true
+	return &t
+}
+
+// IfNotExists makes the statement CREATE INDEX IF NOT EXISTS.
+func (s *CreateIndexStmt) IfNotExists() *CreateIndexStmt {
+	var t = *s
+	t.ifNotExists = true
+	return &t
+}
+
+func (s *CreateIndexStmt) SQL() (string, error) {
+	stmt, err := s.toAST()
+	if err != nil {
+		return "", err
+	}
+	return renderSQL(stmt)
+}
+
+func (s *CreateIndexStmt) toAST() (*ast.CreateIndex, error) {
+	if len(s.keys) == 0 {
+		return nil, errors.Errorf("index %s has no keys", s.name)
+	}
+	var errs errorList
+	name, err := toASTIdent(s.name)
+	errs.add(err)
+	table, err := toASTIdent(s.table)
+	errs.add(err)
+	ci := &ast.CreateIndex{
+		Name:         name,
+		TableName:    table,
+		Unique:       s.unique,
+		NullFiltered: s.nullFiltered,
+		IfNotExists:  s.ifNotExists,
+	}
+	for _, k := range s.keys {
+		key, err := toASTIdent(k.Column)
+		errs.add(err)
+		ci.Keys = append(ci.Keys, &ast.IndexKey{Name: key, Dir: ast.Direction(k.Dir)})
+	}
+	if len(s.storing) > 0 {
+		ci.Storing = &ast.Storing{}
+		for _, c := range s.storing {
+			col, err := toASTIdent(c)
+			errs.add(err)
+			ci.Storing.Columns = append(ci.Storing.Columns, col)
+		}
+	}
+	if s.interleave != "" {
+		parent, err := toASTIdent(s.interleave)
+		errs.add(err)
+		ci.InterleaveIn = &ast.InterleaveIn{TableName: parent}
+	}
+	if err := errs.err(); err != nil {
+		return nil, err
+	}
+	return ci, nil
+}
+
+// DropIndexStmt is a DROP INDEX statement.
+type DropIndexStmt struct {
+	name     string
+	ifExists bool
+}
+
+// DropIndex creates a new DropIndexStmt with given index name.
+func DropIndex(name string) *DropIndexStmt {
+	return &DropIndexStmt{name: name}
+}
+
+// IfExists makes the statement DROP INDEX IF EXISTS.
+func (s *DropIndexStmt) IfExists() *DropIndexStmt {
+	var t = *s
+	t.ifExists = true
+	return &t
+}
+
+func (s *DropIndexStmt) SQL() (string, error) {
+	name, err := toASTIdent(s.name)
+	if err != nil {
+		return "", err
+	}
+	return renderSQL(&ast.DropIndex{Name: name, IfExists: s.ifExists})
+}
@@ -0,0 +1,50 @@
+package memeduck_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/abyssparanoia/memeduck"
+)
+
+func TestCreateIndex(t *testing.T) {
+	for _, c := range []struct {
+		stmt     *memeduck.CreateIndexStmt
+		expected string
+	}{
+		{
+			memeduck.CreateIndex("user_name", "user").Key("name", ""),
+			"CREATE INDEX user_name ON user (name)",
+		},
+		{
+			memeduck.CreateIndex("user_email", "user").Unique().NullFiltered().IfNotExists().
+				Key("email", memeduck.ASC).
+				Storing("name", "age"),
+			"CREATE UNIQUE NULL_FILTERED INDEX IF NOT EXISTS user_email ON user (email ASC) STORING (name, age)",
+		},
+		{
+			memeduck.CreateIndex("user_item_by_created_at", "user_item").
+				Key("user_id", "").
+				Key("created_at", memeduck.DESC).
+				InterleaveIn("user"),
+			"CREATE INDEX user_item_by_created_at ON user_item (user_id, created_at DESC), INTERLEAVE IN user",
+		},
+	} {
+		sql, err := c.stmt.SQL()
+		assert.Nil(t, err)
+		assert.Equal(t, c.expected, sql)
+	}
+
+	_, err := memeduck.CreateIndex("user_name", "user").SQL()
+	assert.EqualError(t, err, "index user_name has no keys")
+}
+
+func TestDropIndex(t *testing.T) {
+	sql, err := memeduck.DropIndex("user_name").SQL()
+	assert.Nil(t, err)
+	assert.Equal(t, "DROP INDEX user_name", sql)
+	sql, err = memeduck.DropIndex("user_name").IfExists().SQL()
+	assert.Nil(t, err)
+	assert.Equal(t, "DROP INDEX IF EXISTS user_name", sql)
+}
@@ -2,6 +2,7 @@ package memeduck_test
 
 import (
 	"math"
+	"math/big"
 	"testing"
 	"time"
 
@@ -58,7 +59,7 @@ func TestInsertWithStringSliceSlice(t *testing.T) {
 		memeduck.Insert("hoge", []string{"a", "b"}).Values([][][]string{
 			{{}, {"a"}, {"b", "c"}},
 		}),
-		`INSERT INTO hoge (a, b) VALUES (ARRAY[], ARRAY["a"], ARRAY["b", "c"])`,
+		`INSERT INTO hoge (a, b) VALUES (ARRAY<STRING>[], ARRAY["a"], ARRAY["b", "c"])`,
 	)
 }
 
@@ -86,7 +87,7 @@ func TestInsertWithStringPtrSliceSlice(t *testing.T) {
 		memeduck.Insert("hoge", []string{"a", "b", "c"}).Values([][][]*string{
 			{{}, {&a}, {&b, nil}},
 		}),
-		`INSERT INTO hoge (a, b, c) VALUES (ARRAY[], ARRAY["foo"], ARRAY["bar", NULL])`,
+		`INSERT INTO hoge (a, b, c) VALUES (ARRAY<STRING>[], ARRAY["foo"], ARRAY["bar", NULL])`,
 	)
 }
 
@@ -116,7 +117,7 @@ func TestInsertWithNullStringSliceSlice(t *testing.T) {
 		memeduck.Insert("hoge", []string{"a", "b", "c"}).Values([][][]spanner.NullString{
 			{{}, {a}, {b, null}},
 		}),
-		`INSERT INTO hoge (a, b, c) VALUES (ARRAY[], ARRAY["foo"], ARRAY["bar", NULL])`,
+		`INSERT INTO hoge (a, b, c) VALUES (ARRAY<STRING>[], ARRAY["foo"], ARRAY["bar", NULL])`,
 	)
 }
 
@@ -140,7 +141,7 @@ func TestInsertWithByteSliceSliceSlice(t *testing.T) {
 		memeduck.Insert("hoge", []string{"a", "b", "c"}).Values([][][][]byte{
 			{{}, {{0, 1}}, {{2, 3, 4}, nil}},
 		}),
-		`INSERT INTO hoge (a, b, c) VALUES (ARRAY[], ARRAY[B"\x00\x01"], ARRAY[B"\x02\x03\x04", NULL])`,
+		`INSERT INTO hoge (a, b, c) VALUES (ARRAY<BYTES>[], ARRAY[B"\x00\x01"], ARRAY[B"\x02\x03\x04", NULL])`,
 	)
 }
 
@@ -158,7 +159,7 @@ func TestInsertWithIntSliceSlice(t *testing.T) {
 		memeduck.Insert("hoge", []string{"a", "b", "c"}).Values([][][]int{
 			{{}, {123}, {456, 789}},
 		}),
-		`INSERT INTO hoge (a, b, c) VALUES (ARRAY[], ARRAY[123], ARRAY[456, 789])`,
+		`INSERT INTO hoge (a, b, c) VALUES (ARRAY<INT64>[], ARRAY[123], ARRAY[456, 789])`,
 	)
 }
 
@@ -186,7 +187,7 @@ func TestInsertWithIntPtrSliceSlice(t *testing.T) {
 		memeduck.Insert("hoge", []string{"a", "b", "c"}).Values([][][]*int{
 			{{}, {&a}, {&b, nil}},
 		}),
-		`INSERT INTO hoge (a, b, c) VALUES (ARRAY[], ARRAY[123], ARRAY[456, NULL])`,
+		`INSERT INTO hoge (a, b, c) VALUES (ARRAY<INT64>[], ARRAY[123], ARRAY[456, NULL])`,
 	)
 }
 
@@ -204,7 +205,7 @@ func TestInsertWithInt64SliceSlice(t *testing.T) {
 		memeduck.Insert("hoge", []string{"a", "b", "c"}).Values([][][]int64{
 			{{}, {123}, {456, 789}},
 		}),
-		`INSERT INTO hoge (a, b, c) VALUES (ARRAY[], ARRAY[123], ARRAY[456, 789])`,
+		`INSERT INTO hoge (a, b, c) VALUES (ARRAY<INT64>[], ARRAY[123], ARRAY[456, 789])`,
 	)
 }
 
@@ -232,7 +233,7 @@ func TestInsertWithInt64PtrSliceSlice(t *testing.T) {
 		memeduck.Insert("hoge", []string{"a", "b", "c"}).Values([][][]*int64{
 			{{}, {&a}, {&b, nil}},
 		}),
-		`INSERT INTO hoge (a, b, c) VALUES (ARRAY[], ARRAY[123], ARRAY[456, NULL])`,
+		`INSERT INTO hoge (a, b, c) VALUES (ARRAY<INT64>[], ARRAY[123], ARRAY[456, NULL])`,
 	)
 }
 
@@ -262,7 +263,7 @@ func TestInsertWithNullInt64SliceSlice(t *testing.T) {
 		memeduck.Insert("hoge", []string{"a", "b", "c"}).Values([][][]spanner.NullInt64{
 			{{}, {a}, {b, null}},
 		}),
-		`INSERT INTO hoge (a, b, c) VALUES (ARRAY[], ARRAY[123], ARRAY[456, NULL])`,
+		`INSERT INTO hoge (a, b, c) VALUES (ARRAY<INT64>[], ARRAY[123], ARRAY[456, NULL])`,
 	)
 }
 
@@ -280,7 +281,7 @@ func TestInsertWithBoolSliceSlice(t *testing.T) {
 		memeduck.Insert("hoge", []string{"a", "b", "c"}).Values([][][]bool{
 			{{}, {true}, {false, true}},
 		}),
-		`INSERT INTO hoge (a, b, c) VALUES (ARRAY[], ARRAY[TRUE], ARRAY[FALSE, TRUE])`,
+		`INSERT INTO hoge (a, b, c) VALUES (ARRAY<BOOL>[], ARRAY[TRUE], ARRAY[FALSE, TRUE])`,
 	)
 }
 
@@ -308,7 +309,7 @@ func TestInsertWithBoolPtrSliceSlice(t *testing.T) {
 		memeduck.Insert("hoge", []string{"a", "b", "c"}).Values([][][]*bool{
 			{{}, {&a}, {&b, nil}},
 		}),
-		`INSERT INTO hoge (a, b, c) VALUES (ARRAY[], ARRAY[TRUE], ARRAY[FALSE, NULL])`,
+		`INSERT INTO hoge (a, b, c) VALUES (ARRAY<BOOL>[], ARRAY[TRUE], ARRAY[FALSE, NULL])`,
 	)
 }
 
@@ -338,7 +339,7 @@ func TestInsertWithNullBoolSliceSlice(t *testing.T) {
 		memeduck.Insert("hoge", []string{"a", "b", "c"}).Values([][][]spanner.NullBool{
 			{{}, {a}, {b, null}},
 		}),
-		`INSERT INTO hoge (a, b, c) VALUES (ARRAY[], ARRAY[TRUE], ARRAY[FALSE, NULL])`,
+		`INSERT INTO hoge (a, b, c) VALUES (ARRAY<BOOL>[], ARRAY[TRUE], ARRAY[FALSE, NULL])`,
 	)
 }
 
@@ -369,7 +370,7 @@ func TestInsertWithFloat64SliceSlice(t *testing.T) {
 		memeduck.Insert("hoge", []string{"a", "b", "c"}).Values([][][]float64{
 			{{}, {0}, {31.5, math.Inf(1)}},
 		}),
-		`INSERT INTO hoge (a, b, c) VALUES (ARRAY[], ARRAY[0e+00], ARRAY[3.15e+01, +Inf])`,
+		`INSERT INTO hoge (a, b, c) VALUES (ARRAY<FLOAT64>[], ARRAY[0e+00], ARRAY[3.15e+01, +Inf])`,
 	)
 }
 
@@ -402,7 +403,7 @@ func TestInsertWithFloat64PtrSliceSlice(t *testing.T) {
 		memeduck.Insert("hoge", []string{"a", "b", "c"}).Values([][][]*float64{
 			{{}, {&a}, {&b, nil}},
 		}),
-		`INSERT INTO hoge (a, b, c) VALUES (ARRAY[], ARRAY[0e+00], ARRAY[3.15e+01, NULL])`,
+		`INSERT INTO hoge (a, b, c) VALUES (ARRAY<FLOAT64>[], ARRAY[0e+00], ARRAY[3.15e+01, NULL])`,
 	)
 }
 
@@ -437,7 +438,7 @@ func TestInsertWithNullFloat64SliceSlice(t *testing.T) {
 		memeduck.Insert("hoge", []string{"a", "b", "c"}).Values([][][]spanner.NullFloat64{
 			{{}, {a}, {b, null}},
 		}),
-		`INSERT INTO hoge (a, b, c) VALUES (ARRAY[], ARRAY[0e+00], ARRAY[3.15e+01, NULL])`,
+		`INSERT INTO hoge (a, b, c) VALUES (ARRAY<FLOAT64>[], ARRAY[0e+00], ARRAY[3.15e+01, NULL])`,
 	)
 }
 
@@ -452,8 +453,8 @@ func TestInsertWithTimeSlice(t *testing.T) {
 		}),
 		`INSERT INTO hoge (a, b, c, d) VALUES (`+
 			`TIMESTAMP "2020-06-06T12:34:56.123456Z", `+
-			`TIMESTAMP "2021-08-10T00:01:23.456789+09:00", `+
-			`TIMESTAMP "2022-12-08T14:22:51.837583-04:30", `+
+			`TIMESTAMP "2021-08-09T15:01:23.456789Z", `+
+			`TIMESTAMP "2022-12-08T18:52:51.837583Z", `+
 			`TIMESTAMP "2023-10-10T08:43:17.536829Z")`,
 	)
 }
@@ -467,10 +468,10 @@ func TestInsertWithTimeSliceSlice(t *testing.T) {
 			{{}, {a}, {b, c}},
 		}),
 		`INSERT INTO hoge (a, b, c) VALUES (`+
-			`ARRAY[], `+
+			`ARRAY<TIMESTAMP>[], `+
 			`ARRAY[TIMESTAMP "2020-06-06T12:34:56.123456Z"], `+
-			`ARRAY[TIMESTAMP "2021-08-10T00:01:23.456789+09:00", `+
-			`TIMESTAMP "2022-12-08T14:22:51.837583-04:30"])`,
+			`ARRAY[TIMESTAMP "2021-08-09T15:01:23.456789Z", `+
+			`TIMESTAMP "2022-12-08T18:52:51.837583Z"])`,
 	)
 }
 
@@ -485,8 +486,8 @@ func TestInsertWithTimePtrSlice(t *testing.T) {
 		}),
 		`INSERT INTO hoge (a, b, c, d) VALUES (`+
 			`TIMESTAMP "2020-06-06T12:34:56.123456Z", `+
-			`TIMESTAMP "2021-08-10T00:01:23.456789+09:00", `+
-			`TIMESTAMP "2022-12-08T14:22:51.837583-04:30", `+
+			`TIMESTAMP "2021-08-09T15:01:23.456789Z", `+
+			`TIMESTAMP "2022-12-08T18:52:51.837583Z", `+
 			`TIMESTAMP "2023-10-10T08:43:17.536829Z", `+
 			`NULL)`,
 	)
@@ -500,9 +501,9 @@ func TestInsertWithTimePtrSliceSlice(t *testing.T) {
 			{{}, {&a}, {&b, nil}},
 		}),
 		`INSERT INTO hoge (a, b, c) VALUES (`+
-			`ARRAY[], `+
+			`ARRAY<TIMESTAMP>[], `+
 			`ARRAY[TIMESTAMP "2020-06-06T12:34:56.123456Z"], `+
-			`ARRAY[TIMESTAMP "2021-08-10T00:01:23.456789+09:00", NULL])`,
+			`ARRAY[TIMESTAMP "2021-08-09T15:01:23.456789Z", NULL])`,
 	)
 }
 
@@ -518,8 +519,8 @@ func TestInsertWithNullTimeSlice(t *testing.T) {
 		}),
 		`INSERT INTO hoge (a, b, c, d) VALUES (`+
 			`TIMESTAMP "2020-06-06T12:34:56.123456Z", `+
-			`TIMESTAMP "2021-08-10T00:01:23.456789+09:00", `+
-			`TIMESTAMP "2022-12-08T14:22:51.837583-04:30", `+
+			`TIMESTAMP "2021-08-09T15:01:23.456789Z", `+
+			`TIMESTAMP "2022-12-08T18:52:51.837583Z", `+
 			`TIMESTAMP "2023-10-10T08:43:17.536829Z", `+
 			`NULL)`,
 	)
@@ -534,9 +535,9 @@ func TestInsertWithNullTimeSliceSlice(t *testing.T) {
 			{{}, {a}, {b, null}},
 		}),
 		`INSERT INTO hoge (a, b, c) VALUES (`+
-			`ARRAY[], `+
+			`ARRAY<TIMESTAMP>[], `+
 			`ARRAY[TIMESTAMP "2020-06-06T12:34:56.123456Z"], `+
-			`ARRAY[TIMESTAMP "2021-08-10T00:01:23.456789+09:00", NULL])`,
+			`ARRAY[TIMESTAMP "2021-08-09T15:01:23.456789Z", NULL])`,
 	)
 }
 
@@ -562,7 +563,7 @@ func TestInsertWithDateSliceSlice(t *testing.T) {
 			{{}, {a}, {b, c}},
 		}),
 		`INSERT INTO hoge (a, b, c) VALUES (`+
-			`ARRAY[], `+
+			`ARRAY<DATE>[], `+
 			`ARRAY[DATE "2024-03-02"], `+
 			`ARRAY[DATE "2025-06-20", DATE "2026-03-05"])`,
 	)
@@ -590,7 +591,7 @@ func TestInsertWithDatePtrSliceSlice(t *testing.T) {
 			{{}, {&a}, {&b, nil}},
 		}),
 		`INSERT INTO hoge (a, b, c) VALUES (`+
-			`ARRAY[], `+
+			`ARRAY<DATE>[], `+
 			`ARRAY[DATE "2024-03-02"], `+
 			`ARRAY[DATE "2025-06-20", NULL])`,
 	)
@@ -619,12 +620,55 @@ func TestInsertWithNullDateSliceSlice(t *testing.T) {
 			{{}, {a}, {b, null}},
 		}),
 		`INSERT INTO hoge (a, b, c) VALUES (`+
-			`ARRAY[], `+
+			`ARRAY<DATE>[], `+
 			`ARRAY[DATE "2024-03-02"], `+
 			`ARRAY[DATE "2025-06-20", NULL])`,
 	)
 }
 
+func TestInsertWithNullNumericSlice(t *testing.T) {
+	var a = spanner.NullNumeric{Numeric: *big.NewRat(12345, 100), Valid: true}
+	var b = spanner.NullNumeric{Numeric: *big.NewRat(-1, 4), Valid: true}
+	var c = spanner.NullNumeric{}
+	testInsert(t,
+		memeduck.Insert("hoge", []string{"a", "b", "c"}).Values([][]spanner.NullNumeric{
+			{a, b, c},
+		}),
+		`INSERT INTO hoge (a, b, c) VALUES (`+
+			`NUMERIC "123.450000000", `+
+			`NUMERIC "-0.250000000", `+
+			`NULL)`,
+	)
+	testInsert(t,
+		memeduck.Insert("hoge", []string{"a"}).Values([][][]spanner.NullNumeric{
+			{{}},
+		}),
+		`INSERT INTO hoge (a) VALUES (ARRAY<NUMERIC>[])`,
+	)
+}
+
+func TestInsertWithRatSlice(t *testing.T) {
+	max, _ := new(big.Rat).SetString("99999999999999999999999999999.999999999")
+	testInsert(t,
+		memeduck.Insert("hoge", []string{"a", "b", "c"}).Values([][]interface{}{
+			{big.NewRat(1, 8), *big.NewRat(-3, 1), max},
+			{(*big.Rat)(nil), []*big.Rat{}, []*big.Rat{big.NewRat(1, 2), nil}},
+		}),
+		`INSERT INTO hoge (a, b, c) VALUES (`+
+			`NUMERIC "0.125000000", `+
+			`NUMERIC "-3.000000000", `+
+			`NUMERIC "99999999999999999999999999999.999999999"), (`+
+			`NULL, `+
+			`ARRAY<NUMERIC>[], `+
+			`ARRAY[NUMERIC "0.500000000", NULL])`,
+	)
+	tooLarge, _ := new(big.Rat).SetString("100000000000000000000000000000")
+	for _, v := range []interface{}{big.NewRat(1, 3), tooLarge, spanner.NullNumeric{Numeric: *big.NewRat(1, 3), Valid: true}} {
+		_, err := memeduck.Insert("hoge", []string{"a"}).Values([][]interface{}{{v}}).SQL()
+		assert.Error(t, err)
+	}
+}
+
 func TestInsertWithParamSlice(t *testing.T) {
 	testInsert(t,
 		memeduck.Insert("hoge", []string{"a", "b"}).Values([][]*memeduck.ParamExpr{
@@ -712,6 +756,34 @@ func TestInsertWithGoStructWithTags(t *testing.T) {
 	)
 }
 
+type testInsertGoStructBase struct {
+	ID        string
+	CreatedAt string `spanner:"created_at"`
+}
+
+type testInsertGoStructEmbedded struct {
+	testInsertGoStructBase
+	Name string
+}
+
+type testInsertGoStructDuplicated struct {
+	testInsertGoStructBase
+	Created string `spanner:"created_at"`
+}
+
+func TestInsertWithEmbeddedGoStruct(t *testing.T) {
+	testInsert(t,
+		memeduck.Insert("hoge", []string{"id", "created_at", "name"}).Values([]testInsertGoStructEmbedded{
+			{testInsertGoStructBase: testInsertGoStructBase{ID: "1", CreatedAt: "now"}, Name: "a"},
+		}),
+		`INSERT INTO hoge (id, created_at, name) VALUES ("1", "now", "a")`,
+	)
+	_, err := memeduck.Insert("hoge", []string{"id", "created_at"}).Values([]testInsertGoStructDuplicated{
+		{testInsertGoStructBase: testInsertGoStructBase{ID: "1"}},
+	}).SQL()
+	assert.ErrorContains(t, err, "duplicate column created_at in fields testInsertGoStructBase.CreatedAt and Created")
+}
+
 func TestInsertWithHeteroSlice(t *testing.T) {
 	testInsert(t,
 		memeduck.Insert("hoge", []string{"a", "b", "c", "d"}).Values([][]interface{}{
@@ -739,3 +811,128 @@ func parseDate(t *testing.T, s string) civil.Date {
 	assert.Nil(t, err, "failed to parse %s", s)
 	return d
 }
+
+func TestInsertOrUpdate(t *testing.T) {
+	stmt := memeduck.Insert("hoge", []string{"a", "b"}).Values([][]interface{}{{1, "x"}})
+	testInsert(t, stmt.OrUpdate(), `INSERT OR UPDATE INTO hoge (a, b) VALUES (1, "x")`)
+	testInsert(t, stmt.OrIgnore(), `INSERT OR IGNORE INTO hoge (a, b) VALUES (1, "x")`)
+	testInsert(t, stmt.OrUpdate().OrIgnore(), `INSERT OR IGNORE INTO hoge (a, b) VALUES (1, "x")`)
+	testInsert(t, stmt, `INSERT INTO hoge (a, b) VALUES (1, "x")`)
+	testInsert(t,
+		memeduck.Insert("hoge", []string{"a"}).OrUpdate().Idempotent(true).Values([][]interface{}{{2}}),
+		`INSERT OR UPDATE INTO hoge (a) VALUES (2)`,
+	)
+	assert.True(t, memeduck.Insert("hoge", []string{"a"}).Idempotent(true).Values([][]interface{}{{2}}).IsIdempotent())
+	sql, err := stmt.OrUpdate().RedactedSQL("b")
+	assert.Nil(t, err)
+	assert.Equal(t, `INSERT OR UPDATE INTO hoge (a, b) VALUES (1, ?)`, sql)
+}
+
+func TestInsertSelect(t *testing.T) {
+	query := memeduck.Select("user", []string{"id", "name"}).Where(memeduck.Eq(memeduck.Ident("active"), true))
+	testInsert(t,
+		memeduck.Insert("user_archive", []string{"id", "name"}).Select(query),
+		`INSERT INTO user_archive (id, name) SELECT id, name FROM user WHERE active = TRUE`,
+	)
+	testInsert(t,
+		memeduck.Insert("user_archive", []string{"id", "name"}).Select(query).OrIgnore(),
+		`INSERT OR IGNORE INTO user_archive (id, name) SELECT id, name FROM user WHERE active = TRUE`,
+	)
+	testInsert(t,
+		memeduck.Insert("user_archive", []string{"id", "name"}).Select(query).Values([][]interface{}{{1, "x"}}),
+		`INSERT INTO user_archive (id, name) VALUES (1, "x")`,
+	)
+	_, err := memeduck.Insert("user_archive", []string{"id", "name"}).Select(query.Hint("SCAN_METHOD", "ROW")).SQL()
+	assert.Error(t, err)
+}
+
+func TestInsertWithMapSlice(t *testing.T) {
+	testInsert(t,
+		memeduck.Insert("hoge", []string{"a", "b"}).Values([]map[string]interface{}{
+			{"b": "x", "a": 1},
+			{"a": 2, "b": nil},
+		}),
+		`INSERT INTO hoge (a, b) VALUES (1, "x"), (2, NULL)`,
+	)
+	testInsert(t,
+		memeduck.Insert("hoge", []string{"a"}).Values([]interface{}{map[string]int64{"a": 3}}),
+		`INSERT INTO hoge (a) VALUES (3)`,
+	)
+	_, err := memeduck.Insert("hoge", []string{"a", "b"}).Values([]map[string]interface{}{{"a": 1}}).SQL()
+	assert.EqualError(t, err, "can't convert map[string]interface {} into SQL row: map doesn't have column b")
+	_, err = memeduck.Insert("hoge", []string{"a"}).Values([]map[string]interface{}{{"a": 1, "c": 2, "b": 3}}).SQL()
+	assert.EqualError(t, err, "can't convert map[string]interface {} into SQL row: map has key b, which is not a column\nmap has key c, which is not a column")
+	_, err = memeduck.Insert("hoge", []string{"a"}).Values([]map[int]interface{}{{1: 1}}).SQL()
+	assert.Error(t, err)
+}
+
+type testInsertStructRow struct {
+	ID     int64  `spanner:"id"`
+	Name   string `spanner:"name"`
+	Secret string `spanner:"-"`
+	Memo   string
+}
+
+func TestInsertStruct(t *testing.T) {
+	testInsert(t,
+		memeduck.InsertStruct("hoge", &testInsertStructRow{ID: 1, Name: "a", Memo: "m"}),
+		`INSERT INTO hoge (id, name, Memo) VALUES (1, "a", "m")`,
+	)
+	testInsert(t,
+		memeduck.InsertStruct("hoge", []*testInsertStructRow{{ID: 1, Name: "a"}, {ID: 2, Name: "b"}}).OrUpdate(),
+		`INSERT OR UPDATE INTO hoge (id, name, Memo) VALUES (1, "a", ""), (2, "b", "")`,
+	)
+	_, err := memeduck.InsertStruct("hoge", []int{1}).SQL()
+	assert.EqualError(t, err, "[]int is not a struct")
+	_, err = memeduck.InsertStruct("hoge", nil).SQL()
+	assert.Error(t, err)
+}
+
+type testNameMapperRow struct {
+	UserID    int64
+	HTTPCode  int64
+	Address2  string
+	Nickname  string `spanner:"nick"`
+	CreatedAt time.Time
+}
+
+func TestFactoryNameMapper(t *testing.T) {
+	f := memeduck.NewFactory().NameMapper(memeduck.SnakeCase)
+	row := &testNameMapperRow{UserID: 1, HTTPCode: 200, Address2: "x", Nickname: "n"}
+	testInsert(t,
+		f.InsertStruct("hoge", row),
+		`INSERT INTO hoge (user_id, http_code, address2, nick, created_at) VALUES (1, 200, "x", "n", TIMESTAMP "0001-01-01T00:00:00Z")`,
+	)
+	testInsert(t,
+		f.Insert("hoge", []string{"user_id", "nick"}).Values([]*testNameMapperRow{row}),
+		`INSERT INTO hoge (user_id, nick) VALUES (1, "n")`,
+	)
+	_, err := memeduck.Insert("hoge", []string{"user_id"}).Values([]*testNameMapperRow{row}).SQL()
+	assert.Error(t, err)
+
+	assert.Equal(t, "user_id", memeduck.SnakeCase("UserID"))
+	assert.Equal(t, "id", memeduck.SnakeCase("ID"))
+	assert.Equal(t, "UserId", memeduck.CamelCase("user_id"))
+}
+
+type testTagOptionsRow struct {
+	ID        int64     `spanner:"id"`
+	Name      string    `spanner:"name,omitempty"`
+	UpdatedAt time.Time `spanner:",commit_timestamp"`
+	CreatedAt time.Time `spanner:"created_at,readonly"`
+}
+
+func TestInsertStructTagOptions(t *testing.T) {
+	rows := []*testTagOptionsRow{{ID: 1, Name: "a"}, {ID: 2}}
+	testInsert(t,
+		memeduck.InsertStruct("hoge", rows),
+		`INSERT INTO hoge (id, name, UpdatedAt) VALUES (1, "a", PENDING_COMMIT_TIMESTAMP()), (2, DEFAULT, PENDING_COMMIT_TIMESTAMP())`,
+	)
+	_, err := memeduck.Insert("hoge", []string{"id", "created_at"}).Values(rows[:1]).SQL()
+	assert.EqualError(t, err, "can't convert *memeduck_test.testTagOptionsRow into SQL row: column created_at of type memeduck_test.testTagOptionsRow is read-only")
+
+	table := memeduck.YoTable("hoge", []string{"id", "name", "UpdatedAt", "created_at"}, []string{"id"})
+	stmt, err := memeduck.UpdateRow(table, &testTagOptionsRow{ID: 2})
+	assert.Nil(t, err)
+	testUpdate(t, stmt, `UPDATE hoge SET UpdatedAt = PENDING_COMMIT_TIMESTAMP() WHERE id = 2`)
+}
@@ -1,14 +1,22 @@
 package internal
 
 import (
+	"database/sql/driver"
+	"encoding/json"
+	"math/big"
 	"reflect"
 	"strconv"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"cloud.google.com/go/civil"
 	"cloud.google.com/go/spanner"
 	"github.com/cloudspannerecosystem/memefish/ast"
+	"github.com/cloudspannerecosystem/memefish/token"
 	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
 // ASTExpr is a type that can be converted into ast.Expr.
@@ -81,17 +89,17 @@ func ToExpr(val interface{}) (ast.Expr, error) {
 		}
 		return FloatLit(v.Float64), nil
 	case time.Time:
-		return TimeLit(v), nil
+		return timeExpr(v), nil
 	case *time.Time:
 		if v == nil {
 			return NullLit(), nil
 		}
-		return TimeLit(*v), nil
+		return timeExpr(*v), nil
 	case spanner.NullTime:
 		if !v.Valid {
 			return NullLit(), nil
 		}
-		return TimeLit(v.Time), nil
+		return timeExpr(v.Time), nil
 	case civil.Date:
 		return DateLit(v), nil
 	case *civil.Date:
@@ -104,11 +112,39 @@ func ToExpr(val interface{}) (ast.Expr, error) {
 			return NullLit(), nil
 		}
 		return DateLit(v.Date), nil
+	case spanner.NullNumeric:
+		if !v.Valid {
+			return NullLit(), nil
+		}
+		return numericExpr(&v.Numeric)
+	case big.Rat:
+		return numericExpr(&v)
+	case *big.Rat:
+		if v == nil {
+			return NullLit(), nil
+		}
+		return numericExpr(v)
+	case spanner.NullJSON:
+		if !v.Valid {
+			return NullLit(), nil
+		}
+		return jsonExpr(v.Value)
 	default:
 		if se, ok := val.(ASTExpr); ok {
 			return se.ToASTExpr()
 		}
-		// TODO: support big.Rat
+		if m, ok := val.(proto.Message); ok {
+			return protoMessageExpr(m)
+		}
+		if e, ok := val.(protoreflect.Enum); ok {
+			return ProtoEnumExpr(e, false), nil
+		}
+		if dv, ok := val.(driver.Valuer); ok {
+			return valuerExpr(dv)
+		}
+		if m, ok := val.(json.Marshaler); ok {
+			return jsonExpr(m)
+		}
 		// Slices
 		valV := reflect.ValueOf(val)
 		if valV.Type().Kind() == reflect.Slice {
@@ -121,10 +157,14 @@ func ToExpr(val interface{}) (ast.Expr, error) {
 				}
 				exprs = append(exprs, ei)
 			}
-			return ArrayLit(exprs), nil
+			lit := ArrayLit(exprs)
+			if len(exprs) == 0 {
+				// An empty array needs its element type, otherwise Spanner can't determine its type.
+				lit.Type = TypeOf(valV.Type().Elem())
+			}
+			return lit, nil
 		} else {
-			return nil, errors.Errorf("can't convert %T into SQL expr", val)
-
+			return nil, &UnsupportedTypeError{Type: valV.Type()}
 		}
 	}
 }
@@ -142,6 +182,9 @@ func BytesLit(v []byte) *ast.BytesLiteral {
 }
 
 func IntLit(v int64) *ast.IntLiteral {
+	if minInternedInt <= v && v <= maxInternedInt {
+		return intLits[v-minInternedInt]
+	}
 	return &ast.IntLiteral{
 		Base:  10,
 		Value: strconv.FormatInt(v, 10),
@@ -149,9 +192,10 @@ func IntLit(v int64) *ast.IntLiteral {
 }
 
 func BoolLit(v bool) *ast.BoolLiteral {
-	return &ast.BoolLiteral{
-		Value: v,
+	if v {
+		return trueLit
 	}
+	return falseLit
 }
 
 func FloatLit(v float64) *ast.FloatLiteral {
@@ -160,14 +204,44 @@ func FloatLit(v float64) *ast.FloatLiteral {
 	}
 }
 
+// TimeLit creates a timestamp literal of v normalized to UTC, keeping nanoseconds.
 func TimeLit(v time.Time) *ast.TimestampLiteral {
 	return &ast.TimestampLiteral{
 		Value: &ast.StringLiteral{
-			Value: v.Format(time.RFC3339Nano),
+			Value: v.UTC().Format(time.RFC3339Nano),
 		},
 	}
 }
 
+// TimeLitPrecision creates a timestamp literal of v normalized to UTC, with exactly digits sub-second digits.
+// Extra precision of v is truncated.
+func TimeLitPrecision(v time.Time, digits int) *ast.TimestampLiteral {
+	layout := "2006-01-02T15:04:05"
+	if digits > 0 {
+		layout += "." + strings.Repeat("0", digits)
+	}
+	return &ast.TimestampLiteral{
+		Value: &ast.StringLiteral{
+			Value: v.UTC().Format(layout + "Z07:00"),
+		},
+	}
+}
+
+// PendingCommitTimestamp creates `PENDING_COMMIT_TIMESTAMP()` function call.
+func PendingCommitTimestamp() ast.Expr {
+	return &ast.CallExpr{
+		Func: &ast.Ident{Name: "PENDING_COMMIT_TIMESTAMP"},
+	}
+}
+
+// timeExpr converts v into a timestamp literal, or PENDING_COMMIT_TIMESTAMP() if v is spanner.CommitTimestamp.
+func timeExpr(v time.Time) ast.Expr {
+	if v == spanner.CommitTimestamp {
+		return PendingCommitTimestamp()
+	}
+	return TimeLit(v)
+}
+
 func DateLit(v civil.Date) *ast.DateLiteral {
 	return &ast.DateLiteral{
 		Value: &ast.StringLiteral{
@@ -182,6 +256,167 @@ func ArrayLit(exprs []ast.Expr) *ast.ArrayLiteral {
 	}
 }
 
+func NumericLit(v *big.Rat) *ast.NumericLiteral {
+	return &ast.NumericLiteral{
+		Value: StringLit(spanner.NumericString(v)),
+	}
+}
+
+// jsonExpr marshals v into a JSON literal.
+func jsonExpr(v interface{}) (ast.Expr, error) {
+	if v == nil {
+		return NullLit(), nil
+	}
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Ptr && rv.IsNil() {
+		return NullLit(), nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "can't convert %T into JSON", v)
+	}
+	return JSONLit(string(b)), nil
+}
+
+// valuerExpr converts the value returned by v.Value(), so that types written for database/sql can be used.
+func valuerExpr(v driver.Valuer) (ast.Expr, error) {
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Ptr && rv.IsNil() {
+		return NullLit(), nil
+	}
+	dv, err := v.Value()
+	if err != nil {
+		return nil, errors.WithMessagef(err, "can't get value of %T", v)
+	}
+	return ToExpr(dv)
+}
+
+// numericExpr converts v into a NUMERIC literal.
+// It returns an error if v doesn't fit in NUMERIC, i.e. precision 38 and scale 9, instead of rounding v.
+func numericExpr(v *big.Rat) (ast.Expr, error) {
+	scaled := new(big.Rat).Mul(v, new(big.Rat).SetInt(numericScale))
+	if !scaled.IsInt() {
+		return nil, errors.Errorf("NUMERIC %s has more than %d fractional digits", v.RatString(), spanner.NumericScaleDigits)
+	}
+	if new(big.Int).Abs(scaled.Num()).Cmp(numericLimit) >= 0 {
+		return nil, errors.Errorf("NUMERIC %s has more than %d integer digits", v.RatString(), spanner.NumericPrecisionDigits-spanner.NumericScaleDigits)
+	}
+	return NumericLit(v), nil
+}
+
+var (
+	numericScale = new(big.Int).Exp(big.NewInt(10), big.NewInt(spanner.NumericScaleDigits), nil)
+	numericLimit = new(big.Int).Exp(big.NewInt(10), big.NewInt(spanner.NumericPrecisionDigits), nil)
+)
+
 func NullLit() *ast.NullLiteral {
-	return &ast.NullLiteral{}
+	return nullLit
+}
+
+// JSONLiteral is a JSON literal like `JSON "{}"`, which memefish doesn't support.
+type JSONLiteral struct {
+	exprNode
+	Value *ast.StringLiteral
+}
+
+func (l *JSONLiteral) SQL() string {
+	return "JSON " + l.Value.SQL()
+}
+
+func JSONLit(v string) *JSONLiteral {
+	return &JSONLiteral{
+		Value: StringLit(v),
+	}
+}
+
+// KeywordCallExpr is a function call whose name is a keyword like `COLLATE(x, spec)`.
+// ast.CallExpr can't be used for it because its name is quoted as an identifier.
+type KeywordCallExpr struct {
+	exprNode
+	Name     string
+	Distinct bool
+	Args     []ast.Arg
+}
+
+func (c *KeywordCallExpr) SQL() string {
+	return c.Name + callArgsSQL(c.Distinct, c.Args)
+}
+
+// PathCallExpr is a call of a function whose name is a dotted path like `SAFE.LOWER(x)` or `NET.HOST(url)`.
+// ast.CallExpr can't be used for it because its name is a single identifier.
+type PathCallExpr struct {
+	exprNode
+	Func     *ast.Path
+	Distinct bool
+	Args     []ast.Arg
+}
+
+func (c *PathCallExpr) SQL() string {
+	return c.Func.SQL() + callArgsSQL(c.Distinct, c.Args)
+}
+
+func callArgsSQL(distinct bool, args []ast.Arg) string {
+	sql := "("
+	if distinct {
+		sql += "DISTINCT "
+	}
+	for i, a := range args {
+		if i != 0 {
+			sql += ", "
+		}
+		sql += a.SQL()
+	}
+	return sql + ")"
+}
+
+// Call creates a function call expression. A dotted name like "SAFE.LOWER" is called as a path.
+func Call(name string, distinct bool, args []ast.Arg) ast.Expr {
+	if parts := strings.Split(name, "."); len(parts) > 1 {
+		path := &ast.Path{}
+		for _, p := range parts {
+			path.Idents = append(path.Idents, Ident(p))
+		}
+		return &PathCallExpr{
+			Func:     path,
+			Distinct: distinct,
+			Args:     args,
+		}
+	}
+	if token.IsKeyword(name) {
+		return &KeywordCallExpr{
+			Name:     strings.ToUpper(name),
+			Distinct: distinct,
+			Args:     args,
+		}
+	}
+	return &ast.CallExpr{
+		Func:     &ast.Ident{Name: name},
+		Distinct: distinct,
+		Args:     args,
+	}
+}
+
+// UnsupportedTypeError is an error for Go values which can't be converted into SQL expressions.
+type UnsupportedTypeError struct {
+	Type reflect.Type
+}
+
+func (e *UnsupportedTypeError) Error() string {
+	return "can't convert " + e.Type.String() + " into SQL expr"
+}
+
+// MaxIdentLength is the maximum length of identifiers in Spanner.
+const MaxIdentLength = 128
+
+// CheckIdent returns an error if name can't be an identifier even if quoted.
+// Identifiers colliding with reserved words or having non-identifier characters are quoted by backticks when rendered.
+func CheckIdent(name string) error {
+	if name == "" {
+		return errors.New("empty identifier")
+	}
+	if !utf8.ValidString(name) {
+		return errors.Errorf("identifier %q is not valid UTF-8", name)
+	}
+	if utf8.RuneCountInString(name) > MaxIdentLength {
+		return errors.Errorf("identifier %s is longer than %d characters", name, MaxIdentLength)
+	}
+	return nil
 }
@@ -0,0 +1,56 @@
+package internal
+
+import (
+	"strings"
+
+	"github.com/cloudspannerecosystem/memefish"
+	"github.com/cloudspannerecosystem/memefish/ast"
+	"github.com/cloudspannerecosystem/memefish/token"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// extraTypeNames are names of scalar types memefish doesn't support.
+var extraTypeNames = map[string]bool{
+	string(jsonTypeName):     true,
+	string(intervalTypeName): true,
+}
+
+// ParseType parses a type name like "INT64" or "ARRAY<STRING>".
+// JSON, INTERVAL and fully-qualified names of PROTO and ENUM types are also accepted
+// though memefish doesn't support them.
+func ParseType(name string) (ast.Type, error) {
+	if n := strings.TrimSpace(name); protoTypeNamePattern.MatchString(n) {
+		return ProtoType(protoreflect.FullName(n)), nil
+	}
+	trimmed := strings.ToUpper(strings.TrimSpace(name))
+	if extraTypeNames[trimmed] {
+		return &ast.SimpleType{Name: ast.ScalarTypeName(trimmed)}, nil
+	}
+	if strings.HasPrefix(trimmed, "ARRAY<") && strings.HasSuffix(trimmed, ">") {
+		if elem := strings.TrimSpace(trimmed[len("ARRAY<") : len(trimmed)-1]); extraTypeNames[elem] {
+			return &ast.ArrayType{Item: &ast.SimpleType{Name: ast.ScalarTypeName(elem)}}, nil
+		}
+	}
+	p := &memefish.Parser{
+		Lexer: &memefish.Lexer{
+			File: &token.File{Buffer: name},
+		},
+	}
+	t, err := p.ParseType()
+	if err != nil {
+		return nil, errors.WithMessagef(err, "invalid type %q", name)
+	}
+	return t, nil
+}
+
+// SafeCastExpr is `SAFE_CAST(expr AS type)`, which memefish doesn't support.
+type SafeCastExpr struct {
+	exprNode
+	Expr ast.Expr
+	Type ast.Type
+}
+
+func (c *SafeCastExpr) SQL() string {
+	return "SAFE_CAST(" + c.Expr.SQL() + " AS " + c.Type.SQL() + ")"
+}
@@ -0,0 +1,25 @@
+package internal
+
+import "github.com/cloudspannerecosystem/memefish/ast"
+
+// exprNode makes a type embedding it satisfy ast.Expr.
+// It is used for expressions memefish has no AST node for.
+type exprNode struct {
+	ast.NullLiteral
+}
+
+func (exprNode) isCustomExpr() {}
+
+type customExpr interface {
+	ast.Expr
+	isCustomExpr()
+}
+
+// Operand returns expr in the form that can be used as an operand of memefish's operator nodes.
+// memefish doesn't know the precedence of expressions defined in this package, so they are parenthesized.
+func Operand(expr ast.Expr) ast.Expr {
+	if _, ok := expr.(customExpr); ok {
+		return &ast.ParenExpr{Expr: expr}
+	}
+	return expr
+}
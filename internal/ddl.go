@@ -0,0 +1,77 @@
+package internal
+
+import (
+	"github.com/cloudspannerecosystem/memefish"
+	"github.com/cloudspannerecosystem/memefish/ast"
+	"github.com/cloudspannerecosystem/memefish/token"
+	"github.com/pkg/errors"
+)
+
+// ParseDDLs parses DDL statements separated by semicolons.
+func ParseDDLs(ddl string) ([]ast.DDL, error) {
+	p := &memefish.Parser{
+		Lexer: &memefish.Lexer{
+			File: &token.File{Buffer: ddl},
+		},
+	}
+	return p.ParseDDLs()
+}
+
+// ParseExpr parses an expression like a DEFAULT expression of a column.
+func ParseExpr(expr string) (ast.Expr, error) {
+	p := &memefish.Parser{
+		Lexer: &memefish.Lexer{
+			File: &token.File{Buffer: expr},
+		},
+	}
+	e, err := p.ParseExpr()
+	if err != nil {
+		return nil, errors.Errorf("invalid expression %q", expr)
+	}
+	return e, nil
+}
+
+// ParseSchemaType parses a column type as written in DDL like "INT64", "STRING(MAX)" or "ARRAY<BYTES(16)>".
+func ParseSchemaType(name string) (ast.SchemaType, error) {
+	p := &memefish.Parser{
+		Lexer: &memefish.Lexer{
+			File: &token.File{Buffer: "CREATE TABLE t (c " + name + ") PRIMARY KEY ()"},
+		},
+	}
+	ddl, err := p.ParseDDL()
+	if err != nil {
+		return nil, errors.Errorf("invalid column type %q", name)
+	}
+	ct, ok := ddl.(*ast.CreateTable)
+	if !ok || len(ct.Columns) != 1 || ct.Columns[0].NotNull {
+		return nil, errors.Errorf("invalid column type %q", name)
+	}
+	return ct.Columns[0].Type, nil
+}
+
+// DropView is `DROP VIEW name`, which memefish doesn't support.
+type DropView struct {
+	Name *ast.Ident
+}
+
+func (d *DropView) Pos() token.Pos {
+	return d.Name.Pos()
+}
+
+func (d *DropView) End() token.Pos {
+	return d.Name.End()
+}
+
+func (d *DropView) SQL() string {
+	return "DROP VIEW " + d.Name.SQL()
+}
+
+// ChangeStreamSetOptions is `SET OPTIONS (...)` of ALTER CHANGE STREAM,
+// which memefish renders with a redundant space before OPTIONS.
+type ChangeStreamSetOptions struct {
+	ast.ChangeStreamSetOptions
+}
+
+func (a *ChangeStreamSetOptions) SQL() string {
+	return "SET" + a.Options.SQL()
+}
@@ -0,0 +1,185 @@
+//go:build memeduckdebug
+
+package internal
+
+import (
+	"strings"
+
+	"github.com/cloudspannerecosystem/memefish"
+	"github.com/cloudspannerecosystem/memefish/ast"
+	"github.com/cloudspannerecosystem/memefish/token"
+	"github.com/pkg/errors"
+)
+
+// Debug reports whether expensive invariant checks are enabled by `memeduckdebug` build tag.
+const Debug = true
+
+// Verify checks that sql rendered from node is parsed back into the same SQL,
+// which catches broken syntax and missing parentheses on operator precedence.
+// Statements containing nodes memefish doesn't support are verified by their SELECT queries which don't.
+// It also checks that aliases in the statement are resolved unambiguously.
+func Verify(node ast.Node, sql string) error {
+	if err := verifyAliases(node); err != nil {
+		return errors.Errorf("memeduckdebug: %s in rendered SQL: %s", err, sql)
+	}
+	if hasCustomNode(node) {
+		return verifyQueries(node)
+	}
+	p := newParser(sql)
+	var stmt ast.Node
+	var err error
+	if _, ok := node.(ast.DDL); ok {
+		stmt, err = p.ParseDDL()
+	} else {
+		stmt, err = p.ParseStatement()
+	}
+	if err != nil {
+		return errors.WithMessagef(err, "memeduckdebug: rendered SQL can't be parsed: %s", sql)
+	}
+	if reparsed := stmt.SQL(); reparsed != sql {
+		return errors.Errorf("memeduckdebug: rendered SQL changes on re-parse: %s => %s", sql, reparsed)
+	}
+	return nil
+}
+
+func newParser(sql string) *memefish.Parser {
+	return &memefish.Parser{
+		Lexer: &memefish.Lexer{
+			File: &token.File{Buffer: sql},
+		},
+	}
+}
+
+// verifyQueries verifies the outermost SELECT queries in node without custom nodes by re-parsing them.
+func verifyQueries(node ast.Node) error {
+	var err error
+	Walk(node, func(n ast.Node) bool {
+		sel, ok := n.(*ast.Select)
+		if err != nil || !ok || hasCustomNode(sel) {
+			return err == nil
+		}
+		sql := sel.SQL()
+		stmt, perr := newParser(sql).ParseQuery()
+		if perr != nil {
+			err = errors.WithMessagef(perr, "memeduckdebug: rendered query can't be parsed: %s", sql)
+		} else if reparsed := stmt.SQL(); reparsed != sql {
+			err = errors.Errorf("memeduckdebug: rendered query changes on re-parse: %s => %s", sql, reparsed)
+		}
+		return false
+	})
+	return err
+}
+
+// verifyAliases checks that no two tables in FROM clauses have the same name or alias,
+// and that names in GROUP BY and ORDER BY don't refer to more than one result alias.
+func verifyAliases(node ast.Node) error {
+	var err error
+	Walk(node, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.Select:
+			err = verifySelectAliases(n)
+		case *Select:
+			err = verifySelectAliases(&n.Select)
+		}
+		return err == nil
+	})
+	return err
+}
+
+func verifySelectAliases(sel *ast.Select) error {
+	if sel.From != nil {
+		seen := make(map[string]bool)
+		for _, name := range tableNames(sel.From.Source, nil) {
+			if seen[strings.ToLower(name)] {
+				return errors.Errorf("duplicate table alias %s", name)
+			}
+			seen[strings.ToLower(name)] = true
+		}
+	}
+	aliases := make(map[string]int)
+	for _, r := range sel.Results {
+		if a, ok := r.(*ast.Alias); ok {
+			aliases[strings.ToLower(a.As.Alias.Name)]++
+		}
+	}
+	var refs []ast.Expr
+	if sel.GroupBy != nil {
+		refs = append(refs, sel.GroupBy.Exprs...)
+	}
+	if sel.OrderBy != nil {
+		for _, item := range sel.OrderBy.Items {
+			refs = append(refs, item.Expr)
+		}
+	}
+	for _, e := range refs {
+		var name string
+		switch e := e.(type) {
+		case *ast.Ident:
+			name = e.Name
+		case *ast.Path:
+			if len(e.Idents) == 1 {
+				name = e.Idents[0].Name
+			}
+		}
+		if aliases[strings.ToLower(name)] > 1 {
+			return errors.Errorf("ambiguous alias %s", name)
+		}
+	}
+	return nil
+}
+
+// tableNames appends the names and aliases the tables in the table expression e are referred by to names.
+func tableNames(e ast.TableExpr, names []string) []string {
+	switch e := e.(type) {
+	case *ast.TableName:
+		if e.As != nil {
+			return append(names, e.As.Alias.Name)
+		}
+		return append(names, e.Table.Name)
+	case *ast.Unnest:
+		if e.As != nil {
+			names = append(names, e.As.Alias.Name)
+		}
+		if e.WithOffset != nil && e.WithOffset.As != nil {
+			names = append(names, e.WithOffset.As.Alias.Name)
+		}
+	case *ast.SubQueryTableExpr:
+		if e.As != nil {
+			names = append(names, e.As.Alias.Name)
+		}
+	case *ast.ParenTableExpr:
+		return tableNames(e.Source, names)
+	case *ast.Join:
+		return tableNames(e.Right, tableNames(e.Left, names))
+	}
+	return names
+}
+
+func hasCustomNode(node ast.Node) bool {
+	found := false
+	Walk(node, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case customExpr, *InsertOrStmt, *ThenReturnStmt, *Select, *DropView, *ChangeStreamSetOptions:
+			found = true
+		case *ast.OrderByItem:
+			// NULLS FIRST/LAST is rendered in Dir.
+			found = found || n.Dir != "" && n.Dir != ast.DirectionAsc && n.Dir != ast.DirectionDesc
+		case *ast.SimpleType:
+			// JSON, INTERVAL, PROTO and ENUM types.
+			found = found || !standardTypeNames[n.Name]
+		}
+		return !found
+	})
+	return found
+}
+
+var standardTypeNames = map[ast.ScalarTypeName]bool{
+	ast.BoolTypeName:      true,
+	ast.Int64TypeName:     true,
+	ast.Float64TypeName:   true,
+	ast.StringTypeName:    true,
+	ast.BytesTypeName:     true,
+	ast.DateTypeName:      true,
+	ast.TimestampTypeName: true,
+	ast.NumericTypeName:   true,
+}
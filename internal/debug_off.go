@@ -0,0 +1,13 @@
+//go:build !memeduckdebug
+
+package internal
+
+import "github.com/cloudspannerecosystem/memefish/ast"
+
+// Debug reports whether expensive invariant checks are enabled by `memeduckdebug` build tag.
+const Debug = false
+
+// Verify does nothing without `memeduckdebug` build tag.
+func Verify(node ast.Node, sql string) error {
+	return nil
+}
@@ -0,0 +1,71 @@
+//go:build memeduckdebug
+
+package internal_test
+
+import (
+	"testing"
+
+	"github.com/cloudspannerecosystem/memefish/ast"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/abyssparanoia/memeduck/internal"
+)
+
+func TestVerify(t *testing.T) {
+	assert.True(t, internal.Debug)
+	del := &ast.Delete{
+		TableName: internal.Ident("hoge"),
+		Where: &ast.Where{
+			Expr: &ast.BinaryExpr{Op: ast.OpEqual, Left: internal.Ident("a"), Right: internal.IntLit(1)},
+		},
+	}
+	assert.Nil(t, internal.Verify(del, del.SQL()))
+	assert.Error(t, internal.Verify(del, "DELETE FROM hoge WHERE a ="))
+
+	broken := &ast.Delete{
+		TableName: internal.Ident("hoge"),
+		Where:     &ast.Where{Expr: &ast.IntLiteral{Value: "1 +"}},
+	}
+	assert.Error(t, internal.Verify(broken, broken.SQL()))
+
+	// memefish can't parse JSON literals, so they are not verified.
+	custom := &ast.Delete{
+		TableName: internal.Ident("hoge"),
+		Where:     &ast.Where{Expr: internal.JSONLit("{")},
+	}
+	assert.Nil(t, internal.Verify(custom, "not SQL"))
+
+	// SELECT queries in statements with custom nodes are still verified.
+	query := &ast.Select{
+		Results: []ast.SelectItem{&ast.ExprSelectItem{Expr: internal.Ident("a")}},
+		From:    &ast.From{Source: &ast.TableName{Table: internal.Ident("fuga")}},
+		Where:   &ast.Where{Expr: &ast.IntLiteral{Value: "1 +"}},
+	}
+	custom.Where.Expr = &ast.BinaryExpr{Op: ast.OpAnd, Left: internal.Operand(internal.JSONLit("{")), Right: &ast.ExistsSubQuery{Query: query}}
+	assert.Error(t, internal.Verify(custom, custom.SQL()))
+	query.Where.Expr = internal.BoolLit(true)
+	assert.Nil(t, internal.Verify(custom, custom.SQL()))
+}
+
+func TestVerifyAliases(t *testing.T) {
+	sel := &ast.Select{
+		Results: []ast.SelectItem{
+			&ast.Alias{Expr: internal.Ident("a"), As: &ast.AsAlias{Alias: internal.Ident("x")}},
+			&ast.Alias{Expr: internal.Ident("b"), As: &ast.AsAlias{Alias: internal.Ident("y")}},
+		},
+		From: &ast.From{Source: &ast.Join{
+			Op:    ast.CommaJoin,
+			Left:  &ast.TableName{Table: internal.Ident("hoge")},
+			Right: &ast.TableName{Table: internal.Ident("fuga"), As: &ast.AsAlias{Alias: internal.Ident("f")}},
+		}},
+		OrderBy: &ast.OrderBy{Items: []*ast.OrderByItem{{Expr: internal.Ident("x")}}},
+	}
+	assert.Nil(t, internal.Verify(sel, sel.SQL()))
+
+	sel.From.Source.(*ast.Join).Right.(*ast.TableName).As.Alias = internal.Ident("HOGE")
+	assert.EqualError(t, internal.Verify(sel, sel.SQL()), "memeduckdebug: duplicate table alias HOGE in rendered SQL: "+sel.SQL())
+
+	sel.From.Source.(*ast.Join).Right.(*ast.TableName).As = nil
+	sel.Results[1].(*ast.Alias).As.Alias = internal.Ident("X")
+	assert.EqualError(t, internal.Verify(sel, sel.SQL()), "memeduckdebug: ambiguous alias x in rendered SQL: "+sel.SQL())
+}
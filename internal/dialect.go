@@ -0,0 +1,42 @@
+package internal
+
+import "github.com/pkg/errors"
+
+// Dialect names.
+const (
+	GoogleSQL  = "GoogleSQL"
+	PostgreSQL = "PostgreSQL"
+)
+
+// Features which are not available in every dialect.
+const (
+	FeatureAsStruct       = "AS STRUCT"
+	FeatureWindow         = "WINDOW clause"
+	FeatureWithOffset     = "WITH OFFSET"
+	FeatureForceIndex     = "FORCE_INDEX hint"
+	FeatureStatementHints = "statement hints"
+	FeaturePrettySQL      = "PrettySQL"
+	FeatureInsertGuard    = "IdempotencyKeyUnused of INSERT"
+)
+
+// unsupportedFeatures lists features not available in each dialect.
+// GoogleSQL supports every feature memeduck can build.
+var unsupportedFeatures = map[string]map[string]bool{
+	PostgreSQL: {
+		FeatureAsStruct:       true,
+		FeatureWindow:         true,
+		FeatureWithOffset:     true,
+		FeatureForceIndex:     true,
+		FeatureStatementHints: true,
+		FeaturePrettySQL:      true,
+		FeatureInsertGuard:    true,
+	},
+}
+
+// CheckFeature returns an error if the feature is not supported in the dialect.
+func CheckFeature(dialect, feature string) error {
+	if unsupportedFeatures[dialect][feature] {
+		return errors.Errorf("%s is not supported in %s dialect", feature, dialect)
+	}
+	return nil
+}
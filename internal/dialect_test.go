@@ -0,0 +1,14 @@
+package internal_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/abyssparanoia/memeduck/internal"
+)
+
+func TestCheckFeature(t *testing.T) {
+	assert.Nil(t, internal.CheckFeature(internal.GoogleSQL, internal.FeatureAsStruct))
+	assert.EqualError(t, internal.CheckFeature(internal.PostgreSQL, internal.FeatureAsStruct), "AS STRUCT is not supported in PostgreSQL dialect")
+}
@@ -0,0 +1,56 @@
+package internal
+
+import (
+	"strings"
+
+	"github.com/cloudspannerecosystem/memefish/ast"
+	"github.com/cloudspannerecosystem/memefish/token"
+)
+
+const (
+	InsertOrUpdate = "OR UPDATE"
+	InsertOrIgnore = "OR IGNORE"
+)
+
+// InsertOrStmt is `INSERT OR UPDATE INTO ...` or `INSERT OR IGNORE INTO ...`, which memefish doesn't support.
+type InsertOrStmt struct {
+	Mode   string
+	Insert *ast.Insert
+}
+
+func (s *InsertOrStmt) Pos() token.Pos {
+	return s.Insert.Pos()
+}
+
+func (s *InsertOrStmt) End() token.Pos {
+	return s.Insert.End()
+}
+
+func (s *InsertOrStmt) SQL() string {
+	return "INSERT " + s.Mode + " " + strings.TrimPrefix(s.Insert.SQL(), "INSERT ")
+}
+
+// ThenReturnStmt is a DML statement with `THEN RETURN` clause, which memefish doesn't support.
+type ThenReturnStmt struct {
+	DML   ast.Node
+	Items []ast.SelectItem
+}
+
+func (s *ThenReturnStmt) Pos() token.Pos {
+	return s.DML.Pos()
+}
+
+func (s *ThenReturnStmt) End() token.Pos {
+	return s.DML.End()
+}
+
+func (s *ThenReturnStmt) SQL() string {
+	sql := s.DML.SQL() + " THEN RETURN "
+	for i, item := range s.Items {
+		if i != 0 {
+			sql += ", "
+		}
+		sql += item.SQL()
+	}
+	return sql
+}
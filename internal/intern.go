@@ -0,0 +1,52 @@
+package internal
+
+import (
+	"strconv"
+	"sync"
+	"sync/atomic"
+
+	"github.com/cloudspannerecosystem/memefish/ast"
+)
+
+// Frequently used AST nodes are interned to reduce allocations on building statements.
+// Interned nodes are shared between statements, so they must never be modified.
+
+const (
+	minInternedInt = -128
+	maxInternedInt = 1024
+
+	// maxInternedIdents bounds the number of interned identifiers, so that dynamic names can't grow the cache forever.
+	maxInternedIdents = 4096
+)
+
+var (
+	trueLit  = &ast.BoolLiteral{Value: true}
+	falseLit = &ast.BoolLiteral{Value: false}
+	nullLit  = &ast.NullLiteral{}
+	intLits  = func() []*ast.IntLiteral {
+		lits := make([]*ast.IntLiteral, 0, maxInternedInt-minInternedInt+1)
+		for v := int64(minInternedInt); v <= maxInternedInt; v++ {
+			lits = append(lits, &ast.IntLiteral{Base: 10, Value: strconv.FormatInt(v, 10)})
+		}
+		return lits
+	}()
+
+	idents     sync.Map
+	identCount int64
+)
+
+// Ident returns an identifier node of name, which is shared for frequently used names.
+func Ident(name string) *ast.Ident {
+	if id, ok := idents.Load(name); ok {
+		return id.(*ast.Ident)
+	}
+	id := &ast.Ident{Name: name}
+	if atomic.LoadInt64(&identCount) >= maxInternedIdents {
+		return id
+	}
+	if actual, loaded := idents.LoadOrStore(name, id); loaded {
+		return actual.(*ast.Ident)
+	}
+	atomic.AddInt64(&identCount, 1)
+	return id
+}
@@ -0,0 +1,42 @@
+package internal_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/abyssparanoia/memeduck/internal"
+)
+
+func TestInterned(t *testing.T) {
+	assert.Same(t, internal.IntLit(1), internal.IntLit(1))
+	assert.Same(t, internal.IntLit(-128), internal.IntLit(-128))
+	assert.NotSame(t, internal.IntLit(100000), internal.IntLit(100000))
+	assert.Equal(t, "100000", internal.IntLit(100000).SQL())
+	assert.Equal(t, "-5", internal.IntLit(-5).SQL())
+	assert.Same(t, internal.BoolLit(true), internal.BoolLit(true))
+	assert.Equal(t, "FALSE", internal.BoolLit(false).SQL())
+	assert.Same(t, internal.NullLit(), internal.NullLit())
+	assert.Same(t, internal.Ident("id"), internal.Ident("id"))
+	assert.Equal(t, "`select`", internal.Ident("select").SQL())
+}
+
+func BenchmarkIdent(b *testing.B) {
+	names := make([]string, 16)
+	for i := range names {
+		names[i] = fmt.Sprintf("col%d", i)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		internal.Ident(names[i%len(names)])
+	}
+}
+
+func BenchmarkIntLit(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		internal.IntLit(int64(i % 100))
+	}
+}
@@ -0,0 +1,14 @@
+package internal
+
+import "github.com/cloudspannerecosystem/memefish/ast"
+
+// IntervalExpr is an interval like `INTERVAL 1 DAY`, which memefish doesn't support.
+type IntervalExpr struct {
+	exprNode
+	Value ast.Expr
+	Unit  string
+}
+
+func (e *IntervalExpr) SQL() string {
+	return "INTERVAL " + Operand(e.Value).SQL() + " " + e.Unit
+}
@@ -0,0 +1,144 @@
+package internal
+
+import (
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/civil"
+	"cloud.google.com/go/spanner"
+	"github.com/cloudspannerecosystem/memefish/ast"
+	"github.com/pkg/errors"
+)
+
+// Parameterize replaces literals in node with query parameters in place, and returns the values of the parameters.
+// Parameters are named p1, p2, ..., skipping names already used in node.
+// An array of literals of the same type is replaced with a single parameter of a slice,
+// so that the SQL doesn't change with the number of elements.
+// NULL, hints, LIMIT counts and arguments which must be literals, like the spec of COLLATE, are kept as they are.
+func Parameterize(node ast.Node) (map[string]interface{}, error) {
+	used := make(map[string]bool)
+	keep := make(map[ast.Expr]bool)
+	Walk(node, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.Param:
+			used[n.Name] = true
+		case *ast.CallExpr:
+			keepLiteralArgs(keep, n.Func.Name, n.Args)
+		case *KeywordCallExpr:
+			keepLiteralArgs(keep, n.Name, n.Args)
+		}
+		return true
+	})
+	params := make(map[string]interface{})
+	next := 0
+	var err error
+	replaceExprs(reflect.ValueOf(node), func(expr ast.Expr) ast.Expr {
+		if err != nil || keep[expr] {
+			return nil
+		}
+		var v interface{}
+		if arr, ok := expr.(*ast.ArrayLiteral); ok {
+			v, err = arrayLiteralValue(arr)
+		} else if isLiteral(expr) {
+			v, err = literalValue(expr)
+		}
+		if err != nil || v == nil {
+			return nil
+		}
+		var name string
+		for name == "" || used[name] {
+			next++
+			name = "p" + strconv.Itoa(next)
+		}
+		params[name] = v
+		return &ast.Param{Name: name}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return params, nil
+}
+
+// literalOnlyArgs lists the positions of function arguments which Spanner accepts only as literals.
+var literalOnlyArgs = map[string][]int{
+	"COLLATE":          {1},
+	"JSON_QUERY":       {1},
+	"JSON_VALUE":       {1},
+	"JSON_QUERY_ARRAY": {1},
+	"JSON_VALUE_ARRAY": {1},
+}
+
+// keepLiteralArgs adds the arguments of the function call which must be literals to keep.
+func keepLiteralArgs(keep map[ast.Expr]bool, name string, args []ast.Arg) {
+	for _, i := range literalOnlyArgs[strings.ToUpper(name)] {
+		if i >= len(args) {
+			continue
+		}
+		if arg, ok := args[i].(*ast.ExprArg); ok {
+			keep[arg.Expr] = true
+		}
+	}
+}
+
+// arrayLiteralValue converts an array of literals of the same type into a slice.
+// It returns nil if the array is empty or has other expressions.
+func arrayLiteralValue(arr *ast.ArrayLiteral) (interface{}, error) {
+	if len(arr.Values) == 0 {
+		return nil, nil
+	}
+	var slice reflect.Value
+	for i, e := range arr.Values {
+		if !isLiteral(e) {
+			return nil, nil
+		}
+		v, err := literalValue(e)
+		if err != nil {
+			return nil, err
+		}
+		rv := reflect.ValueOf(v)
+		if i == 0 {
+			slice = reflect.MakeSlice(reflect.SliceOf(rv.Type()), 0, len(arr.Values))
+		} else if rv.Type() != slice.Type().Elem() {
+			return nil, nil
+		}
+		slice = reflect.Append(slice, rv)
+	}
+	return slice.Interface(), nil
+}
+
+// literalValue converts a literal into the Go value bound to a query parameter.
+func literalValue(expr ast.Expr) (interface{}, error) {
+	switch l := expr.(type) {
+	case *ast.StringLiteral:
+		return l.Value, nil
+	case *ast.BytesLiteral:
+		return l.Value, nil
+	case *ast.IntLiteral:
+		v, err := strconv.ParseInt(l.Value, 0, 64)
+		return v, errors.WithStack(err)
+	case *ast.FloatLiteral:
+		v, err := strconv.ParseFloat(l.Value, 64)
+		return v, errors.WithStack(err)
+	case *ast.BoolLiteral:
+		return l.Value, nil
+	case *ast.NumericLiteral:
+		v, ok := new(big.Rat).SetString(l.Value.Value)
+		if !ok {
+			return nil, errors.Errorf("invalid NUMERIC %q", l.Value.Value)
+		}
+		return spanner.NullNumeric{Numeric: *v, Valid: true}, nil
+	case *ast.DateLiteral:
+		v, err := civil.ParseDate(l.Value.Value)
+		return v, errors.WithStack(err)
+	case *ast.TimestampLiteral:
+		v, err := time.Parse(time.RFC3339Nano, l.Value.Value)
+		return v, errors.WithStack(err)
+	case *JSONLiteral:
+		return spanner.NullJSON{Value: json.RawMessage(l.Value.Value), Valid: true}, nil
+	}
+	return nil, errors.Errorf("%T is not a literal", expr)
+}
@@ -0,0 +1,44 @@
+package internal
+
+import (
+	"github.com/cloudspannerecosystem/memefish"
+	"github.com/cloudspannerecosystem/memefish/ast"
+	"github.com/cloudspannerecosystem/memefish/token"
+)
+
+// ParamNames returns names of query parameters appearing in sql, in order of first appearance.
+func ParamNames(sql string) ([]string, error) {
+	lex := &memefish.Lexer{
+		File: &token.File{Buffer: sql},
+	}
+	var names []string
+	seen := make(map[string]bool)
+	for {
+		if err := lex.NextToken(); err != nil {
+			return nil, err
+		}
+		if lex.Token.Kind == token.TokenEOF {
+			return names, nil
+		}
+		if lex.Token.Kind != token.TokenParam || seen[lex.Token.AsString] {
+			continue
+		}
+		seen[lex.Token.AsString] = true
+		names = append(names, lex.Token.AsString)
+	}
+}
+
+// NodeParamNames returns names of query parameters appearing in node, in order of first appearance.
+// Unlike ParamNames, it works on the AST, so it doesn't depend on the dialect the node is rendered in.
+func NodeParamNames(node ast.Node) []string {
+	var names []string
+	seen := make(map[string]bool)
+	Walk(node, func(n ast.Node) bool {
+		if p, ok := n.(*ast.Param); ok && !seen[p.Name] {
+			seen[p.Name] = true
+			names = append(names, p.Name)
+		}
+		return true
+	})
+	return names
+}
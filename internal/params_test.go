@@ -0,0 +1,29 @@
+package internal_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/abyssparanoia/memeduck/internal"
+)
+
+func TestParamNames(t *testing.T) {
+	names, err := internal.ParamNames(`SELECT a FROM t WHERE b = @b AND c = "@c" AND d IN UNNEST(@d) AND e = @b`)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"b", "d"}, names)
+
+	names, err = internal.ParamNames(`SELECT a FROM t`)
+	assert.Nil(t, err)
+	assert.Empty(t, names)
+}
+
+func TestNodeParamNames(t *testing.T) {
+	expr, err := internal.ParseExpr(`b = @b AND c = "@c" AND d IN UNNEST(@d) AND e = @b`)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"b", "d"}, internal.NodeParamNames(expr))
+
+	expr, err = internal.ParseExpr(`a`)
+	assert.Nil(t, err)
+	assert.Empty(t, internal.NodeParamNames(expr))
+}
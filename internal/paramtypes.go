@@ -0,0 +1,165 @@
+package internal
+
+import (
+	"strings"
+
+	"github.com/cloudspannerecosystem/memefish/ast"
+	"github.com/pkg/errors"
+)
+
+// ParamTypes infers types of query parameters in node from the context they are used in.
+// Parameters whose type can't be inferred are mapped to "".
+func ParamTypes(node ast.Node) (map[string]string, error) {
+	inf := &paramTypeInferrer{types: make(map[string]string)}
+	// A type inferred for a parameter may make other ones inferable, e.g. `@a = @b AND @b = 1`,
+	// so repeat until nothing changes.
+	for {
+		inf.changed = false
+		Walk(node, func(n ast.Node) bool {
+			inf.visit(n)
+			return inf.err == nil
+		})
+		if inf.err != nil {
+			return nil, inf.err
+		}
+		if !inf.changed {
+			return inf.types, nil
+		}
+	}
+}
+
+type paramTypeInferrer struct {
+	types   map[string]string
+	changed bool
+	err     error
+}
+
+func (inf *paramTypeInferrer) visit(n ast.Node) {
+	switch n := n.(type) {
+	case *ast.Param:
+		if _, ok := inf.types[n.Name]; !ok {
+			inf.types[n.Name] = ""
+		}
+	case *ast.BinaryExpr:
+		switch n.Op {
+		case ast.OpEqual, ast.OpNotEqual, ast.OpLess, ast.OpGreater, ast.OpLessEqual, ast.OpGreaterEqual:
+			inf.unify(n.Left, n.Right)
+		case ast.OpLike, ast.OpNotLike:
+			inf.set(n.Left, "STRING")
+			inf.set(n.Right, "STRING")
+		case ast.OpAnd, ast.OpOr:
+			inf.set(n.Left, "BOOL")
+			inf.set(n.Right, "BOOL")
+		}
+	case *ast.UnaryExpr:
+		if n.Op == ast.OpNot {
+			inf.set(n.Expr, "BOOL")
+		}
+	case *ast.BetweenExpr:
+		inf.unify(n.Left, n.RightStart)
+		inf.unify(n.Left, n.RightEnd)
+		inf.unify(n.RightStart, n.RightEnd)
+	case *ast.InExpr:
+		switch r := n.Right.(type) {
+		case *ast.UnnestInCondition:
+			if t := inf.typeOf(n.Left); t != "" {
+				inf.set(r.Expr, "ARRAY<"+t+">")
+			}
+			if t := inf.typeOf(r.Expr); strings.HasPrefix(t, "ARRAY<") {
+				inf.set(n.Left, strings.TrimSuffix(strings.TrimPrefix(t, "ARRAY<"), ">"))
+			}
+		case *ast.ValuesInCondition:
+			for _, e := range r.Exprs {
+				inf.unify(n.Left, e)
+			}
+		}
+	case *ast.CastExpr:
+		inf.set(n.Expr, n.Type.SQL())
+	case *ast.Where:
+		inf.set(n.Expr, "BOOL")
+	}
+}
+
+// unify infers the type of a parameter from the other side of a comparison.
+func (inf *paramTypeInferrer) unify(a, b ast.Expr) {
+	if t := inf.typeOf(b); t != "" {
+		inf.set(a, t)
+	}
+	if t := inf.typeOf(a); t != "" {
+		inf.set(b, t)
+	}
+}
+
+func (inf *paramTypeInferrer) set(e ast.Expr, t string) {
+	if inf.err != nil {
+		return
+	}
+	p, ok := unparen(e).(*ast.Param)
+	if !ok {
+		return
+	}
+	cur := inf.types[p.Name]
+	if cur == t {
+		return
+	}
+	if cur != "" {
+		inf.err = errors.Errorf("parameter @%s is used as both %s and %s", p.Name, cur, t)
+		return
+	}
+	inf.types[p.Name] = t
+	inf.changed = true
+}
+
+func (inf *paramTypeInferrer) typeOf(e ast.Expr) string {
+	if p, ok := unparen(e).(*ast.Param); ok {
+		return inf.types[p.Name]
+	}
+	return LiteralType(e)
+}
+
+// LiteralType returns the type of a literal or a CAST expression, or "" if e is not one of them
+// or its type is unknown, e.g. NULL or an empty array without type.
+func LiteralType(e ast.Expr) string {
+	switch e := unparen(e).(type) {
+	case *ast.CastExpr:
+		return e.Type.SQL()
+	case *ast.BoolLiteral:
+		return "BOOL"
+	case *ast.IntLiteral:
+		return "INT64"
+	case *ast.FloatLiteral:
+		return "FLOAT64"
+	case *ast.StringLiteral:
+		return "STRING"
+	case *ast.BytesLiteral:
+		return "BYTES"
+	case *ast.DateLiteral:
+		return "DATE"
+	case *ast.TimestampLiteral:
+		return "TIMESTAMP"
+	case *ast.NumericLiteral:
+		return "NUMERIC"
+	case *JSONLiteral:
+		return "JSON"
+	case *ast.ArrayLiteral:
+		if e.Type != nil {
+			return "ARRAY<" + e.Type.SQL() + ">"
+		}
+		for _, v := range e.Values {
+			if t := LiteralType(v); t != "" {
+				return "ARRAY<" + t + ">"
+			}
+		}
+	}
+	return ""
+}
+
+func unparen(e ast.Expr) ast.Expr {
+	for {
+		p, ok := e.(*ast.ParenExpr)
+		if !ok {
+			return e
+		}
+		e = p.Expr
+	}
+}
@@ -0,0 +1,45 @@
+package internal_test
+
+import (
+	"testing"
+
+	"github.com/cloudspannerecosystem/memefish/ast"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/abyssparanoia/memeduck/internal"
+)
+
+func TestParamTypes(t *testing.T) {
+	param := func(name string) *ast.Param { return &ast.Param{Name: name} }
+	expr := &ast.BinaryExpr{
+		Op: ast.OpAnd,
+		Left: &ast.BinaryExpr{
+			Op:    ast.OpEqual,
+			Left:  param("a"),
+			Right: param("b"),
+		},
+		Right: &ast.BinaryExpr{
+			Op: ast.OpAnd,
+			Left: &ast.BinaryExpr{
+				Op:    ast.OpEqual,
+				Left:  param("b"),
+				Right: internal.IntLit(1),
+			},
+			Right: &ast.BinaryExpr{
+				Op:    ast.OpLike,
+				Left:  &ast.Ident{Name: "c"},
+				Right: param("c"),
+			},
+		},
+	}
+	types, err := internal.ParamTypes(expr)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"a": "INT64", "b": "INT64", "c": "STRING"}, types)
+
+	_, err = internal.ParamTypes(&ast.BinaryExpr{
+		Op:    ast.OpAnd,
+		Left:  &ast.BinaryExpr{Op: ast.OpEqual, Left: param("a"), Right: internal.IntLit(1)},
+		Right: &ast.BinaryExpr{Op: ast.OpEqual, Left: param("a"), Right: internal.StringLit("1")},
+	})
+	assert.Error(t, err)
+}
@@ -0,0 +1,503 @@
+package internal
+
+import (
+	"encoding/hex"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/cloudspannerecosystem/memefish/ast"
+	"github.com/pkg/errors"
+)
+
+// RenderPostgreSQL renders node in the PostgreSQL dialect of Spanner,
+// and returns the names of query parameters in order of their positional parameters $1, $2, ....
+// Function names are rendered as they are, so functions have to exist in both dialects.
+func RenderPostgreSQL(node ast.Node) (string, []string, error) {
+	r := &pgRenderer{index: make(map[string]int)}
+	sql := r.node(node)
+	if r.err != nil {
+		return "", nil, r.err
+	}
+	return sql, r.params, nil
+}
+
+// PostgreSQLParamName returns the name Spanner binds the positional parameter $n to.
+func PostgreSQLParamName(n int) string {
+	return "p" + strconv.Itoa(n)
+}
+
+type pgRenderer struct {
+	params []string
+	index  map[string]int
+	err    error
+}
+
+func (r *pgRenderer) unsupported(what string) string {
+	if r.err == nil {
+		r.err = errors.Errorf("%s is not supported in %s dialect", what, PostgreSQL)
+	}
+	return ""
+}
+
+func (r *pgRenderer) node(n ast.Node) string {
+	switch n := n.(type) {
+	case *ast.QueryStatement:
+		if n.Hint != nil {
+			return r.unsupported(FeatureStatementHints)
+		}
+		return r.node(n.Query)
+	case *ast.Select:
+		return r.selectSQL(n)
+	case *ast.Insert:
+		return r.insert(n)
+	case *ast.Update:
+		return r.update(n)
+	case *ast.Delete:
+		sql := "DELETE FROM " + r.ident(n.TableName)
+		if n.Where != nil {
+			sql += " WHERE " + r.expr(n.Where.Expr)
+		}
+		return sql
+	case *ThenReturnStmt:
+		sql := r.node(n.DML) + " RETURNING "
+		for i, item := range n.Items {
+			if i != 0 {
+				sql += ", "
+			}
+			sql += r.selectItem(item)
+		}
+		return sql
+	case *InsertOrStmt:
+		return r.unsupported("INSERT " + n.Mode)
+	case *Select:
+		return r.unsupported(FeatureWindow)
+	case ast.Expr:
+		return r.expr(n)
+	}
+	return r.unsupported(nodeName(n))
+}
+
+func (r *pgRenderer) selectSQL(s *ast.Select) string {
+	sql := "SELECT "
+	if s.Distinct {
+		sql += "DISTINCT "
+	}
+	if s.AsStruct {
+		return r.unsupported(FeatureAsStruct)
+	}
+	for i, item := range s.Results {
+		if i != 0 {
+			sql += ", "
+		}
+		sql += r.selectItem(item)
+	}
+	if s.From != nil {
+		sql += " FROM " + r.tableExpr(s.From.Source)
+	}
+	if s.Where != nil {
+		sql += " WHERE " + r.expr(s.Where.Expr)
+	}
+	if s.GroupBy != nil {
+		sql += " GROUP BY " + r.exprs(s.GroupBy.Exprs)
+	}
+	if s.Having != nil {
+		sql += " HAVING " + r.expr(s.Having.Expr)
+	}
+	if s.OrderBy != nil {
+		sql += " ORDER BY "
+		for i, item := range s.OrderBy.Items {
+			if i != 0 {
+				sql += ", "
+			}
+			if item.Collate != nil {
+				return r.unsupported("COLLATE")
+			}
+			sql += r.expr(item.Expr)
+			if item.Dir != "" {
+				sql += " " + string(item.Dir)
+			}
+		}
+	}
+	if s.Limit != nil {
+		sql += " LIMIT " + r.node(s.Limit.Count)
+		if s.Limit.Offset != nil {
+			sql += " OFFSET " + r.node(s.Limit.Offset.Value)
+		}
+	}
+	return sql
+}
+
+func (r *pgRenderer) selectItem(item ast.SelectItem) string {
+	switch i := item.(type) {
+	case *ast.Star:
+		return "*"
+	case *ast.ExprSelectItem:
+		return r.expr(i.Expr)
+	case *ast.Alias:
+		return r.expr(i.Expr) + " AS " + r.ident(i.As.Alias)
+	}
+	return r.unsupported(nodeName(item))
+}
+
+func (r *pgRenderer) tableExpr(t ast.TableExpr) string {
+	switch t := t.(type) {
+	case *ast.TableName:
+		if t.Hint != nil {
+			return r.unsupported(FeatureForceIndex)
+		}
+		sql := r.ident(t.Table)
+		if t.As != nil {
+			sql += " AS " + r.ident(t.As.Alias)
+		}
+		return sql
+	case *ast.Join:
+		if t.Hint != nil {
+			return r.unsupported("join hints")
+		}
+		sql := r.tableExpr(t.Left) + " " + string(t.Op) + " " + r.tableExpr(t.Right)
+		if on, ok := t.Cond.(*ast.On); ok {
+			sql += " ON " + r.expr(on.Expr)
+		} else if t.Cond != nil {
+			return r.unsupported("USING")
+		}
+		return sql
+	case *ast.Unnest:
+		return r.unsupported("UNNEST in FROM clause")
+	}
+	return r.unsupported(nodeName(t))
+}
+
+func (r *pgRenderer) insert(n *ast.Insert) string {
+	sql := "INSERT INTO " + r.ident(n.TableName) + " ("
+	for i, col := range n.Columns {
+		if i != 0 {
+			sql += ", "
+		}
+		sql += r.ident(col)
+	}
+	sql += ") "
+	switch in := n.Input.(type) {
+	case *ast.ValuesInput:
+		sql += "VALUES "
+		for i, row := range in.Rows {
+			if i != 0 {
+				sql += ", "
+			}
+			sql += "("
+			for j, e := range row.Exprs {
+				if j != 0 {
+					sql += ", "
+				}
+				if e.Default {
+					sql += "DEFAULT"
+				} else {
+					sql += r.expr(e.Expr)
+				}
+			}
+			sql += ")"
+		}
+	case *ast.SubQueryInput:
+		sql += r.node(in.Query)
+	default:
+		return r.unsupported(nodeName(in))
+	}
+	return sql
+}
+
+func (r *pgRenderer) update(n *ast.Update) string {
+	sql := "UPDATE " + r.ident(n.TableName) + " SET "
+	for i, item := range n.Updates {
+		if i != 0 {
+			sql += ", "
+		}
+		for j, id := range item.Path {
+			if j != 0 {
+				sql += "."
+			}
+			sql += r.ident(id)
+		}
+		sql += " = " + r.expr(item.Expr)
+	}
+	if n.Where != nil {
+		sql += " WHERE " + r.expr(n.Where.Expr)
+	}
+	return sql
+}
+
+func (r *pgRenderer) exprs(es []ast.Expr) string {
+	sqls := make([]string, 0, len(es))
+	for _, e := range es {
+		sqls = append(sqls, r.expr(e))
+	}
+	return strings.Join(sqls, ", ")
+}
+
+func (r *pgRenderer) expr(e ast.Expr) string {
+	switch e := e.(type) {
+	case *ast.Ident:
+		return r.ident(e)
+	case *ast.Path:
+		sqls := make([]string, 0, len(e.Idents))
+		for _, id := range e.Idents {
+			sqls = append(sqls, r.ident(id))
+		}
+		return strings.Join(sqls, ".")
+	case *ast.Param:
+		n, ok := r.index[e.Name]
+		if !ok {
+			r.params = append(r.params, e.Name)
+			n = len(r.params)
+			r.index[e.Name] = n
+		}
+		return "$" + strconv.Itoa(n)
+	case *ast.NullLiteral:
+		return "NULL"
+	case *ast.BoolLiteral:
+		if e.Value {
+			return "TRUE"
+		}
+		return "FALSE"
+	case *ast.IntLiteral:
+		return e.Value
+	case *ast.FloatLiteral:
+		return e.Value
+	case *ast.StringLiteral:
+		return pgString(e.Value)
+	case *ast.BytesLiteral:
+		return pgString(`\x`+hex.EncodeToString(e.Value)) + "::bytea"
+	case *ast.DateLiteral:
+		return pgString(e.Value.Value) + "::date"
+	case *ast.TimestampLiteral:
+		return pgString(e.Value.Value) + "::timestamptz"
+	case *ast.NumericLiteral:
+		return pgString(e.Value.Value) + "::numeric"
+	case *JSONLiteral:
+		return pgString(e.Value.Value) + "::jsonb"
+	case *ast.ArrayLiteral:
+		sql := "ARRAY[" + r.exprs(e.Values) + "]"
+		if len(e.Values) == 0 && e.Type != nil {
+			sql += "::" + r.typ(e.Type) + "[]"
+		}
+		return sql
+	case *ast.ParenExpr:
+		return "(" + r.expr(e.Expr) + ")"
+	case *ast.BinaryExpr:
+		prec := pgBinaryPrec(e.Op)
+		op := string(e.Op)
+		switch e.Op {
+		case ast.OpNotEqual:
+			op = "<>"
+		case ast.OpBitXor:
+			op = "#"
+		}
+		return r.operand(e.Left, prec, false) + " " + op + " " + r.operand(e.Right, prec, true)
+	case *ast.UnaryExpr:
+		if e.Op == ast.OpNot {
+			return "NOT " + r.operand(e.Expr, pgPrecNot, false)
+		}
+		return string(e.Op) + r.operand(e.Expr, pgPrecUnary, false)
+	case *ast.InExpr:
+		left := r.operand(e.Left, pgPrecCompare, false)
+		switch in := e.Right.(type) {
+		case *ast.UnnestInCondition:
+			if e.Not {
+				return left + " <> ALL(" + r.expr(in.Expr) + ")"
+			}
+			return left + " = ANY(" + r.expr(in.Expr) + ")"
+		case *ast.ValuesInCondition:
+			return left + pgNot(e.Not) + " IN (" + r.exprs(in.Exprs) + ")"
+		case *ast.SubQueryInCondition:
+			return left + pgNot(e.Not) + " IN (" + r.node(in.Query) + ")"
+		}
+		return r.unsupported(nodeName(e.Right))
+	case *ast.BetweenExpr:
+		return r.operand(e.Left, pgPrecCompare, false) + pgNot(e.Not) + " BETWEEN " +
+			r.operand(e.RightStart, pgPrecCompare, true) + " AND " + r.operand(e.RightEnd, pgPrecCompare, true)
+	case *ast.IsNullExpr:
+		return r.operand(e.Left, pgPrecCompare, false) + " IS" + pgNot(e.Not) + " NULL"
+	case *ast.IsBoolExpr:
+		b := "FALSE"
+		if e.Right {
+			b = "TRUE"
+		}
+		return r.operand(e.Left, pgPrecCompare, false) + " IS" + pgNot(e.Not) + " " + b
+	case *ast.CallExpr:
+		return r.call(e.Func.Name, e.Distinct, e.Args)
+	case *KeywordCallExpr:
+		return r.call(e.Name, e.Distinct, e.Args)
+	case *PathCallExpr:
+		return r.call(r.expr(e.Func), e.Distinct, e.Args)
+	case *ast.CountStarExpr:
+		return "COUNT(*)"
+	case *ast.CastExpr:
+		return "CAST(" + r.expr(e.Expr) + " AS " + r.typ(e.Type) + ")"
+	case *ast.ExtractExpr:
+		if e.AtTimeZone != nil {
+			return r.unsupported("EXTRACT AT TIME ZONE")
+		}
+		return "EXTRACT(" + e.Part.Name + " FROM " + r.expr(e.Expr) + ")"
+	case *ast.ScalarSubQuery:
+		return "(" + r.node(e.Query) + ")"
+	case *ast.ExistsSubQuery:
+		if e.Hint != nil {
+			return r.unsupported("subquery hints")
+		}
+		return "EXISTS(" + r.node(e.Query) + ")"
+	case *ast.ArraySubQuery:
+		return "ARRAY(" + r.node(e.Query) + ")"
+	case *SafeCastExpr:
+		return r.unsupported("SAFE_CAST")
+	case *IntervalExpr:
+		return r.unsupported("INTERVAL")
+	case *AnalyticExpr:
+		return r.unsupported(FeatureWindow)
+	}
+	return r.unsupported(nodeName(e))
+}
+
+func (r *pgRenderer) call(name string, distinct bool, args []ast.Arg) string {
+	sql := name + "("
+	if distinct {
+		sql += "DISTINCT "
+	}
+	for i, a := range args {
+		if i != 0 {
+			sql += ", "
+		}
+		arg, ok := a.(*ast.ExprArg)
+		if !ok {
+			return r.unsupported(nodeName(a))
+		}
+		sql += r.expr(arg.Expr)
+	}
+	return sql + ")"
+}
+
+// operand renders e as an operand of an operator of prec, enclosed in parentheses if it binds looser.
+// The right operand is also enclosed if it binds as tight, as operators are left-associative.
+func (r *pgRenderer) operand(e ast.Expr, prec int, right bool) string {
+	sql := r.expr(e)
+	p := pgExprPrec(e)
+	if p < prec || right && p == prec {
+		return "(" + sql + ")"
+	}
+	return sql
+}
+
+// pgTypes maps GoogleSQL types to PostgreSQL ones.
+var pgTypes = map[string]string{
+	"BOOL":      "bool",
+	"INT64":     "bigint",
+	"FLOAT32":   "float4",
+	"FLOAT64":   "float8",
+	"STRING":    "varchar",
+	"BYTES":     "bytea",
+	"DATE":      "date",
+	"TIMESTAMP": "timestamptz",
+	"NUMERIC":   "numeric",
+	"JSON":      "jsonb",
+}
+
+func (r *pgRenderer) typ(t ast.Type) string {
+	switch t := t.(type) {
+	case *ast.SimpleType:
+		if pt, ok := pgTypes[string(t.Name)]; ok {
+			return pt
+		}
+		return r.unsupported("type " + string(t.Name))
+	case *ast.ArrayType:
+		return r.typ(t.Item) + "[]"
+	}
+	return r.unsupported("type " + t.SQL())
+}
+
+var pgPlainIdentPattern = regexp.MustCompile(`^[a-z_][a-z0-9_]*$`)
+
+// pgReservedWords are reserved keywords of PostgreSQL which can't be used as identifiers without quoting.
+var pgReservedWords = map[string]bool{
+	"all": true, "analyse": true, "analyze": true, "and": true, "any": true, "array": true, "as": true,
+	"asc": true, "asymmetric": true, "both": true, "case": true, "cast": true, "check": true, "collate": true,
+	"column": true, "constraint": true, "create": true, "current_catalog": true, "current_date": true,
+	"current_role": true, "current_time": true, "current_timestamp": true, "current_user": true,
+	"default": true, "deferrable": true, "desc": true, "distinct": true, "do": true, "else": true,
+	"end": true, "except": true, "false": true, "fetch": true, "for": true, "foreign": true, "from": true,
+	"grant": true, "group": true, "having": true, "in": true, "initially": true, "intersect": true,
+	"into": true, "lateral": true, "leading": true, "limit": true, "localtime": true, "localtimestamp": true,
+	"not": true, "null": true, "offset": true, "on": true, "only": true, "or": true, "order": true,
+	"placing": true, "primary": true, "references": true, "returning": true, "select": true,
+	"session_user": true, "some": true, "symmetric": true, "table": true, "then": true, "to": true,
+	"trailing": true, "true": true, "union": true, "unique": true, "user": true, "using": true,
+	"variadic": true, "when": true, "where": true, "window": true, "with": true,
+}
+
+// ident quotes identifiers by double quotes unless they are lower case and not reserved,
+// as PostgreSQL folds unquoted identifiers to lower case.
+func (r *pgRenderer) ident(id *ast.Ident) string {
+	if pgPlainIdentPattern.MatchString(id.Name) && !pgReservedWords[id.Name] {
+		return id.Name
+	}
+	return `"` + strings.ReplaceAll(id.Name, `"`, `""`) + `"`
+}
+
+// nodeName returns the name of the type of the node for error messages.
+func nodeName(n interface{}) string {
+	return reflect.Indirect(reflect.ValueOf(n)).Type().Name()
+}
+
+func pgString(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+func pgNot(not bool) string {
+	if not {
+		return " NOT"
+	}
+	return ""
+}
+
+// Precedence of operators in PostgreSQL, from loose to tight.
+const (
+	pgPrecOr = iota + 1
+	pgPrecAnd
+	pgPrecNot
+	pgPrecCompare
+	pgPrecOther
+	pgPrecAdd
+	pgPrecMul
+	pgPrecUnary
+	pgPrecPrimary
+)
+
+func pgBinaryPrec(op ast.BinaryOp) int {
+	switch op {
+	case ast.OpOr:
+		return pgPrecOr
+	case ast.OpAnd:
+		return pgPrecAnd
+	case ast.OpEqual, ast.OpNotEqual, ast.OpLess, ast.OpGreater, ast.OpLessEqual, ast.OpGreaterEqual,
+		ast.OpLike, ast.OpNotLike:
+		return pgPrecCompare
+	case ast.OpAdd, ast.OpSub:
+		return pgPrecAdd
+	case ast.OpMul, ast.OpDiv:
+		return pgPrecMul
+	}
+	return pgPrecOther
+}
+
+func pgExprPrec(e ast.Expr) int {
+	switch e := e.(type) {
+	case *ast.BinaryExpr:
+		return pgBinaryPrec(e.Op)
+	case *ast.UnaryExpr:
+		if e.Op == ast.OpNot {
+			return pgPrecNot
+		}
+		return pgPrecUnary
+	case *ast.InExpr, *ast.BetweenExpr, *ast.IsNullExpr, *ast.IsBoolExpr:
+		return pgPrecCompare
+	}
+	return pgPrecPrimary
+}
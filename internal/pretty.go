@@ -0,0 +1,161 @@
+package internal
+
+import (
+	"strings"
+
+	"github.com/cloudspannerecosystem/memefish/ast"
+)
+
+const prettyIndent = "  "
+
+// Pretty renders node as multi-line SQL, with clauses on their own lines and list items indented.
+// Expressions are rendered in a line, and nodes other than statements are rendered as SQL does.
+func Pretty(node ast.Node) string {
+	switch n := node.(type) {
+	case *ast.QueryStatement:
+		if n.Hint != nil {
+			return n.Hint.SQL() + "\n" + Pretty(n.Query)
+		}
+		return Pretty(n.Query)
+	case *ast.Select:
+		return prettySelect(n)
+	case *ast.Insert:
+		return prettyInsert(n)
+	case *ast.Update:
+		lines := []string{"UPDATE " + n.TableName.SQL(), "SET"}
+		for i, item := range n.Updates {
+			line := prettyIndent + item.SQL()
+			if i != len(n.Updates)-1 {
+				line += ","
+			}
+			lines = append(lines, line)
+		}
+		return strings.Join(append(lines, prettyWhere(n.Where)...), "\n")
+	case *ast.Delete:
+		lines := []string{"DELETE FROM " + n.TableName.SQL()}
+		return strings.Join(append(lines, prettyWhere(n.Where)...), "\n")
+	case *InsertOrStmt:
+		return "INSERT " + n.Mode + " " + strings.TrimPrefix(Pretty(n.Insert), "INSERT ")
+	case *ThenReturnStmt:
+		items := make([]string, 0, len(n.Items))
+		for _, item := range n.Items {
+			items = append(items, item.SQL())
+		}
+		return Pretty(n.DML) + "\nTHEN RETURN " + strings.Join(items, ", ")
+	}
+	return node.SQL()
+}
+
+func prettySelect(s *ast.Select) string {
+	head := "SELECT"
+	if s.Distinct {
+		head += " DISTINCT"
+	}
+	if s.AsStruct {
+		head += " AS STRUCT"
+	}
+	lines := []string{head}
+	for i, item := range s.Results {
+		line := prettyIndent + item.SQL()
+		if i != len(s.Results)-1 {
+			line += ","
+		}
+		lines = append(lines, line)
+	}
+	if s.From != nil {
+		lines = append(lines, s.From.SQL())
+	}
+	lines = append(lines, prettyWhere(s.Where)...)
+	if s.GroupBy != nil {
+		lines = append(lines, s.GroupBy.SQL())
+	}
+	if s.Having != nil {
+		lines = append(lines, s.Having.SQL())
+	}
+	if s.OrderBy != nil {
+		lines = append(lines, s.OrderBy.SQL())
+	}
+	if s.Limit != nil {
+		lines = append(lines, s.Limit.SQL())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// prettyWhere renders WHERE clause with each of the top-level AND conditions on its own line.
+func prettyWhere(w *ast.Where) []string {
+	if w == nil {
+		return nil
+	}
+	conds := splitAnd(w.Expr)
+	lines := []string{"WHERE"}
+	for i, c := range conds {
+		sql := c.SQL()
+		if b, ok := c.(*ast.BinaryExpr); ok && b.Op == ast.OpOr {
+			sql = "(" + sql + ")"
+		}
+		if i == 0 {
+			lines = append(lines, prettyIndent+sql)
+		} else {
+			lines = append(lines, prettyIndent+"AND "+sql)
+		}
+	}
+	return lines
+}
+
+func splitAnd(e ast.Expr) []ast.Expr {
+	if b, ok := e.(*ast.BinaryExpr); ok && b.Op == ast.OpAnd {
+		return append(splitAnd(b.Left), splitAnd(b.Right)...)
+	}
+	return []ast.Expr{e}
+}
+
+// prettyInsert renders INSERT statement with VALUES rows on their own lines, aligning values of each column.
+func prettyInsert(n *ast.Insert) string {
+	cols := make([]string, 0, len(n.Columns))
+	for _, c := range n.Columns {
+		cols = append(cols, c.SQL())
+	}
+	head := "INSERT INTO " + n.TableName.SQL() + " (" + strings.Join(cols, ", ") + ")"
+	values, ok := n.Input.(*ast.ValuesInput)
+	if !ok {
+		if sub, ok := n.Input.(*ast.SubQueryInput); ok {
+			return head + "\n" + Pretty(sub.Query)
+		}
+		return n.SQL()
+	}
+	rows := make([][]string, 0, len(values.Rows))
+	var widths []int
+	for _, row := range values.Rows {
+		cells := make([]string, 0, len(row.Exprs))
+		for i, e := range row.Exprs {
+			cell := e.SQL()
+			cells = append(cells, cell)
+			if i >= len(widths) {
+				widths = append(widths, 0)
+			}
+			if len(cell) > widths[i] {
+				widths[i] = len(cell)
+			}
+		}
+		rows = append(rows, cells)
+	}
+	lines := []string{head, "VALUES"}
+	for i, cells := range rows {
+		var b strings.Builder
+		b.WriteString(prettyIndent + "(")
+		for j, cell := range cells {
+			if j == len(cells)-1 {
+				b.WriteString(cell)
+				break
+			}
+			b.WriteString(cell + ",")
+			b.WriteString(strings.Repeat(" ", widths[j]-len(cell)+1))
+		}
+		b.WriteString(")")
+		if i != len(rows)-1 {
+			b.WriteString(",")
+		}
+		lines = append(lines, b.String())
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,48 @@
+package internal
+
+import (
+	"regexp"
+
+	"github.com/cloudspannerecosystem/memefish/ast"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// protoTypeNamePattern matches fully-qualified names of proto messages and enums like `examples.music.Singer`.
+var protoTypeNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)+$`)
+
+// ProtoType creates the PROTO or ENUM type of the fully-qualified name, which memefish doesn't support.
+func ProtoType(name protoreflect.FullName) *ast.SimpleType {
+	return &ast.SimpleType{Name: ast.ScalarTypeName(name)}
+}
+
+// protoMessageExpr converts m into `CAST(b"..." AS full.Name)` with its serialized bytes.
+func protoMessageExpr(m proto.Message) (ast.Expr, error) {
+	r := m.ProtoReflect()
+	if !r.IsValid() {
+		return NullLit(), nil
+	}
+	b, err := proto.MarshalOptions{Deterministic: true}.Marshal(m)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "can't serialize %s", r.Descriptor().FullName())
+	}
+	return &ast.CastExpr{
+		Expr: BytesLit(b),
+		Type: ProtoType(r.Descriptor().FullName()),
+	}, nil
+}
+
+// ProtoEnumExpr converts e into `CAST("NAME" AS full.Name)`, or `CAST(number AS full.Name)` if byNumber is true
+// or the number has no name.
+func ProtoEnumExpr(e protoreflect.Enum, byNumber bool) ast.Expr {
+	d := e.Descriptor()
+	var expr ast.Expr = IntLit(int64(e.Number()))
+	if v := d.Values().ByNumber(e.Number()); v != nil && !byNumber {
+		expr = StringLit(string(v.Name()))
+	}
+	return &ast.CastExpr{
+		Expr: expr,
+		Type: ProtoType(d.FullName()),
+	}
+}
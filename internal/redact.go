@@ -0,0 +1,176 @@
+package internal
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/cloudspannerecosystem/memefish/ast"
+)
+
+// redacted creates a placeholder `?` replacing a literal.
+// It is an ast.IntLiteral, which is rendered verbatim, so that memefish can parenthesize it as a literal.
+func redacted() *ast.IntLiteral {
+	return &ast.IntLiteral{Value: "?"}
+}
+
+// Redact replaces literals in node with `?` in place.
+// If cols is not empty, only literals compared with or assigned to the columns are replaced.
+// NULL, hints and LIMIT counts are kept as they are.
+func Redact(node ast.Node, cols []string) {
+	if len(cols) == 0 {
+		redactValue(reflect.ValueOf(node))
+		return
+	}
+	isCol := func(expr ast.Expr) bool {
+		switch e := expr.(type) {
+		case *ast.Ident:
+			return containsFold(cols, e.Name)
+		case *ast.Path:
+			return containsFold(cols, e.Idents[len(e.Idents)-1].Name)
+		}
+		return false
+	}
+	Walk(node, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.BinaryExpr:
+			if isCol(n.Left) {
+				redactValue(reflect.ValueOf(&n.Right).Elem())
+			}
+			if isCol(n.Right) {
+				redactValue(reflect.ValueOf(&n.Left).Elem())
+			}
+		case *ast.InExpr:
+			if isCol(n.Left) {
+				redactValue(reflect.ValueOf(n.Right))
+			}
+		case *ast.BetweenExpr:
+			if isCol(n.Left) {
+				redactValue(reflect.ValueOf(&n.RightStart).Elem())
+				redactValue(reflect.ValueOf(&n.RightEnd).Elem())
+			}
+		case *ast.UpdateItem:
+			if containsFold(cols, n.Path[len(n.Path)-1].Name) {
+				redactValue(reflect.ValueOf(&n.Expr).Elem())
+			}
+		case *ast.Insert:
+			for i, col := range n.Columns {
+				if containsFold(cols, col.Name) {
+					redactInputColumn(n.Input, i)
+				}
+			}
+		}
+		return true
+	})
+}
+
+// redactInputColumn replaces literals of the i-th column in the rows of input.
+func redactInputColumn(input ast.InsertInput, i int) {
+	switch input := input.(type) {
+	case *ast.ValuesInput:
+		for _, row := range input.Rows {
+			if i < len(row.Exprs) {
+				redactValue(reflect.ValueOf(&row.Exprs[i].Expr).Elem())
+			}
+		}
+	case *ast.SubQueryInput:
+		redactQueryColumn(input.Query, i)
+	}
+}
+
+// redactQueryColumn replaces literals of the i-th result of query.
+// `SELECT * FROM (query)`, like rows guarded by an idempotency key, is followed into the inner query.
+func redactQueryColumn(query ast.QueryExpr, i int) {
+	switch q := query.(type) {
+	case *ast.Select:
+		if len(q.Results) == 1 && q.From != nil {
+			if _, ok := q.Results[0].(*ast.Star); ok {
+				if src, ok := q.From.Source.(*ast.SubQueryTableExpr); ok {
+					redactQueryColumn(src.Query, i)
+				}
+				return
+			}
+		}
+		if i >= len(q.Results) {
+			return
+		}
+		switch item := q.Results[i].(type) {
+		case *ast.ExprSelectItem:
+			redactValue(reflect.ValueOf(&item.Expr).Elem())
+		case *ast.Alias:
+			redactValue(reflect.ValueOf(&item.Expr).Elem())
+		}
+	case *ast.CompoundQuery:
+		for _, q := range q.Queries {
+			redactQueryColumn(q, i)
+		}
+	case *ast.SubQuery:
+		redactQueryColumn(q.Query, i)
+	}
+}
+
+func redactValue(v reflect.Value) {
+	replaceExprs(v, func(expr ast.Expr) ast.Expr {
+		if isLiteral(expr) {
+			return redacted()
+		}
+		return nil
+	})
+}
+
+// replaceExprs replaces expressions in v with the results of fn in place, unless fn returns nil.
+// Expressions replaced are not traversed further. Hints and LIMIT clauses are kept as they are.
+func replaceExprs(v reflect.Value, fn func(ast.Expr) ast.Expr) {
+	switch v.Kind() {
+	case reflect.Interface:
+		if v.IsNil() {
+			return
+		}
+		if expr, ok := v.Interface().(ast.Expr); ok && v.CanSet() {
+			if repl := fn(expr); repl != nil && reflect.TypeOf(repl).AssignableTo(v.Type()) {
+				v.Set(reflect.ValueOf(repl))
+				return
+			}
+		}
+		replaceExprs(v.Elem(), fn)
+	case reflect.Ptr:
+		if v.IsNil() {
+			return
+		}
+		switch v.Interface().(type) {
+		case *ast.Hint, *ast.Limit:
+			return
+		}
+		if v.Elem().Kind() == reflect.Struct {
+			replaceExprs(v.Elem(), fn)
+		}
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < v.NumField(); i++ {
+			if t.Field(i).IsExported() {
+				replaceExprs(v.Field(i), fn)
+			}
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			replaceExprs(v.Index(i), fn)
+		}
+	}
+}
+
+func isLiteral(v interface{}) bool {
+	switch v.(type) {
+	case *ast.StringLiteral, *ast.BytesLiteral, *ast.IntLiteral, *ast.FloatLiteral, *ast.BoolLiteral,
+		*ast.NumericLiteral, *ast.DateLiteral, *ast.TimestampLiteral, *JSONLiteral:
+		return true
+	}
+	return false
+}
+
+func containsFold(names []string, name string) bool {
+	for _, n := range names {
+		if strings.EqualFold(n, name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,42 @@
+package internal
+
+import (
+	"reflect"
+	"strconv"
+
+	"github.com/cloudspannerecosystem/memefish/ast"
+)
+
+// MaxSafeInt is the largest integer which can be represented exactly as a JavaScript number (2^53 - 1).
+const MaxSafeInt = 1<<53 - 1
+
+// IsSafeInt reports whether v can be represented exactly as a JavaScript number.
+func IsSafeInt(v int64) bool {
+	return -MaxSafeInt <= v && v <= MaxSafeInt
+}
+
+// Int64FromString creates `CAST(expr AS INT64)`, which reads an INT64 value from the STRING expr.
+func Int64FromString(expr ast.Expr) *ast.CastExpr {
+	return &ast.CastExpr{Expr: expr, Type: &ast.SimpleType{Name: ast.Int64TypeName}}
+}
+
+// CastLargeInts replaces integer literals in node beyond the JavaScript-safe range with `CAST("..." AS INT64)`,
+// and the parameters named in params with `CAST(@p AS INT64)`, in place.
+// Hints and LIMIT clauses are kept as they are.
+func CastLargeInts(node ast.Node, params map[string]bool) {
+	replaceExprs(reflect.ValueOf(node), func(expr ast.Expr) ast.Expr {
+		switch e := expr.(type) {
+		case *ast.IntLiteral:
+			v, err := strconv.ParseInt(e.Value, 0, 64)
+			if err != nil || IsSafeInt(v) {
+				return nil
+			}
+			return Int64FromString(StringLit(strconv.FormatInt(v, 10)))
+		case *ast.Param:
+			if params[e.Name] {
+				return Int64FromString(e)
+			}
+		}
+		return nil
+	})
+}
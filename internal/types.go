@@ -0,0 +1,53 @@
+package internal
+
+import (
+	"math/big"
+	"reflect"
+	"time"
+
+	"cloud.google.com/go/civil"
+	"cloud.google.com/go/spanner"
+	"github.com/cloudspannerecosystem/memefish/ast"
+)
+
+// Names of types memefish doesn't define.
+const (
+	jsonTypeName     ast.ScalarTypeName = "JSON"
+	intervalTypeName ast.ScalarTypeName = "INTERVAL"
+)
+
+var goTypes = map[reflect.Type]ast.ScalarTypeName{
+	reflect.TypeOf(""):                    ast.StringTypeName,
+	reflect.TypeOf(spanner.NullString{}):  ast.StringTypeName,
+	reflect.TypeOf(0):                     ast.Int64TypeName,
+	reflect.TypeOf(int64(0)):              ast.Int64TypeName,
+	reflect.TypeOf(spanner.NullInt64{}):   ast.Int64TypeName,
+	reflect.TypeOf(false):                 ast.BoolTypeName,
+	reflect.TypeOf(spanner.NullBool{}):    ast.BoolTypeName,
+	reflect.TypeOf(0.0):                   ast.Float64TypeName,
+	reflect.TypeOf(spanner.NullFloat64{}): ast.Float64TypeName,
+	reflect.TypeOf([]byte{}):              ast.BytesTypeName,
+	reflect.TypeOf(time.Time{}):           ast.TimestampTypeName,
+	reflect.TypeOf(spanner.NullTime{}):    ast.TimestampTypeName,
+	reflect.TypeOf(civil.Date{}):          ast.DateTypeName,
+	reflect.TypeOf(spanner.NullDate{}):    ast.DateTypeName,
+	reflect.TypeOf(spanner.NullNumeric{}): ast.NumericTypeName,
+	reflect.TypeOf(big.Rat{}):             ast.NumericTypeName,
+	reflect.TypeOf(spanner.NullJSON{}):    jsonTypeName,
+}
+
+// TypeOf returns the Spanner type of values of the Go type t, or nil if it is unknown.
+func TypeOf(t reflect.Type) ast.Type {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if name, ok := goTypes[t]; ok {
+		return &ast.SimpleType{Name: name}
+	}
+	if t.Kind() == reflect.Slice {
+		if elem := TypeOf(t.Elem()); elem != nil {
+			return &ast.ArrayType{Item: elem}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package internal
+
+import (
+	"reflect"
+
+	"github.com/cloudspannerecosystem/memefish/ast"
+)
+
+// Walk traverses node in depth-first order, calling fn for each ast.Node including node itself.
+// Child nodes are not visited when fn returns false.
+func Walk(node ast.Node, fn func(ast.Node) bool) {
+	walkValue(reflect.ValueOf(node), fn)
+}
+
+func walkValue(v reflect.Value, fn func(ast.Node) bool) {
+	switch v.Kind() {
+	case reflect.Interface:
+		if !v.IsNil() {
+			walkValue(v.Elem(), fn)
+		}
+	case reflect.Ptr:
+		if v.IsNil() {
+			return
+		}
+		if n, ok := v.Interface().(ast.Node); ok && !fn(n) {
+			return
+		}
+		if v.Elem().Kind() == reflect.Struct {
+			walkFields(v.Elem(), fn)
+		}
+	case reflect.Struct:
+		walkFields(v, fn)
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			walkValue(v.Index(i), fn)
+		}
+	}
+}
+
+func walkFields(v reflect.Value, fn func(ast.Node) bool) {
+	t := v.Type()
+	for i := 0; i < v.NumField(); i++ {
+		if t.Field(i).IsExported() {
+			walkValue(v.Field(i), fn)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package internal_test
+
+import (
+	"testing"
+
+	"github.com/cloudspannerecosystem/memefish/ast"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/abyssparanoia/memeduck/internal"
+)
+
+func TestWalk(t *testing.T) {
+	expr := &ast.BinaryExpr{
+		Op:   ast.OpAnd,
+		Left: &ast.BinaryExpr{Op: ast.OpEqual, Left: &ast.Ident{Name: "a"}, Right: &ast.Param{Name: "a"}},
+		Right: &internal.AnalyticExpr{
+			Func: &ast.CallExpr{Func: &ast.Ident{Name: "ROW_NUMBER"}},
+			Window: &internal.WindowSpec{
+				PartitionBy: []ast.Expr{&ast.Param{Name: "b"}},
+			},
+		},
+	}
+	var params []string
+	internal.Walk(expr, func(n ast.Node) bool {
+		if p, ok := n.(*ast.Param); ok {
+			params = append(params, p.Name)
+		}
+		return true
+	})
+	assert.Equal(t, []string{"a", "b"}, params)
+
+	var visited int
+	internal.Walk(expr, func(n ast.Node) bool {
+		visited++
+		return false
+	})
+	assert.Equal(t, 1, visited)
+}
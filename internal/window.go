@@ -0,0 +1,109 @@
+package internal
+
+import (
+	"strings"
+
+	"github.com/cloudspannerecosystem/memefish/ast"
+)
+
+// AnalyticExpr is an analytic function call `fn OVER (window)`.
+type AnalyticExpr struct {
+	exprNode
+	Func   ast.Expr
+	Window *WindowSpec
+}
+
+func (e *AnalyticExpr) SQL() string {
+	return e.Func.SQL() + " OVER " + e.Window.SQL()
+}
+
+// WindowSpec is a window specification.
+type WindowSpec struct {
+	Name        *ast.Ident // optional, the named window this specification is based on
+	PartitionBy []ast.Expr
+	OrderBy     *ast.OrderBy // optional
+	Frame       *WindowFrame // optional
+}
+
+func (w *WindowSpec) SQL() string {
+	if w.Name != nil && len(w.PartitionBy) == 0 && w.OrderBy == nil && w.Frame == nil {
+		return w.Name.SQL()
+	}
+	var clauses []string
+	if w.Name != nil {
+		clauses = append(clauses, w.Name.SQL())
+	}
+	if len(w.PartitionBy) > 0 {
+		exprs := make([]string, 0, len(w.PartitionBy))
+		for _, e := range w.PartitionBy {
+			exprs = append(exprs, e.SQL())
+		}
+		clauses = append(clauses, "PARTITION BY "+strings.Join(exprs, ", "))
+	}
+	if w.OrderBy != nil {
+		clauses = append(clauses, w.OrderBy.SQL())
+	}
+	if w.Frame != nil {
+		clauses = append(clauses, w.Frame.SQL())
+	}
+	return "(" + strings.Join(clauses, " ") + ")"
+}
+
+// WindowFrame is a window frame clause like `ROWS BETWEEN start AND end`.
+type WindowFrame struct {
+	Unit  string // ROWS or RANGE
+	Start *FrameBound
+	End   *FrameBound // optional
+}
+
+func (f *WindowFrame) SQL() string {
+	if f.End == nil {
+		return f.Unit + " " + f.Start.SQL()
+	}
+	return f.Unit + " BETWEEN " + f.Start.SQL() + " AND " + f.End.SQL()
+}
+
+// FrameBound is a boundary of window frames like `UNBOUNDED PRECEDING` or `3 FOLLOWING`.
+type FrameBound struct {
+	Offset ast.Expr // optional
+	Kind   string
+}
+
+func (b *FrameBound) SQL() string {
+	if b.Offset == nil {
+		return b.Kind
+	}
+	return b.Offset.SQL() + " " + b.Kind
+}
+
+// NamedWindow is a window definition in WINDOW clauses.
+type NamedWindow struct {
+	Name *ast.Ident
+	Spec *WindowSpec
+}
+
+func (w *NamedWindow) SQL() string {
+	return w.Name.SQL() + " AS " + w.Spec.SQL()
+}
+
+// Select is a SELECT query with a WINDOW clause, which ast.Select doesn't support.
+type Select struct {
+	ast.Select
+	Windows []*NamedWindow // len(Windows) > 0
+}
+
+func (s *Select) SQL() string {
+	sel := s.Select
+	sel.OrderBy, sel.Limit = nil, nil
+	sql := sel.SQL() + " WINDOW " + s.Windows[0].SQL()
+	for _, w := range s.Windows[1:] {
+		sql += ", " + w.SQL()
+	}
+	if s.OrderBy != nil {
+		sql += " " + s.OrderBy.SQL()
+	}
+	if s.Limit != nil {
+		sql += " " + s.Limit.SQL()
+	}
+	return sql
+}
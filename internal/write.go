@@ -0,0 +1,61 @@
+package internal
+
+import (
+	"bufio"
+	"io"
+
+	"github.com/cloudspannerecosystem/memefish/ast"
+)
+
+// WriteSQL writes SQL of node to w. Rows of INSERT statements are rendered and written one by one,
+// so that SQL of huge statements isn't built as a string at once.
+func WriteSQL(w io.Writer, node ast.Node) error {
+	bw := bufio.NewWriter(w)
+	writeSQL(bw, node)
+	return bw.Flush()
+}
+
+// writeSQL writes SQL of node to bw. Errors are kept in bw and reported by Flush.
+func writeSQL(bw *bufio.Writer, node ast.Node) {
+	switch n := node.(type) {
+	case *InsertOrStmt:
+		bw.WriteString("INSERT " + n.Mode + " ")
+		writeInsert(bw, n.Insert, "INTO ")
+	case *ast.Insert:
+		writeInsert(bw, n, "INSERT INTO ")
+	case *ThenReturnStmt:
+		writeSQL(bw, n.DML)
+		bw.WriteString(" THEN RETURN ")
+		for i, item := range n.Items {
+			if i != 0 {
+				bw.WriteString(", ")
+			}
+			bw.WriteString(item.SQL())
+		}
+	default:
+		bw.WriteString(node.SQL())
+	}
+}
+
+func writeInsert(bw *bufio.Writer, n *ast.Insert, head string) {
+	bw.WriteString(head + n.TableName.SQL() + " (")
+	for i, c := range n.Columns {
+		if i != 0 {
+			bw.WriteString(", ")
+		}
+		bw.WriteString(c.SQL())
+	}
+	bw.WriteString(") ")
+	values, ok := n.Input.(*ast.ValuesInput)
+	if !ok {
+		bw.WriteString(n.Input.SQL())
+		return
+	}
+	bw.WriteString("VALUES ")
+	for i, row := range values.Rows {
+		if i != 0 {
+			bw.WriteString(", ")
+		}
+		bw.WriteString(row.SQL())
+	}
+}
@@ -0,0 +1,58 @@
+package memeduck
+
+import (
+	"reflect"
+
+	"github.com/pkg/errors"
+)
+
+// KeyChunksParam is the name of the query parameter bound to the keys of each chunk of KeyChunks.
+const KeyChunksParam = "keys"
+
+// KeyChunk is a SELECT statement reading a chunk of keys by `key IN UNNEST(@keys)`.
+type KeyChunk struct {
+	Stmt *SelectStmt
+	// Keys is the slice of keys to be bound to the KeyChunksParam parameter.
+	Keys interface{}
+}
+
+// KeyChunks is a set of SELECT statements reading rows of a large list of keys in chunks.
+type KeyChunks struct {
+	Chunks []*KeyChunk
+	// MergeOrder is the ordering rows of each chunk are sorted in.
+	// Chunks of sorted keys can be concatenated in order, otherwise results can be merged by this ordering.
+	MergeOrder []*KeyPart
+}
+
+// SelectByKeys splits keys, a slice of primary keys of the table, into chunks of at most chunkSize keys,
+// and creates a SELECT statement of cols for each chunk, like
+// `SELECT cols FROM table WHERE key IN UNNEST(@keys) ORDER BY key`.
+// The table must have a single-column primary key.
+func SelectByKeys(table *Table, cols []string, keys interface{}, chunkSize int) (*KeyChunks, error) {
+	if len(table.PrimaryKey) != 1 {
+		return nil, errors.Errorf("table %s must have a single-column primary key, but has %d", table.Name, len(table.PrimaryKey))
+	}
+	if chunkSize <= 0 {
+		return nil, errors.Errorf("chunk size must be positive, but got %d", chunkSize)
+	}
+	keysV := reflect.ValueOf(keys)
+	if keysV.Kind() != reflect.Slice {
+		return nil, errors.Errorf("%T is not a slice of keys", keys)
+	}
+	order := table.PrimaryKeyOrder()
+	stmt := Select(table.Name, cols).
+		Where(In(Ident(order[0].Column), Unnest(Param(KeyChunksParam)))).
+		OrderByKeys(order)
+	chunks := &KeyChunks{MergeOrder: order}
+	for i := 0; i < keysV.Len(); i += chunkSize {
+		end := i + chunkSize
+		if end > keysV.Len() {
+			end = keysV.Len()
+		}
+		chunks.Chunks = append(chunks.Chunks, &KeyChunk{
+			Stmt: stmt,
+			Keys: keysV.Slice(i, end).Interface(),
+		})
+	}
+	return chunks, nil
+}
@@ -0,0 +1,31 @@
+package memeduck_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/abyssparanoia/memeduck"
+)
+
+func TestSelectByKeys(t *testing.T) {
+	chunks, err := memeduck.SelectByKeys(testSingerTable, []string{"SingerId", "Name"}, []int64{1, 2, 3, 4, 5}, 2)
+	assert.Nil(t, err)
+	assert.Len(t, chunks.Chunks, 3)
+	for _, c := range chunks.Chunks {
+		testSelect(t, c.Stmt, `SELECT SingerId, Name FROM Singer WHERE SingerId IN UNNEST(@keys) ORDER BY SingerId ASC`)
+	}
+	assert.Equal(t, []int64{1, 2}, chunks.Chunks[0].Keys)
+	assert.Equal(t, []int64{3, 4}, chunks.Chunks[1].Keys)
+	assert.Equal(t, []int64{5}, chunks.Chunks[2].Keys)
+	assert.Equal(t, []*memeduck.KeyPart{{Column: "SingerId", Dir: memeduck.ASC}}, chunks.MergeOrder)
+
+	chunks, err = memeduck.SelectByKeys(testSingerTable, []string{"SingerId"}, []int64{}, 2)
+	assert.Nil(t, err)
+	assert.Empty(t, chunks.Chunks)
+
+	_, err = memeduck.SelectByKeys(testAlbumTable, []string{"AlbumId"}, []int64{1}, 2)
+	assert.EqualError(t, err, "table Album must have a single-column primary key, but has 2")
+	_, err = memeduck.SelectByKeys(testSingerTable, []string{"SingerId"}, 1, 2)
+	assert.Error(t, err)
+}
@@ -0,0 +1,206 @@
+package memeduck
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"github.com/cloudspannerecosystem/memefish/ast"
+	"github.com/pkg/errors"
+
+	"github.com/abyssparanoia/memeduck/internal"
+)
+
+// SafeInt64 is an INT64 value that is rendered as `CAST("..." AS INT64)` when it is beyond the JavaScript-safe range.
+// Use it for values which may travel through JSON consumers that parse numbers as IEEE 754 doubles,
+// so they never lose precision silently. To apply it to all values of a statement, use LargeInt64AsString.
+type SafeInt64 int64
+
+func (v SafeInt64) ToASTExpr() (ast.Expr, error) {
+	if internal.IsSafeInt(int64(v)) {
+		return internal.IntLit(int64(v)), nil
+	}
+	return internal.Int64FromString(internal.StringLit(strconv.FormatInt(int64(v), 10))), nil
+}
+
+// largeInt64 returns v formatted in decimal if it is an INT64 value beyond the JavaScript-safe range.
+func largeInt64(v interface{}) (string, bool) {
+	var i int64
+	switch v := v.(type) {
+	case int:
+		i = int64(v)
+	case int64:
+		i = v
+	case SafeInt64:
+		i = int64(v)
+	case spanner.NullInt64:
+		if !v.Valid {
+			return "", false
+		}
+		i = v.Int64
+	default:
+		return "", false
+	}
+	if internal.IsSafeInt(i) {
+		return "", false
+	}
+	return strconv.FormatInt(i, 10), true
+}
+
+// largeInt64AsString converts the INT64 values of params beyond the JavaScript-safe range into strings if on is set.
+func largeInt64AsString(params map[string]interface{}, on bool) map[string]interface{} {
+	if !on {
+		return params
+	}
+	for name, v := range params {
+		if str, ok := largeInt64(v); ok {
+			params[name] = str
+		}
+	}
+	return params
+}
+
+// castLargeInt64s replaces large INT64 literals in stmt, the AST of the statement builder, with `CAST("..." AS INT64)`,
+// and wraps the parameters bound to large INT64 values, which Params binds as strings, in `CAST(@p AS INT64)`.
+func castLargeInt64s(builder interface{}, stmt ast.Node) error {
+	params, err := collectParams(builder)
+	if err != nil {
+		return err
+	}
+	large := make(map[string]bool)
+	for name, v := range params {
+		if _, ok := largeInt64(v); ok {
+			large[name] = true
+		}
+	}
+	internal.CastLargeInts(stmt, large)
+	return nil
+}
+
+// LargeInt64AsString makes INT64 values of the SELECT statement beyond the JavaScript-safe range rendered
+// as `CAST("..." AS INT64)` like SafeInt64, and bound as strings cast to INT64 by Params and Statement.
+func (s *SelectStmt) LargeInt64AsString() *SelectStmt {
+	var t = *s
+	t.int64AsString = true
+	return &t
+}
+
+// LargeInt64AsString makes large INT64 values of the INSERT statement STRING. See SelectStmt.LargeInt64AsString.
+func (s *InsertStmt) LargeInt64AsString() *InsertStmt {
+	var t = *s
+	t.int64AsString = true
+	return &t
+}
+
+// LargeInt64AsString makes large INT64 values of the UPDATE statement STRING. See SelectStmt.LargeInt64AsString.
+func (s *UpdateStmt) LargeInt64AsString() *UpdateStmt {
+	var t = *s
+	t.int64AsString = true
+	return &t
+}
+
+// LargeInt64AsString makes large INT64 values of the DELETE statement STRING. See SelectStmt.LargeInt64AsString.
+func (s *DeleteStmt) LargeInt64AsString() *DeleteStmt {
+	var t = *s
+	t.int64AsString = true
+	return &t
+}
+
+// JSONValue is a Go value rendered as a JSON literal.
+type JSONValue struct {
+	value interface{}
+}
+
+// JSON wraps v to be rendered as a JSON literal like `JSON "{\"a\":1}"`.
+// v is marshaled by encoding/json, so decoded JSON such as map[string]interface{} and []interface{} can be passed as is.
+// A nil v is converted into NULL.
+func JSON(v interface{}) *JSONValue {
+	return &JSONValue{value: v}
+}
+
+func (v *JSONValue) ToASTExpr() (ast.Expr, error) {
+	if v.value == nil {
+		return internal.NullLit(), nil
+	}
+	b, err := json.Marshal(v.value)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "can't convert %T into JSON", v.value)
+	}
+	return internal.JSONLit(string(b)), nil
+}
+
+// ArrayValue is an array literal with an explicit element type.
+type ArrayValue struct {
+	elemType string
+	values   []interface{}
+}
+
+// ArrayOf creates an array literal of elemType like `ARRAY<STRING>["a", "b"]`.
+// Unlike Go slices, the element type is always explicit, so it can be used for arrays of values like nil or Param.
+func ArrayOf(elemType string, values ...interface{}) *ArrayValue {
+	return &ArrayValue{elemType: elemType, values: values}
+}
+
+func (v *ArrayValue) ToASTExpr() (ast.Expr, error) {
+	typ, err := internal.ParseType(v.elemType)
+	if err != nil {
+		return nil, err
+	}
+	exprs := make([]ast.Expr, 0, len(v.values))
+	for i, value := range v.values {
+		expr, err := internal.ToExpr(value)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "at index %d", i)
+		}
+		exprs = append(exprs, expr)
+	}
+	lit := internal.ArrayLit(exprs)
+	lit.Type = typ
+	return lit, nil
+}
+
+// TimestampValue is a time.Time rendered as a timestamp literal with a fixed sub-second precision.
+type TimestampValue struct {
+	t      time.Time
+	digits int
+}
+
+// Timestamp wraps t to be rendered as a timestamp literal in UTC with exactly digits (0 to 9) sub-second digits,
+// e.g. `TIMESTAMP "2024-01-02T03:04:05.000000Z"` for 6 digits. Extra precision of t is truncated.
+// time.Time values are rendered with as many digits as needed to keep nanoseconds.
+func Timestamp(t time.Time, digits int) *TimestampValue {
+	return &TimestampValue{t: t, digits: digits}
+}
+
+func (v *TimestampValue) ToASTExpr() (ast.Expr, error) {
+	if v.digits < 0 || 9 < v.digits {
+		return nil, errors.Errorf("sub-second precision must be between 0 and 9, but got %d", v.digits)
+	}
+	return internal.TimeLitPrecision(v.t, v.digits), nil
+}
+
+// DefaultValue is the DEFAULT keyword in VALUES rows.
+type DefaultValue struct{}
+
+// Default creates a value rendered as DEFAULT in VALUES rows of INSERT statements,
+// which writes the default value of the column, e.g. its DEFAULT expression or the next value of its sequence.
+func Default() *DefaultValue {
+	return &DefaultValue{}
+}
+
+func (*DefaultValue) ToASTExpr() (ast.Expr, error) {
+	return nil, errors.New("DEFAULT can only be used in VALUES rows")
+}
+
+// toDefaultExpr converts v into an item of VALUES rows, which can be DEFAULT.
+func toDefaultExpr(v interface{}) (*ast.DefaultExpr, error) {
+	if _, ok := v.(*DefaultValue); ok {
+		return &ast.DefaultExpr{Default: true}, nil
+	}
+	expr, err := internal.ToExpr(v)
+	if err != nil {
+		return nil, err
+	}
+	return &ast.DefaultExpr{Expr: expr}, nil
+}
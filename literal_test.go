@@ -0,0 +1,186 @@
+package memeduck_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"math"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/abyssparanoia/memeduck"
+)
+
+func TestSafeInt64(t *testing.T) {
+	testExpr(t, memeduck.SafeInt64(0), `0`)
+	testExpr(t, memeduck.SafeInt64(9007199254740991), `9007199254740991`)
+	testExpr(t, memeduck.SafeInt64(-9007199254740991), `-9007199254740991`)
+	testExpr(t, memeduck.SafeInt64(9007199254740992), `CAST("9007199254740992" AS INT64)`)
+	testExpr(t, memeduck.SafeInt64(-9007199254740992), `CAST("-9007199254740992" AS INT64)`)
+	testExpr(t, memeduck.SafeInt64(math.MaxInt64), `CAST("9223372036854775807" AS INT64)`)
+	testWhere(t, memeduck.Eq(memeduck.Ident("id"), memeduck.SafeInt64(math.MinInt64)), `id = CAST("-9223372036854775808" AS INT64)`)
+}
+
+func TestLargeInt64AsString(t *testing.T) {
+	testSelect(t,
+		memeduck.Select("hoge", []string{"id"}).
+			Where(memeduck.Or(memeduck.Eq(memeduck.Ident("id"), 1), memeduck.Eq(memeduck.Ident("id"), int64(math.MaxInt64)))).
+			Limit(10).
+			LargeInt64AsString(),
+		`SELECT id FROM hoge WHERE id = 1 OR id = CAST("9223372036854775807" AS INT64) LIMIT 10`,
+	)
+	f := memeduck.NewFactory().LargeInt64AsString()
+	testInsert(t,
+		f.Insert("hoge", []string{"id", "n"}).Values([][]interface{}{{int64(9007199254740992), 9007199254740991}}),
+		`INSERT INTO hoge (id, n) VALUES (CAST("9007199254740992" AS INT64), 9007199254740991)`,
+	)
+	testUpdate(t,
+		f.Update("hoge").Set(memeduck.Ident("n"), -9007199254740992).Where(memeduck.Eq(memeduck.Ident("id"), 1)),
+		`UPDATE hoge SET n = CAST("-9007199254740992" AS INT64) WHERE id = 1`,
+	)
+	stmt, err := f.Delete("hoge").
+		Where(
+			memeduck.Eq(memeduck.Ident("id"), memeduck.ParamValue("id", int64(math.MaxInt64))),
+			memeduck.Eq(memeduck.Ident("n"), memeduck.ParamValue("n", 1)),
+			memeduck.Eq(memeduck.Ident("m"), memeduck.ParamValue("m", spanner.NullInt64{Int64: math.MinInt64, Valid: true})),
+		).
+		Statement()
+	assert.Nil(t, err)
+	assert.Equal(t, `DELETE FROM hoge WHERE id = CAST(@id AS INT64) AND n = @n AND m = CAST(@m AS INT64)`, stmt.SQL)
+	assert.Equal(t, map[string]interface{}{"id": "9223372036854775807", "n": 1, "m": "-9223372036854775808"}, stmt.Params)
+
+	testSelect(t,
+		memeduck.Select("hoge", []string{"id"}).Where(memeduck.Eq(memeduck.Ident("id"), int64(math.MaxInt64))),
+		`SELECT id FROM hoge WHERE id = 9223372036854775807`,
+	)
+
+	schema, err := memeduck.SchemaFromDDL(`CREATE TABLE hoge (id INT64 NOT NULL, n INT64, m INT64) PRIMARY KEY (id)`)
+	assert.Nil(t, err)
+	assert.Nil(t, memeduck.Select("hoge", []string{"id"}).
+		Where(memeduck.Eq(memeduck.Ident("n"), memeduck.SafeInt64(math.MinInt64))).
+		Validate(schema))
+	assert.Nil(t, f.Insert("hoge", []string{"id", "n"}).Values([][]interface{}{{int64(math.MaxInt64), 1}}).Validate(schema))
+	assert.Nil(t, f.Update("hoge").Set(memeduck.Ident("n"), -9007199254740992).Where(memeduck.Eq(memeduck.Ident("id"), 1)).Validate(schema))
+	assert.Nil(t, f.Delete("hoge").
+		Where(memeduck.Eq(memeduck.Ident("id"), memeduck.ParamValue("id", int64(math.MaxInt64)))).
+		Validate(schema))
+}
+
+func TestJSON(t *testing.T) {
+	testExpr(t, memeduck.JSON(map[string]interface{}{"b": 1, "a": []interface{}{"x", nil}}), `JSON "{\"a\":[\"x\",null],\"b\":1}"`)
+	testExpr(t, memeduck.JSON([]interface{}{1, "it's"}), `JSON "[1,\"it\'s\"]"`)
+	testExpr(t, memeduck.JSON(nil), `NULL`)
+	_, err := memeduck.JSON(map[string]interface{}{"a": func() {}}).ToASTExpr()
+	assert.Error(t, err)
+	testUpdate(t,
+		memeduck.Update("hoge").
+			Set(memeduck.Ident("config"), memeduck.JSON(map[string]interface{}{"enabled": true})).
+			Where(memeduck.Eq(memeduck.Ident("id"), 1)),
+		`UPDATE hoge SET config = JSON "{\"enabled\":true}" WHERE id = 1`,
+	)
+}
+
+func TestArrayOf(t *testing.T) {
+	testExpr(t, memeduck.ArrayOf("STRING"), `ARRAY<STRING>[]`)
+	testExpr(t, memeduck.ArrayOf("INT64", 1, nil, memeduck.Param("n")), `ARRAY<INT64>[1, NULL, @n]`)
+	testExpr(t, memeduck.ArrayOf("STRUCT<a INT64>"), `ARRAY<STRUCT<a INT64>>[]`)
+	_, err := memeduck.ArrayOf("NOT A TYPE").ToASTExpr()
+	assert.Error(t, err)
+}
+
+func TestEmptySliceHasType(t *testing.T) {
+	testWhere(t, memeduck.In(memeduck.Ident("a"), memeduck.Unnest([]string{})), `a IN UNNEST(ARRAY<STRING>[])`)
+	testWhere(t, memeduck.In(memeduck.Ident("a"), memeduck.Unnest([]*int64{})), `a IN UNNEST(ARRAY<INT64>[])`)
+	testWhere(t, memeduck.In(memeduck.Ident("a"), memeduck.Unnest([]interface{}{})), `a IN UNNEST(ARRAY[])`)
+}
+
+func TestTimestamp(t *testing.T) {
+	v := time.Date(2024, 1, 2, 12, 4, 5, 123456789, time.FixedZone("JST", 9*60*60))
+	testExpr(t, memeduck.Timestamp(v, 6), `TIMESTAMP "2024-01-02T03:04:05.123456Z"`)
+	testExpr(t, memeduck.Timestamp(v, 0), `TIMESTAMP "2024-01-02T03:04:05Z"`)
+	testExpr(t, memeduck.Timestamp(v, 9), `TIMESTAMP "2024-01-02T03:04:05.123456789Z"`)
+	testExpr(t, memeduck.Timestamp(v.Truncate(time.Second), 6), `TIMESTAMP "2024-01-02T03:04:05.000000Z"`)
+	testWhere(t, memeduck.Eq(memeduck.Ident("t"), v), `t = TIMESTAMP "2024-01-02T03:04:05.123456789Z"`)
+	_, err := memeduck.Timestamp(v, 10).ToASTExpr()
+	assert.Error(t, err)
+}
+
+type testJSONMarshaler struct {
+	Name string
+}
+
+func (m *testJSONMarshaler) MarshalJSON() ([]byte, error) {
+	return []byte(`{"name":"` + m.Name + `"}`), nil
+}
+
+func TestJSONColumn(t *testing.T) {
+	testInsert(t,
+		memeduck.Insert("hoge", []string{"a", "b", "c", "d"}).Values([][]interface{}{
+			{
+				spanner.NullJSON{Value: map[string]int{"x": 1}, Valid: true},
+				spanner.NullJSON{},
+				&testJSONMarshaler{Name: "foo"},
+				(*testJSONMarshaler)(nil),
+			},
+		}),
+		`INSERT INTO hoge (a, b, c, d) VALUES (JSON "{\"x\":1}", NULL, JSON "{\"name\":\"foo\"}", NULL)`,
+	)
+	testWhere(t,
+		memeduck.Eq(memeduck.JSONValueAt(memeduck.Ident("attrs"), "$.color"), "red"),
+		`JSON_VALUE(attrs, "$.color") = "red"`,
+	)
+	testExpr(t, memeduck.JSONQuery(memeduck.Ident("attrs"), "$.sizes"), `JSON_QUERY(attrs, "$.sizes")`)
+	testExpr(t, memeduck.ArrayOf("JSON"), `ARRAY<JSON>[]`)
+}
+
+func TestBytes(t *testing.T) {
+	testWhere(t, memeduck.Eq(memeduck.Ident("b"), []byte("a\"'\\\n\xff")), `b = B"a\"\'\\\n\xFF"`)
+	testUpdate(t,
+		memeduck.Update("hoge").Set(memeduck.Ident("b"), []byte{0, 1}).Where(memeduck.Bool(true)),
+		`UPDATE hoge SET b = B"\x00\x01" WHERE TRUE`,
+	)
+	testExpr(t, memeduck.FromBase64("AAE="), `FROM_BASE64("AAE=")`)
+}
+
+type testValuerStatus int
+
+func (s testValuerStatus) Value() (driver.Value, error) {
+	switch s {
+	case 1:
+		return "ACTIVE", nil
+	case 2:
+		return "DELETED", nil
+	}
+	return nil, errors.Errorf("unknown status %d", int(s))
+}
+
+func TestDriverValuer(t *testing.T) {
+	testWhere(t, memeduck.Eq(memeduck.Ident("name"), sql.NullString{String: "foo", Valid: true}), `name = "foo"`)
+	testWhere(t, memeduck.Eq(memeduck.Ident("name"), sql.NullString{}), `name = NULL`)
+	testWhere(t, memeduck.Eq(memeduck.Ident("age"), sql.NullInt64{Int64: 20, Valid: true}), `age = 20`)
+	testWhere(t, memeduck.Eq(memeduck.Ident("age"), (*sql.NullInt64)(nil)), `age = NULL`)
+	testWhere(t, memeduck.Eq(memeduck.Ident("status"), testValuerStatus(1)), `status = "ACTIVE"`)
+	testWhere(t, memeduck.In(memeduck.Ident("status"), memeduck.Unnest([]testValuerStatus{1, 2})), `status IN UNNEST(ARRAY["ACTIVE", "DELETED"])`)
+	testInsert(t,
+		memeduck.Insert("hoge", []string{"a", "b"}).Values([][]interface{}{{sql.NullBool{Bool: true, Valid: true}, testValuerStatus(2)}}),
+		`INSERT INTO hoge (a, b) VALUES (TRUE, "DELETED")`,
+	)
+	_, err := memeduck.Eq(memeduck.Ident("status"), testValuerStatus(3)).ToASTWhere()
+	assert.EqualError(t, err, "can't get value of memeduck_test.testValuerStatus: unknown status 3")
+}
+
+func TestDefault(t *testing.T) {
+	testInsert(t,
+		memeduck.Insert("hoge", []string{"id", "name"}).Values([][]interface{}{{memeduck.Default(), "a"}}),
+		`INSERT INTO hoge (id, name) VALUES (DEFAULT, "a")`,
+	)
+	testInsert(t,
+		memeduck.Insert("hoge", []string{"id", "name"}).Values([]map[string]interface{}{{"id": memeduck.Default(), "name": "b"}}),
+		`INSERT INTO hoge (id, name) VALUES (DEFAULT, "b")`,
+	)
+	_, err := memeduck.Eq(memeduck.Ident("id"), memeduck.Default()).ToASTWhere()
+	assert.EqualError(t, err, "DEFAULT can only be used in VALUES rows")
+}
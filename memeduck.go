@@ -2,6 +2,7 @@ package memeduck
 
 import (
 	"reflect"
+	"sort"
 	"strings"
 
 	"github.com/cloudspannerecosystem/memefish/ast"
@@ -20,19 +21,50 @@ type SelectStmt struct {
 	limit      *int
 	offset     *int
 	asStruct   bool
-	subQueries []SubQuery
+	items      []SelectItem
+	windows    []*namedWindow
+	unnest     *unnestSource
+	withOffset bool
+	offsetAs   string
+	hints      []*hint
+	replica    bool
+	dialect    Dialect
+	nullsAsc   NullsOrder
+	nullsDesc  NullsOrder
+	// int64AsString makes large INT64 values STRING. See LargeInt64AsString.
+	int64AsString bool
+}
+
+type namedWindow struct {
+	name string
+	spec *WindowSpec
+}
+
+// SelectItem is an expression that appears in the result columns of SELECT statements.
+type SelectItem interface {
+	ToAST() (ast.SelectItem, error)
 }
 
 type ordering struct {
-	col string
-	dir Direction
+	col     string
+	dir     Direction
+	collate string
+	nulls   NullsOrder
 }
 
 func (o *ordering) toASTOrderByItem() *ast.OrderByItem {
-	return &ast.OrderByItem{
-		Expr: &ast.Ident{Name: o.col},
+	item := &ast.OrderByItem{
+		Expr: internal.Ident(o.col),
 		Dir:  ast.Direction(o.dir),
 	}
+	if o.collate != "" {
+		item.Collate = &ast.Collate{Value: internal.StringLit(o.collate)}
+	}
+	if o.nulls != NullsDefault {
+		// memefish has no field for NULLS FIRST/LAST, but it renders Dir as is.
+		item.Dir = ast.Direction(strings.TrimSpace(string(o.dir) + " " + string(o.nulls)))
+	}
+	return item
 }
 
 // Direction is an ordering direction used by ORDER BY clause.
@@ -51,22 +83,65 @@ func Select(table string, cols []string) *SelectStmt {
 	}
 }
 
+// SelectFromUnnest creates a new SelectStmt reading rows from `UNNEST(arr) AS as` instead of a table.
+// arr is typically an array parameter or an ARRAY column, e.g. `SELECT id FROM UNNEST(@ids) AS id`.
+// as can be empty to omit the alias.
+func SelectFromUnnest(arr interface{}, as string, cols []string) *SelectStmt {
+	return &SelectStmt{
+		unnest: &unnestSource{expr: arr, as: as},
+		cols:   cols,
+	}
+}
+
+// WithOffset adds a `WITH OFFSET AS as` clause to the UNNEST source created by SelectFromUnnest.
+// as can be empty to omit the alias.
+func (s *SelectStmt) WithOffset(as string) *SelectStmt {
+	var t = *s
+	t.withOffset = true
+	t.offsetAs = as
+	return &t
+}
+
+// AsStruct makes the SELECT statement produce a STRUCT value per row, i.e. `SELECT AS STRUCT ...`.
+// Spanner requires it for subqueries in ARRAY() selecting more than one column.
 func (s *SelectStmt) AsStruct() *SelectStmt {
 	var t = *s
 	t.asStruct = true
 	return &t
 }
 
+// SubQuery appends given subqueries to the result columns of the SELECT statement.
 func (s *SelectStmt) SubQuery(queries ...SubQuery) *SelectStmt {
 	var t = *s
-	t.subQueries = append(t.subQueries, queries...)
+	t.items = append([]SelectItem(nil), t.items...)
+	for _, q := range queries {
+		t.items = append(t.items, q)
+	}
+	return &t
+}
+
+// Items appends given expressions to the result columns of the SELECT statement.
+func (s *SelectStmt) Items(items ...SelectItem) *SelectStmt {
+	var t = *s
+	t.items = append(append([]SelectItem(nil), t.items...), items...)
+	return &t
+}
+
+// Window appends a named window definition to its WINDOW clause.
+// Analytic function calls can refer the window by NamedWindow(name).
+func (s *SelectStmt) Window(name string, spec *WindowSpec) *SelectStmt {
+	var t = *s
+	t.windows = append(append([]*namedWindow(nil), t.windows...), &namedWindow{
+		name: name,
+		spec: spec,
+	})
 	return &t
 }
 
 // Where appends given codintional expressions to the SELECT statement.
 func (s *SelectStmt) Where(conds ...WhereCond) *SelectStmt {
 	var t = *s
-	t.conds = append(t.conds, conds...)
+	t.conds = append(append([]WhereCond(nil), t.conds...), conds...)
 	return &t
 }
 
@@ -80,13 +155,38 @@ func (s *SelectStmt) ForceIndex(idx string) *SelectStmt {
 // OrderBy appends a column to its ORDER BY clause.
 func (s *SelectStmt) OrderBy(col string, dir Direction) *SelectStmt {
 	var t = *s
-	t.ords = append(t.ords, &ordering{
+	t.ords = append(append([]*ordering(nil), t.ords...), &ordering{
 		col: col,
 		dir: dir,
 	})
 	return &t
 }
 
+// OrderByCollate appends a column to its ORDER BY clause, which is sorted under the collation specification
+// like "und:ci", i.e. `ORDER BY col COLLATE spec dir`.
+func (s *SelectStmt) OrderByCollate(col string, spec string, dir Direction) *SelectStmt {
+	var t = *s
+	t.ords = append(append([]*ordering(nil), t.ords...), &ordering{
+		col:     col,
+		dir:     dir,
+		collate: spec,
+	})
+	return &t
+}
+
+// OrderByKeys appends the key parts to its ORDER BY clause in order, e.g. Table.PrimaryKeyOrder().
+func (s *SelectStmt) OrderByKeys(keys []*KeyPart) *SelectStmt {
+	var t = *s
+	t.ords = append([]*ordering(nil), t.ords...)
+	for _, k := range keys {
+		t.ords = append(t.ords, &ordering{
+			col: k.Column,
+			dir: k.Dir,
+		})
+	}
+	return &t
+}
+
 // Limit adds a LIMIT clause to the SELECT statement.
 // It replaces existing LIMIT clauses.
 func (s *SelectStmt) Limit(limit int) *SelectStmt {
@@ -104,58 +204,127 @@ func (s *SelectStmt) LimitOffset(limit, offset int) *SelectStmt {
 	return &t
 }
 
+// WithColumns returns a copy of the SELECT statement with a different projection.
+// Its FROM, WHERE, ORDER BY and LIMIT clauses are kept as is, while result columns added by Items or SubQuery are dropped.
+func (s *SelectStmt) WithColumns(cols []string) *SelectStmt {
+	var t = *s
+	t.cols = append([]string(nil), cols...)
+	t.items = nil
+	return &t
+}
+
+// ReplicaEligible marks the SELECT statement as eligible to be served by read-only replicas.
+// It doesn't change the SQL; the execution layer can check IsReplicaEligible to set directed read options.
+func (s *SelectStmt) ReplicaEligible() *SelectStmt {
+	var t = *s
+	t.replica = true
+	return &t
+}
+
+// IsReplicaEligible reports whether the SELECT statement is marked by ReplicaEligible.
+func (s *SelectStmt) IsReplicaEligible() bool {
+	return s.replica
+}
+
+// WhereConds returns the conditional expressions of the SELECT statement.
+// They can be passed to Where of another statement to share the same filter.
+func (s *SelectStmt) WhereConds() []WhereCond {
+	return append([]WhereCond(nil), s.conds...)
+}
+
+// WithTotal returns a pair of queries: the SELECT statement itself as a page query,
+// and a query counting all rows that match the same FROM and WHERE clauses regardless of ORDER BY, LIMIT and OFFSET.
+// The count query keeps the source, the hints and the dialect of the statement.
+func (s *SelectStmt) WithTotal() (page *SelectStmt, total *SelectStmt) {
+	var p = *s
+	var t = *s
+	t.cols = []string{"COUNT(*)"}
+	t.items = nil
+	t.ords = nil
+	t.limit = nil
+	t.offset = nil
+	return &p, &t
+}
+
+// renderSQL renders the statement, verifying it under `memeduckdebug` build tag.
+func renderSQL(stmt ast.Node) (string, error) {
+	sql := stmt.SQL()
+	if err := internal.Verify(stmt, sql); err != nil {
+		return "", err
+	}
+	return sql, nil
+}
+
 func (s *SelectStmt) SQL() (string, error) {
-	stmt, err := s.toAST()
+	stmt, err := s.toASTStatement()
 	if err != nil {
 		return "", err
 	}
-	return stmt.SQL(), nil
+	sql, _, err := s.dialect.render(stmt)
+	return sql, err
+}
+
+// toASTStatement converts the SELECT statement into the AST of the whole statement including hints.
+func (s *SelectStmt) toASTStatement() (ast.Node, error) {
+	stmt, err := s.toAST()
+	if err != nil {
+		return nil, err
+	}
+	if s.int64AsString {
+		if err := castLargeInt64s(s, stmt); err != nil {
+			return nil, err
+		}
+	}
+	if len(s.hints) == 0 {
+		return stmt, nil
+	}
+	h, err := hintsToAST(s.hints)
+	if err != nil {
+		return nil, err
+	}
+	return &ast.QueryStatement{Hint: h, Query: stmt}, nil
+}
+
+// ToASTExpr converts the SELECT statement into a scalar subquery expression `(SELECT ...)`,
+// so that it can be used anywhere an expression is accepted, such as in Eq or UpdateStmt.Set.
+func (s *SelectStmt) ToASTExpr() (ast.Expr, error) {
+	stmt, err := s.toAST()
+	if err != nil {
+		return nil, err
+	}
+	return &ast.ScalarSubQuery{
+		Query: stmt,
+	}, nil
 }
 
 func isCountStar(s string) bool {
 	return strings.ToLower(s) == "count(*)"
 }
 
-func (s *SelectStmt) toAST() (*ast.Select, error) {
+func (s *SelectStmt) toAST() (ast.QueryExpr, error) {
 	var err error
+	if err := s.checkDialect(); err != nil {
+		return nil, err
+	}
+	var errs errorList
 	var where *ast.Where = nil
 	if len(s.conds) > 0 {
 		where, err = And(s.conds...).ToASTWhere()
-		if err != nil {
-			return nil, err
-		}
+		errs.add(err)
 	}
 
-	if len(s.cols) <= 0 {
-		return nil, errors.New("no columns specified")
-	}
-	items := make([]ast.SelectItem, 0, len(s.cols))
-	for _, col := range s.cols {
-		var expr ast.Expr
-		if isCountStar(col) {
-			expr = &ast.CountStarExpr{}
-		} else {
-			expr = &ast.Ident{Name: col}
-		}
-		items = append(items, &ast.ExprSelectItem{
-			Expr: expr,
-		})
-	}
-	if len(s.subQueries) > 0 {
-		for _, q := range s.subQueries {
-			item, err := q.ToAST()
-			if err != nil {
-				return nil, err
-			}
-			items = append(items, item)
-		}
-	}
+	items, err := s.toASTResults()
+	errs.add(err)
 
 	var orderBy *ast.OrderBy = nil
 	if len(s.ords) > 0 {
 		items := make([]*ast.OrderByItem, 0, len(s.ords))
 		for _, o := range s.ords {
-			items = append(items, o.toASTOrderByItem())
+			if err := internal.CheckIdent(o.col); err != nil {
+				errs.add(err)
+				continue
+			}
+			items = append(items, s.withNullsDefault(o).toASTOrderByItem())
 		}
 		orderBy = &ast.OrderBy{
 			Items: items,
@@ -173,8 +342,73 @@ func (s *SelectStmt) toAST() (*ast.Select, error) {
 			}
 		}
 	}
+	fromSource, err := s.toASTFromSource()
+	errs.add(err)
+	if err := errs.err(); err != nil {
+		return nil, err
+	}
+
+	sel := &ast.Select{
+		From: &ast.From{
+			Source: fromSource,
+		},
+		AsStruct: s.asStruct,
+		Results:  items,
+		Where:    where,
+		OrderBy:  orderBy,
+		Limit:    limit,
+	}
+	if len(s.windows) <= 0 {
+		return sel, nil
+	}
+	windows := make([]*internal.NamedWindow, 0, len(s.windows))
+	names := make(map[string]bool, len(s.windows))
+	for _, w := range s.windows {
+		if names[w.name] {
+			return nil, errors.Errorf("window %s is defined more than once", w.name)
+		}
+		names[w.name] = true
+		spec, err := w.spec.toAST()
+		if err != nil {
+			return nil, err
+		}
+		windows = append(windows, &internal.NamedWindow{
+			Name: &ast.Ident{Name: w.name},
+			Spec: spec,
+		})
+	}
+	return &internal.Select{
+		Select:  *sel,
+		Windows: windows,
+	}, nil
+}
+
+func (s *SelectStmt) toASTFromSource() (ast.TableExpr, error) {
+	if s.unnest != nil {
+		if len(s.forceIndex) > 0 {
+			return nil, errors.New("FORCE_INDEX can't be used with UNNEST")
+		}
+		unnest, err := s.unnest.toAST()
+		if err != nil {
+			return nil, err
+		}
+		if s.withOffset {
+			unnest.WithOffset = &ast.WithOffset{}
+			if s.offsetAs != "" {
+				unnest.WithOffset.As = &ast.AsAlias{Alias: &ast.Ident{Name: s.offsetAs}}
+			}
+		}
+		return unnest, nil
+	}
+	if s.withOffset {
+		return nil, errors.New("WITH OFFSET can only be used with UNNEST")
+	}
+	table, err := toASTIdent(s.table)
+	if err != nil {
+		return nil, err
+	}
 	fromSource := &ast.TableName{
-		Table: &ast.Ident{Name: s.table},
+		Table: table,
 	}
 	if len(s.forceIndex) > 0 {
 		hint := &ast.Hint{
@@ -191,24 +425,141 @@ func (s *SelectStmt) toAST() (*ast.Select, error) {
 		}
 		fromSource.Hint = hint
 	}
+	return fromSource, nil
+}
 
-	return &ast.Select{
-		From: &ast.From{
-			Source: fromSource,
-		},
-		AsStruct: s.asStruct,
-		Results:  items,
-		Where:    where,
-		OrderBy:  orderBy,
-		Limit:    limit,
-	}, nil
+func (s *SelectStmt) toASTResults() ([]ast.SelectItem, error) {
+	if len(s.cols) <= 0 && len(s.items) <= 0 {
+		return nil, ErrNoColumns
+	}
+	var errs errorList
+	items := make([]ast.SelectItem, 0, len(s.cols)+len(s.items))
+	for _, col := range s.cols {
+		var expr ast.Expr
+		if isCountStar(col) {
+			expr = &ast.CountStarExpr{}
+		} else {
+			id, err := toASTIdent(col)
+			if err != nil {
+				errs.add(err)
+				continue
+			}
+			expr = id
+		}
+		items = append(items, &ast.ExprSelectItem{
+			Expr: expr,
+		})
+	}
+	for _, i := range s.items {
+		item, err := i.ToAST()
+		if err != nil {
+			errs.add(err)
+			continue
+		}
+		items = append(items, item)
+	}
+	if err := errs.err(); err != nil {
+		return nil, err
+	}
+	if err := s.validateNames(items); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// validateNames checks names in the SELECT statement as Spanner resolves them:
+// explicit aliases must not collide with other result columns, and ORDER BY must refer to an unambiguous name.
+// ORDER BY on a table may also refer to columns of the table which are not selected, so only UNNEST sources,
+// whose columns are known, are checked for unknown names.
+func (s *SelectStmt) validateNames(items []ast.SelectItem) error {
+	outputs := make(map[string]int, len(items))
+	for _, item := range items {
+		if name := selectItemName(item); name != "" {
+			outputs[strings.ToLower(name)]++
+		}
+	}
+	for _, item := range items {
+		if a, ok := item.(*ast.Alias); ok && outputs[strings.ToLower(a.As.Alias.Name)] > 1 {
+			return errors.Errorf("alias %s conflicts with another result column", a.As.Alias.Name)
+		}
+	}
+	for _, o := range s.ords {
+		col := strings.ToLower(o.col)
+		if outputs[col] > 1 {
+			return errors.Errorf("ORDER BY %s is ambiguous", o.col)
+		}
+		if s.unnest == nil || outputs[col] > 0 {
+			continue
+		}
+		if s.unnest.as != "" && strings.EqualFold(s.unnest.as, o.col) {
+			continue
+		}
+		if s.withOffset && strings.EqualFold(s.offsetName(), o.col) {
+			continue
+		}
+		return errors.Errorf("ORDER BY %s refers to neither a result column nor a column of UNNEST", o.col)
+	}
+	return nil
+}
+
+// offsetName returns the name of the column added by WITH OFFSET.
+func (s *SelectStmt) offsetName() string {
+	if s.offsetAs == "" {
+		return "offset"
+	}
+	return s.offsetAs
+}
+
+// selectItemName returns the name of the result column, or an empty string if it has no name.
+func selectItemName(item ast.SelectItem) string {
+	switch i := item.(type) {
+	case *ast.Alias:
+		return i.As.Alias.Name
+	case *ast.ExprSelectItem:
+		switch e := i.Expr.(type) {
+		case *ast.Ident:
+			return e.Name
+		case *ast.Path:
+			return e.Idents[len(e.Idents)-1].Name
+		}
+	}
+	return ""
+}
+
+type unnestSource struct {
+	expr interface{}
+	as   string
+}
+
+func (u *unnestSource) toAST() (*ast.Unnest, error) {
+	expr, err := internal.ToExpr(u.expr)
+	if err != nil {
+		return nil, err
+	}
+	unnest := &ast.Unnest{
+		Expr: expr,
+	}
+	if u.as != "" {
+		unnest.As = &ast.AsAlias{Alias: &ast.Ident{Name: u.as}}
+	}
+	return unnest, nil
 }
 
 // UpdateStmt builds UPDATE statements.
 type UpdateStmt struct {
-	table string
-	items []*updateItem
-	conds []WhereCond
+	table       string
+	items       []*updateItem
+	conds       []WhereCond
+	idempotent  bool
+	returning   thenReturn
+	partitioned bool
+	dialect     Dialect
+	names       NameMapper
+	// pii are the columns tagged as PII in structs given to SetStruct, which RedactedSQL masks.
+	pii []string
+	// int64AsString makes large INT64 values STRING. See LargeInt64AsString.
+	int64AsString bool
+	err           error
 }
 
 type updateItem struct {
@@ -223,7 +574,11 @@ func (i *updateItem) toASTUpdateItem() (*ast.UpdateItem, error) {
 	// NOTE: can't use ast.Path here for any reason.
 	path := make([]*ast.Ident, 0, len(i.ident.names))
 	for _, name := range i.ident.names {
-		path = append(path, &ast.Ident{Name: name})
+		id, err := toASTIdent(name)
+		if err != nil {
+			return nil, err
+		}
+		path = append(path, id)
 	}
 	expr, err := internal.ToExpr(i.value)
 	if err != nil {
@@ -245,47 +600,99 @@ func Update(table string) *UpdateStmt {
 // Set adds a assignment clause to the UPDATE statement.
 func (s *UpdateStmt) Set(id *IdentExpr, value interface{}) *UpdateStmt {
 	var t = *s
-	t.items = append(t.items, &updateItem{
+	t.items = append(append([]*updateItem(nil), t.items...), &updateItem{
 		ident: id,
 		value: value,
 	})
 	return &t
 }
 
+// SetMap adds an assignment clause for each key of m, in order of the keys so that the SQL is deterministic.
+func (s *UpdateStmt) SetMap(m map[string]interface{}) *UpdateStmt {
+	var t = *s
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	t.items = append([]*updateItem(nil), t.items...)
+	for _, k := range keys {
+		t.items = append(t.items, &updateItem{
+			ident: Ident(k),
+			value: m[k],
+		})
+	}
+	return &t
+}
+
 // Where adds a WHERE clause to the UPDATE statement.
 func (s *UpdateStmt) Where(conds ...WhereCond) *UpdateStmt {
 	var t = *s
-	t.conds = append(t.conds, conds...)
+	t.conds = append(append([]WhereCond(nil), t.conds...), conds...)
 	return &t
 }
 
+// WhereConds returns the conditional expressions of the UPDATE statement.
+// They can be passed to Where of another statement to share the same filter.
+func (s *UpdateStmt) WhereConds() []WhereCond {
+	return append([]WhereCond(nil), s.conds...)
+}
+
 func (s *UpdateStmt) SQL() (string, error) {
-	stmt, err := s.toAST()
+	stmt, err := s.toASTStatement()
 	if err != nil {
 		return "", err
 	}
-	return stmt.SQL(), nil
+	sql, _, err := s.dialect.render(stmt)
+	return sql, err
+}
+
+// toASTStatement converts the UPDATE statement into the AST of the whole statement including THEN RETURN clause.
+func (s *UpdateStmt) toASTStatement() (ast.Node, error) {
+	stmt, err := s.toAST()
+	if err != nil {
+		return nil, err
+	}
+	if s.int64AsString {
+		if err := castLargeInt64s(s, stmt); err != nil {
+			return nil, err
+		}
+	}
+	if s.partitioned {
+		if err := checkPartitionedDML(stmt, s.returning); err != nil {
+			return nil, err
+		}
+	}
+	return s.returning.wrap(stmt)
 }
 
 func (s *UpdateStmt) toAST() (*ast.Update, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
 	if len(s.items) <= 0 {
 		return nil, errors.New("no SET clause is specified")
 	}
+	if len(s.conds) == 0 {
+		return nil, ErrMissingWhere
+	}
+	var errs errorList
 	items := make([]*ast.UpdateItem, 0, len(s.items))
 	for _, item := range s.items {
 		astItem, err := item.toASTUpdateItem()
-		if err != nil {
-			return nil, err
-		}
+		errs.add(err)
 		items = append(items, astItem)
 	}
 
 	cond, err := And(s.conds...).ToASTWhere()
-	if err != nil {
+	errs.add(err)
+	table, err := toASTIdent(s.table)
+	errs.add(err)
+	if err := errs.err(); err != nil {
 		return nil, err
 	}
 	return &ast.Update{
-		TableName: &ast.Ident{Name: s.table},
+		TableName: table,
 		Updates:   items,
 		Where:     cond,
 	}, nil
@@ -293,8 +700,14 @@ func (s *UpdateStmt) toAST() (*ast.Update, error) {
 
 // DeleteStmt builds DELETE statements.
 type DeleteStmt struct {
-	table string
-	conds []WhereCond
+	table       string
+	conds       []WhereCond
+	idempotent  bool
+	returning   thenReturn
+	partitioned bool
+	dialect     Dialect
+	// int64AsString makes large INT64 values STRING. See LargeInt64AsString.
+	int64AsString bool
 }
 
 // Delete creates a new DeleteStmt with given table name.
@@ -307,84 +720,191 @@ func Delete(table string) *DeleteStmt {
 // Where appends given conditional expressions to the DELETE statement.
 func (s *DeleteStmt) Where(conds ...WhereCond) *DeleteStmt {
 	var t = *s
-	t.conds = append(t.conds, conds...)
+	t.conds = append(append([]WhereCond(nil), t.conds...), conds...)
 	return &t
 }
 
+// WhereConds returns the conditional expressions of the DELETE statement.
+// They can be passed to Where of another statement to share the same filter.
+func (s *DeleteStmt) WhereConds() []WhereCond {
+	return append([]WhereCond(nil), s.conds...)
+}
+
 func (s *DeleteStmt) SQL() (string, error) {
-	stmt, err := s.toAST()
+	stmt, err := s.toASTStatement()
 	if err != nil {
 		return "", err
 	}
-	return stmt.SQL(), nil
+	sql, _, err := s.dialect.render(stmt)
+	return sql, err
+}
+
+// toASTStatement converts the DELETE statement into the AST of the whole statement including THEN RETURN clause.
+func (s *DeleteStmt) toASTStatement() (ast.Node, error) {
+	stmt, err := s.toAST()
+	if err != nil {
+		return nil, err
+	}
+	if s.int64AsString {
+		if err := castLargeInt64s(s, stmt); err != nil {
+			return nil, err
+		}
+	}
+	if s.partitioned {
+		if err := checkPartitionedDML(stmt, s.returning); err != nil {
+			return nil, err
+		}
+	}
+	return s.returning.wrap(stmt)
 }
 
 func (s *DeleteStmt) toAST() (*ast.Delete, error) {
+	if len(s.conds) == 0 {
+		return nil, ErrMissingWhere
+	}
+	var errs errorList
 	cond, err := And(s.conds...).ToASTWhere()
-	if err != nil {
+	errs.add(err)
+	table, err := toASTIdent(s.table)
+	errs.add(err)
+	if err := errs.err(); err != nil {
 		return nil, err
 	}
 	return &ast.Delete{
-		TableName: &ast.Ident{Name: s.table},
+		TableName: table,
 		Where:     cond,
 	}, nil
 }
 
 // InsertStmt builds INSERT statements.
 type InsertStmt struct {
-	table  string
-	cols   []string
-	values interface{}
+	table      string
+	cols       []string
+	values     interface{}
+	idempotent bool
+	mode       string
+	query      *SelectStmt
+	returning  thenReturn
+	names      NameMapper
+	dialect    Dialect
+	// int64AsString makes large INT64 values STRING. See LargeInt64AsString.
+	int64AsString bool
+	// keyUnused guards the rows by an idempotency key. See InsertStmt.IdempotencyKeyUnused.
+	keyUnused *ExistsCond
+	err       error
 }
 
 // Insert creates a new InsertStmt with given table name. and column names.
 func Insert(table string, cols []string) *InsertStmt {
 	return &InsertStmt{
 		table: table,
-		cols:  cols,
+		cols:  append([]string(nil), cols...),
 	}
 }
 
 // Values returns an InsertStmt with its values set to given ones.
-// It replaces existing values.
+// It replaces existing values. The values are referred to, not copied, until the statement is built.
 func (s *InsertStmt) Values(values interface{}) *InsertStmt {
-	return &InsertStmt{
-		table:  s.table,
-		cols:   s.cols,
-		values: values,
-	}
+	var t = *s
+	t.values = values
+	t.query = nil
+	return &t
+}
+
+// Select returns an InsertStmt inserting the rows of the SELECT statement, i.e. `INSERT INTO t (cols) SELECT ...`.
+// It replaces existing values.
+func (s *InsertStmt) Select(query *SelectStmt) *InsertStmt {
+	var t = *s
+	t.query = query
+	t.values = nil
+	return &t
+}
+
+// OrUpdate makes the statement `INSERT OR UPDATE INTO ...`, which updates existing rows with the same key.
+func (s *InsertStmt) OrUpdate() *InsertStmt {
+	var t = *s
+	t.mode = internal.InsertOrUpdate
+	return &t
+}
+
+// OrIgnore makes the statement `INSERT OR IGNORE INTO ...`, which skips rows whose key already exists.
+func (s *InsertStmt) OrIgnore() *InsertStmt {
+	var t = *s
+	t.mode = internal.InsertOrIgnore
+	return &t
 }
 
 func (is *InsertStmt) SQL() (string, error) {
-	stmt, err := is.toAST()
+	stmt, err := is.toASTStatement()
 	if err != nil {
 		return "", err
 	}
-	return stmt.SQL(), nil
+	sql, _, err := is.dialect.render(stmt)
+	return sql, err
+}
+
+// toASTStatement converts the INSERT statement into the AST of the whole statement including its mode.
+func (s *InsertStmt) toASTStatement() (ast.Node, error) {
+	stmt, err := s.toAST()
+	if err != nil {
+		return nil, err
+	}
+	if s.int64AsString {
+		if err := castLargeInt64s(s, stmt); err != nil {
+			return nil, err
+		}
+	}
+	if s.mode == "" {
+		return s.returning.wrap(stmt)
+	}
+	return s.returning.wrap(&internal.InsertOrStmt{Mode: s.mode, Insert: stmt})
 }
 
 func (s *InsertStmt) toAST() (*ast.Insert, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	if len(s.cols) == 0 {
+		return nil, ErrNoColumns
+	}
+	var errs errorList
 	cols := make([]*ast.Ident, 0, len(s.cols))
 	for _, name := range s.cols {
-		cols = append(cols, &ast.Ident{Name: name})
+		id, err := toASTIdent(name)
+		errs.add(err)
+		cols = append(cols, id)
 	}
-	if s.values == nil {
-		return nil, errors.New("neither VALUES nor SELECT specified")
+	table, err := toASTIdent(s.table)
+	errs.add(err)
+	if s.values == nil && s.query == nil {
+		errs.add(errors.New("neither VALUES nor SELECT specified"))
+		return nil, errs.err()
 	}
-	// TODO: support SELECT
 	var input ast.InsertInput
-	var err error
 	rowsV := reflect.ValueOf(s.values)
-	if rowsV.Type().Kind() == reflect.Slice {
+	if s.query != nil {
+		if len(s.query.hints) > 0 {
+			return nil, errors.New("hints of SELECT can't be used in INSERT")
+		}
+		query, err := s.query.toAST()
+		errs.add(err)
+		input = &ast.SubQueryInput{Query: query}
+	} else if rowsV.Type().Kind() == reflect.Slice {
 		input, err = s.sliceToInsertInput(rowsV)
-		if err != nil {
+		errs.add(err)
+	} else {
+		errs.add(errors.Errorf("can't create InsertInput"))
+	}
+	if err := errs.err(); err != nil {
+		return nil, err
+	}
+	if s.keyUnused != nil {
+		if input, err = s.guardInput(input); err != nil {
 			return nil, err
 		}
-	} else {
-		return nil, errors.Errorf("can't create InsertInput")
 	}
 	return &ast.Insert{
-		TableName: &ast.Ident{Name: s.table},
+		TableName: table,
 		Columns:   cols,
 		Input:     input,
 	}, nil
@@ -393,16 +913,21 @@ func (s *InsertStmt) toAST() (*ast.Insert, error) {
 func (s *InsertStmt) sliceToInsertInput(rowsV reflect.Value) (ast.InsertInput, error) {
 	input := &ast.ValuesInput{}
 	if rowsV.Len() <= 0 {
-		return nil, errors.New("empty values")
+		return nil, ErrEmptyValues
 	}
+	var errs errorList
 	for i := 0; i < rowsV.Len(); i++ {
 		rowI := rowsV.Index(i).Interface()
 		row, err := s.toValuesRow(rowI)
 		if err != nil {
-			return nil, errors.WithMessagef(err, "can't convert %T into SQL row", rowI)
+			errs.add(errors.WithMessagef(err, "can't convert %T into SQL row", rowI))
+			continue
 		}
 		input.Rows = append(input.Rows, row)
 	}
+	if err := errs.err(); err != nil {
+		return nil, err
+	}
 	return input, nil
 }
 
@@ -413,6 +938,11 @@ func (s *InsertStmt) toValuesRow(val interface{}) (*ast.ValuesRow, error) {
 		return s.sliceToValuesRow(valV)
 	case reflect.Struct:
 		return s.structToValuesRow(valV)
+	case reflect.Map:
+		if valV.Type().Key().Kind() != reflect.String {
+			return nil, errors.Errorf("%s doesn't have string keys", valV.Type().String())
+		}
+		return s.mapToValuesRow(valV)
 	case reflect.Ptr:
 		if valV.Type().Elem().Kind() == reflect.Struct {
 			return s.structToValuesRow(valV.Elem())
@@ -425,49 +955,104 @@ func (s *InsertStmt) toValuesRow(val interface{}) (*ast.ValuesRow, error) {
 
 // The type of valV is guaranteed to be slice here.
 func (s *InsertStmt) sliceToValuesRow(valV reflect.Value) (*ast.ValuesRow, error) {
+	var errs errorList
 	row := &ast.ValuesRow{}
 	for i := 0; i < valV.Len(); i++ {
-		expr, err := internal.ToExpr(valV.Index(i).Interface())
+		expr, err := toDefaultExpr(valV.Index(i).Interface())
+		errs.add(err)
+		row.Exprs = append(row.Exprs, expr)
+	}
+	if err := errs.err(); err != nil {
+		return nil, err
+	}
+	return row, nil
+}
+
+// The type of valV is guaranteed to be map with string keys here.
+// Each key must be one of the columns, and each column must have its key.
+func (s *InsertStmt) mapToValuesRow(valV reflect.Value) (*ast.ValuesRow, error) {
+	var errs errorList
+	row := &ast.ValuesRow{}
+	found := 0
+	for _, colName := range s.cols {
+		v := valV.MapIndex(reflect.ValueOf(colName).Convert(valV.Type().Key()))
+		if !v.IsValid() {
+			errs.add(errors.Errorf("map doesn't have column %s", colName))
+			continue
+		}
+		found++
+		expr, err := toDefaultExpr(v.Interface())
 		if err != nil {
-			return nil, err
+			errs.add(errors.WithMessagef(err, "column %s", colName))
+			continue
 		}
-		row.Exprs = append(row.Exprs, &ast.DefaultExpr{Expr: expr})
+		row.Exprs = append(row.Exprs, expr)
+	}
+	if valV.Len() > found {
+		keys := valV.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+		for _, k := range keys {
+			if !containsString(s.cols, k.String()) {
+				errs.add(errors.Errorf("map has key %s, which is not a column", k.String()))
+			}
+		}
+	}
+	if err := errs.err(); err != nil {
+		return nil, err
 	}
 	return row, nil
 }
 
+func containsString(ss []string, s string) bool {
+	for _, x := range ss {
+		if x == s {
+			return true
+		}
+	}
+	return false
+}
+
 // The type of valV is guaranteed to be struct here.
 func (s *InsertStmt) structToValuesRow(valV reflect.Value) (*ast.ValuesRow, error) {
 	row := &ast.ValuesRow{}
 	valT := valV.Type()
-	numField := valT.NumField()
+	fields, err := structFields(valT, s.names)
+	if err != nil {
+		return nil, err
+	}
+	var errs errorList
 	for _, colName := range s.cols {
-		colFound := false
-		for i := 0; i < numField; i++ {
-			ft := valT.Field(i)
-			if !columnNameMatches(&ft, colName) {
-				continue
-			}
-			colFound = true
-			expr, err := internal.ToExpr(valV.Field(i).Interface())
-			if err != nil {
-				return nil, err
+		var field *structField
+		for _, f := range fields {
+			if f.matches(colName) {
+				field = f
+				break
 			}
-			row.Exprs = append(row.Exprs, &ast.DefaultExpr{Expr: expr})
 		}
-		if !colFound {
-			return nil, errors.Errorf("type %s does not have column %s", valT.String(), colName)
+		if field == nil {
+			errs.add(errors.Errorf("type %s does not have column %s", valT.String(), colName))
+			continue
 		}
+		if field.readOnly {
+			errs.add(errors.Errorf("column %s of type %s is read-only", colName, valT.String()))
+			continue
+		}
+		fv, err := valV.FieldByIndexErr(field.index)
+		if err != nil {
+			errs.add(errors.WithMessagef(err, "can't get field %s of type %s", field.path, valT.String()))
+			continue
+		}
+		v, ok := field.writeValue(fv)
+		if !ok {
+			row.Exprs = append(row.Exprs, &ast.DefaultExpr{Default: true})
+			continue
+		}
+		expr, err := toDefaultExpr(v)
+		errs.add(err)
+		row.Exprs = append(row.Exprs, expr)
 	}
-	return row, nil
-}
-
-func columnNameMatches(field *reflect.StructField, colName string) bool {
-	tag := field.Tag.Get("spanner")
-	if tag == "" {
-		return strings.EqualFold(field.Name, colName)
-	} else if tag == "-" {
-		return false
+	if err := errs.err(); err != nil {
+		return nil, err
 	}
-	return tag == colName
+	return row, nil
 }
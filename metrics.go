@@ -0,0 +1,73 @@
+package memeduck
+
+import (
+	"strconv"
+
+	"github.com/cloudspannerecosystem/memefish/ast"
+
+	"github.com/abyssparanoia/memeduck/internal"
+)
+
+// MetricsLabels is a normalized set of labels describing a statement,
+// intended for instrumentation around statement execution.
+type MetricsLabels struct {
+	Operation  string
+	Table      string
+	HasLimit   bool
+	ParamCount int
+}
+
+// Map returns the labels as a string map, suitable for Prometheus label values.
+func (l *MetricsLabels) Map() map[string]string {
+	return map[string]string{
+		"operation":   l.Operation,
+		"table":       l.Table,
+		"has_limit":   strconv.FormatBool(l.HasLimit),
+		"param_count": strconv.Itoa(l.ParamCount),
+	}
+}
+
+func newMetricsLabels(op, table string, hasLimit bool, stmt ast.Node) *MetricsLabels {
+	return &MetricsLabels{
+		Operation:  op,
+		Table:      table,
+		HasLimit:   hasLimit,
+		ParamCount: len(internal.NodeParamNames(stmt)),
+	}
+}
+
+// MetricsLabels returns labels describing the SELECT statement.
+func (s *SelectStmt) MetricsLabels() (*MetricsLabels, error) {
+	stmt, err := s.toASTStatement()
+	if err != nil {
+		return nil, err
+	}
+	return newMetricsLabels("SELECT", s.table, s.limit != nil, stmt), nil
+}
+
+// MetricsLabels returns labels describing the INSERT statement.
+func (s *InsertStmt) MetricsLabels() (*MetricsLabels, error) {
+	stmt, err := s.toASTStatement()
+	if err != nil {
+		return nil, err
+	}
+	return newMetricsLabels("INSERT", s.table, false, stmt), nil
+}
+
+// MetricsLabels returns labels describing the UPDATE statement.
+func (s *UpdateStmt) MetricsLabels() (*MetricsLabels, error) {
+	stmt, err := s.toASTStatement()
+	if err != nil {
+		return nil, err
+	}
+	return newMetricsLabels("UPDATE", s.table, false, stmt), nil
+}
+
+// MetricsLabels returns labels describing the DELETE statement.
+func (s *DeleteStmt) MetricsLabels() (*MetricsLabels, error) {
+	stmt, err := s.toASTStatement()
+	if err != nil {
+		return nil, err
+	}
+	return newMetricsLabels("DELETE", s.table, false, stmt), nil
+}
@@ -0,0 +1,65 @@
+package memeduck_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/abyssparanoia/memeduck"
+)
+
+func TestMetricsLabels(t *testing.T) {
+	labels, err := memeduck.Select("hoge", []string{"a"}).
+		Where(
+			memeduck.Eq(memeduck.Ident("b"), memeduck.Param("b")),
+			memeduck.Ne(memeduck.Ident("c"), memeduck.Param("b")),
+			memeduck.Lt(memeduck.Ident("d"), memeduck.Param("d")),
+		).
+		Limit(10).
+		MetricsLabels()
+	assert.Nil(t, err)
+	assert.Equal(t, &memeduck.MetricsLabels{
+		Operation:  "SELECT",
+		Table:      "hoge",
+		HasLimit:   true,
+		ParamCount: 2,
+	}, labels)
+	assert.Equal(t, map[string]string{
+		"operation":   "SELECT",
+		"table":       "hoge",
+		"has_limit":   "true",
+		"param_count": "2",
+	}, labels.Map())
+
+	labels, err = memeduck.Insert("hoge", []string{"a", "b"}).
+		Values([][]interface{}{{memeduck.Param("a"), 1}}).
+		MetricsLabels()
+	assert.Nil(t, err)
+	assert.Equal(t, &memeduck.MetricsLabels{Operation: "INSERT", Table: "hoge", ParamCount: 1}, labels)
+
+	labels, err = memeduck.Update("hoge").
+		Set(memeduck.Ident("a"), memeduck.Param("a")).
+		Where(memeduck.Eq(memeduck.Ident("id"), memeduck.Param("id"))).
+		MetricsLabels()
+	assert.Nil(t, err)
+	assert.Equal(t, &memeduck.MetricsLabels{Operation: "UPDATE", Table: "hoge", ParamCount: 2}, labels)
+
+	labels, err = memeduck.Delete("hoge").
+		Where(memeduck.Bool(true)).
+		MetricsLabels()
+	assert.Nil(t, err)
+	assert.Equal(t, &memeduck.MetricsLabels{Operation: "DELETE", Table: "hoge"}, labels)
+
+	_, err = memeduck.Delete("hoge").MetricsLabels()
+	assert.Error(t, err)
+}
+
+func TestMetricsLabelsPostgreSQL(t *testing.T) {
+	labels, err := memeduck.Update("hoge").
+		Dialect(memeduck.DialectPostgreSQL).
+		Set(memeduck.Ident("a"), memeduck.Param("a")).
+		Where(memeduck.Eq(memeduck.Ident("id"), memeduck.Param("id"))).
+		MetricsLabels()
+	assert.Nil(t, err)
+	assert.Equal(t, &memeduck.MetricsLabels{Operation: "UPDATE", Table: "hoge", ParamCount: 2}, labels)
+}
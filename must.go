@@ -0,0 +1,34 @@
+package memeduck
+
+// MustSQL is like SQL but panics if the SELECT statement can't be built.
+// It is intended for static queries initialized at package level.
+func (s *SelectStmt) MustSQL() string {
+	return mustSQL(s.SQL())
+}
+
+// MustSQL is like SQL but panics if the INSERT statement can't be built.
+func (s *InsertStmt) MustSQL() string {
+	return mustSQL(s.SQL())
+}
+
+// MustSQL is like SQL but panics if the UPDATE statement can't be built.
+func (s *UpdateStmt) MustSQL() string {
+	return mustSQL(s.SQL())
+}
+
+// MustSQL is like SQL but panics if the DELETE statement can't be built.
+func (s *DeleteStmt) MustSQL() string {
+	return mustSQL(s.SQL())
+}
+
+// MustSQL is like SQL but panics if the statement can't be built.
+func (s *ChildStatsStmt) MustSQL() string {
+	return mustSQL(s.SQL())
+}
+
+func mustSQL(sql string, err error) string {
+	if err != nil {
+		panic("memeduck: " + err.Error())
+	}
+	return sql
+}
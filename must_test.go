@@ -0,0 +1,25 @@
+package memeduck_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/abyssparanoia/memeduck"
+)
+
+var testStaticQuery = memeduck.Select("user", []string{"id"}).Where(memeduck.Eq(memeduck.Ident("id"), memeduck.Param("id"))).MustSQL()
+
+func TestMustSQL(t *testing.T) {
+	assert.Equal(t, "SELECT id FROM user WHERE id = @id", testStaticQuery)
+	assert.Equal(t, "INSERT INTO user (id) VALUES (1)", memeduck.Insert("user", []string{"id"}).Values([][]interface{}{{1}}).MustSQL())
+	assert.Equal(t, "UPDATE user SET a = 1 WHERE TRUE", memeduck.Update("user").Set(memeduck.Ident("a"), 1).Where(memeduck.Bool(true)).MustSQL())
+	assert.Equal(t, "DELETE FROM user WHERE TRUE", memeduck.Delete("user").Where(memeduck.Bool(true)).MustSQL())
+
+	assert.PanicsWithValue(t, "memeduck: no SET clause is specified", func() {
+		memeduck.Update("user").Where(memeduck.Bool(true)).MustSQL()
+	})
+	assert.Panics(t, func() {
+		memeduck.Insert("user", []string{"id"}).MustSQL()
+	})
+}
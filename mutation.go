@@ -0,0 +1,223 @@
+package memeduck
+
+import (
+	"reflect"
+
+	"cloud.google.com/go/spanner"
+	"github.com/pkg/errors"
+
+	"github.com/abyssparanoia/memeduck/internal"
+)
+
+// Mutations converts the rows of the INSERT statement into mutations of the Spanner client,
+// `Insert` or `InsertOrUpdate` for OrUpdate, so that the same statement can be applied without DML.
+// Values must be Go values accepted by the Spanner client, not memeduck expressions like Param or Func.
+func (s *InsertStmt) Mutations() ([]*spanner.Mutation, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	op := spanner.Insert
+	switch s.mode {
+	case internal.InsertOrUpdate:
+		op = spanner.InsertOrUpdate
+	case internal.InsertOrIgnore:
+		return nil, errors.New("INSERT OR IGNORE can't be converted into mutations")
+	}
+	if s.query != nil {
+		return nil, errors.New("INSERT ... SELECT can't be converted into mutations")
+	}
+	if len(s.returning.cols) > 0 || len(s.returning.items) > 0 {
+		return nil, errors.New("THEN RETURN can't be converted into mutations")
+	}
+	if s.keyUnused != nil {
+		return nil, errors.New("IdempotencyKeyUnused can't be converted into mutations")
+	}
+	rowsV := reflect.ValueOf(s.values)
+	if rowsV.Kind() != reflect.Slice {
+		return nil, errors.Errorf("can't convert %T into rows", s.values)
+	}
+	ms := make([]*spanner.Mutation, 0, rowsV.Len())
+	for i := 0; i < rowsV.Len(); i++ {
+		cols, vals, err := s.mutationRow(rowsV.Index(i).Interface())
+		if err != nil {
+			return nil, errors.WithMessagef(err, "at row %d", i)
+		}
+		ms = append(ms, op(s.table, cols, vals))
+	}
+	return ms, nil
+}
+
+// mutationRow returns the columns and values of the row to be written by a mutation.
+func (s *InsertStmt) mutationRow(row interface{}) ([]string, []interface{}, error) {
+	rowV := reflect.Indirect(reflect.ValueOf(row))
+	cols := make([]string, 0, len(s.cols))
+	vals := make([]interface{}, 0, len(s.cols))
+	add := func(col string, v interface{}) error {
+		mv, err := mutationValue(v)
+		if err != nil {
+			return errors.WithMessagef(err, "column %s", col)
+		}
+		cols = append(cols, col)
+		vals = append(vals, mv)
+		return nil
+	}
+	switch rowV.Kind() {
+	case reflect.Slice:
+		if rowV.Len() != len(s.cols) {
+			return nil, nil, errors.Errorf("row has %d values for %d columns", rowV.Len(), len(s.cols))
+		}
+		for i, col := range s.cols {
+			if err := add(col, rowV.Index(i).Interface()); err != nil {
+				return nil, nil, err
+			}
+		}
+	case reflect.Map:
+		if _, err := s.mapToValuesRow(rowV); err != nil {
+			return nil, nil, err
+		}
+		for _, col := range s.cols {
+			if err := add(col, rowV.MapIndex(reflect.ValueOf(col).Convert(rowV.Type().Key())).Interface()); err != nil {
+				return nil, nil, err
+			}
+		}
+	case reflect.Struct:
+		for _, col := range s.cols {
+			f, fv, err := structColumn(rowV.Interface(), col, s.names)
+			if err != nil {
+				return nil, nil, err
+			}
+			if f.readOnly {
+				return nil, nil, errors.Errorf("column %s of type %s is read-only", col, rowV.Type().String())
+			}
+			// A mutation has its own columns, so omitted fields are just not written.
+			if v, ok := f.writeValue(fv); ok {
+				if err := add(col, v); err != nil {
+					return nil, nil, err
+				}
+			}
+		}
+	default:
+		return nil, nil, errors.Errorf("%T is neither struct, map nor slice", row)
+	}
+	return cols, vals, nil
+}
+
+// mutationValue checks that v can be passed to mutations of the Spanner client.
+func mutationValue(v interface{}) (interface{}, error) {
+	switch v := v.(type) {
+	case SafeInt64:
+		return int64(v), nil
+	case internal.ASTExpr:
+		return nil, errors.Errorf("%T can't be used in mutations", v)
+	}
+	return v, nil
+}
+
+// Mutation converts the UPDATE statement into an `Update` mutation of the Spanner client.
+// Its WHERE clause must consist of `col = value` conditions combined with AND, which identify the primary key,
+// and SET values must be Go values accepted by the Spanner client.
+func (s *UpdateStmt) Mutation() (*spanner.Mutation, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	if len(s.returning.cols) > 0 || len(s.returning.items) > 0 {
+		return nil, errors.New("THEN RETURN can't be converted into mutations")
+	}
+	if len(s.items) == 0 {
+		return nil, errors.New("no SET clause specified")
+	}
+	cols, vals, err := keyConditions(s.conds)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range s.items {
+		if item.ident.err != nil || len(item.ident.names) != 1 {
+			return nil, errors.New("only columns can be set in mutations")
+		}
+		v, err := mutationValue(item.value)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "column %s", item.ident.names[0])
+		}
+		cols = append(cols, item.ident.names[0])
+		vals = append(vals, v)
+	}
+	return spanner.Update(s.table, cols, vals), nil
+}
+
+// Mutation converts the DELETE statement into a `Delete` mutation of the Spanner client.
+// Its WHERE clause must be either `col = value` conditions combined with AND in the order of the primary key,
+// which deletes a row, or a single `col IN UNNEST(values)` condition on a single-column key, which deletes the rows.
+func (s *DeleteStmt) Mutation() (*spanner.Mutation, error) {
+	if len(s.returning.cols) > 0 || len(s.returning.items) > 0 {
+		return nil, errors.New("THEN RETURN can't be converted into mutations")
+	}
+	if len(s.conds) == 1 {
+		if c, ok := s.conds[0].(*InCond); ok && !c.not {
+			if _, ok := c.lhs.(*IdentExpr); !ok {
+				return nil, errors.New("IN condition must be on a key column")
+			}
+			u, ok := c.rhs.(*UnnestInConditionValue)
+			if !ok {
+				return nil, errors.New("IN condition must be IN UNNEST(values)")
+			}
+			valsV := reflect.ValueOf(u.value)
+			if valsV.Kind() != reflect.Slice {
+				return nil, errors.Errorf("%T is not a slice of keys", u.value)
+			}
+			keys := make([]spanner.KeySet, 0, valsV.Len())
+			for i := 0; i < valsV.Len(); i++ {
+				v, err := mutationValue(valsV.Index(i).Interface())
+				if err != nil {
+					return nil, err
+				}
+				keys = append(keys, spanner.Key{v})
+			}
+			return spanner.Delete(s.table, spanner.KeySets(keys...)), nil
+		}
+	}
+	_, vals, err := keyConditions(s.conds)
+	if err != nil {
+		return nil, err
+	}
+	return spanner.Delete(s.table, spanner.Key(vals)), nil
+}
+
+// keyConditions extracts columns and values from `col = value` conditions combined with AND.
+func keyConditions(conds []WhereCond) ([]string, []interface{}, error) {
+	var cols []string
+	var vals []interface{}
+	var collect func(conds []WhereCond) error
+	collect = func(conds []WhereCond) error {
+		for _, cond := range conds {
+			switch c := cond.(type) {
+			case *LogicalOpCond:
+				if c.op != logicalOpAnd {
+					return errors.New("OR can't be converted into a key")
+				}
+				if err := collect(c.conds); err != nil {
+					return err
+				}
+				continue
+			case *OpCond:
+				if id, ok := c.lhs.(*IdentExpr); ok && c.op == EQ && id.err == nil && len(id.names) == 1 {
+					v, err := mutationValue(c.rhs)
+					if err != nil {
+						return errors.WithMessagef(err, "key column %s", id.names[0])
+					}
+					cols = append(cols, id.names[0])
+					vals = append(vals, v)
+					continue
+				}
+			}
+			return errors.Errorf("%T can't be converted into a key, only `col = value` is allowed", cond)
+		}
+		return nil
+	}
+	if err := collect(conds); err != nil {
+		return nil, nil, err
+	}
+	if len(cols) == 0 {
+		return nil, nil, errors.New("no key conditions specified")
+	}
+	return cols, vals, nil
+}
@@ -0,0 +1,82 @@
+package memeduck_test
+
+import (
+	"testing"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/abyssparanoia/memeduck"
+)
+
+func TestInsertMutations(t *testing.T) {
+	ms, err := memeduck.Insert("hoge", []string{"a", "b"}).Values([][]interface{}{{1, "x"}, {2, nil}}).Mutations()
+	assert.Nil(t, err)
+	assert.Equal(t, []*spanner.Mutation{
+		spanner.Insert("hoge", []string{"a", "b"}, []interface{}{1, "x"}),
+		spanner.Insert("hoge", []string{"a", "b"}, []interface{}{2, nil}),
+	}, ms)
+
+	ms, err = memeduck.InsertStruct("hoge", []*testTagOptionsRow{{ID: 1, Name: "a"}, {ID: 2}}).OrUpdate().Mutations()
+	assert.Nil(t, err)
+	assert.Equal(t, []*spanner.Mutation{
+		spanner.InsertOrUpdate("hoge", []string{"id", "name", "UpdatedAt"}, []interface{}{int64(1), "a", spanner.CommitTimestamp}),
+		spanner.InsertOrUpdate("hoge", []string{"id", "UpdatedAt"}, []interface{}{int64(2), spanner.CommitTimestamp}),
+	}, ms)
+
+	ms, err = memeduck.Insert("hoge", []string{"a"}).Values([]map[string]interface{}{{"a": memeduck.SafeInt64(3)}}).Mutations()
+	assert.Nil(t, err)
+	assert.Equal(t, []*spanner.Mutation{spanner.Insert("hoge", []string{"a"}, []interface{}{int64(3)})}, ms)
+
+	_, err = memeduck.Insert("hoge", []string{"a"}).Values([][]interface{}{{memeduck.Param("a")}}).Mutations()
+	assert.EqualError(t, err, "at row 0: column a: *memeduck.ParamExpr can't be used in mutations")
+	_, err = memeduck.Insert("hoge", []string{"a"}).Values([][]interface{}{{1}}).OrIgnore().Mutations()
+	assert.Error(t, err)
+	_, err = memeduck.Insert("hoge", []string{"a"}).Values([][]interface{}{{1}}).IdempotencyKeyUnused("dedupe", "key", "k").Mutations()
+	assert.EqualError(t, err, "IdempotencyKeyUnused can't be converted into mutations")
+	_, err = memeduck.Insert("hoge", []string{"a", "b"}).Values([][]interface{}{{1}}).Mutations()
+	assert.EqualError(t, err, "at row 0: row has 1 values for 2 columns")
+}
+
+func TestUpdateMutation(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	m, err := memeduck.Update("hoge").
+		Set(memeduck.Ident("name"), "x").
+		Set(memeduck.Ident("updated_at"), now).
+		Where(memeduck.Eq(memeduck.Ident("id"), 1), memeduck.And(memeduck.Eq(memeduck.Ident("seq"), 2))).
+		Mutation()
+	assert.Nil(t, err)
+	assert.Equal(t, spanner.Update("hoge", []string{"id", "seq", "name", "updated_at"}, []interface{}{1, 2, "x", now}), m)
+
+	_, err = memeduck.Update("hoge").Set(memeduck.Ident("name"), "x").Where(memeduck.Like(memeduck.Ident("id"), "a%")).Mutation()
+	assert.EqualError(t, err, "*memeduck.OpCond can't be converted into a key, only `col = value` is allowed")
+	_, err = memeduck.Update("hoge").Set(memeduck.Ident("name"), "x").
+		Where(memeduck.Or(memeduck.Eq(memeduck.Ident("id"), 1), memeduck.Eq(memeduck.Ident("id"), 2))).Mutation()
+	assert.EqualError(t, err, "OR can't be converted into a key")
+
+	type user struct {
+		ID   int64
+		Name string
+	}
+	stmt := memeduck.Update("User").SetStruct(&user{ID: 1, Name: "x"}, "Name", "Missing").Where(memeduck.Eq(memeduck.Ident("ID"), 1))
+	_, sqlErr := stmt.SQL()
+	assert.Error(t, sqlErr)
+	_, err = stmt.Mutation()
+	assert.EqualError(t, err, sqlErr.Error())
+}
+
+func TestDeleteMutation(t *testing.T) {
+	m, err := memeduck.Delete("hoge").Where(memeduck.Eq(memeduck.Ident("id"), 1), memeduck.Eq(memeduck.Ident("seq"), 2)).Mutation()
+	assert.Nil(t, err)
+	assert.Equal(t, spanner.Delete("hoge", spanner.Key{1, 2}), m)
+
+	m, err = memeduck.Delete("hoge").Where(memeduck.In(memeduck.Ident("id"), memeduck.Unnest([]int64{1, 2}))).Mutation()
+	assert.Nil(t, err)
+	assert.Equal(t, spanner.Delete("hoge", spanner.KeySets(spanner.Key{int64(1)}, spanner.Key{int64(2)})), m)
+
+	_, err = memeduck.Delete("hoge").Where(memeduck.Bool(true)).Mutation()
+	assert.Error(t, err)
+	_, err = memeduck.Delete("hoge").Where(memeduck.Eq(memeduck.Ident("id"), 1)).ThenReturn("id").Mutation()
+	assert.Error(t, err)
+}
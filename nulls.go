@@ -0,0 +1,128 @@
+package memeduck
+
+// NullsOrder specifies where NULLs are sorted by ORDER BY.
+type NullsOrder string
+
+const (
+	// NullsDefault leaves NULL ordering to the database, i.e. NULLs first for ASC and last for DESC.
+	NullsDefault NullsOrder = ""
+	// NullsFirst sorts NULLs first, i.e. `NULLS FIRST`.
+	NullsFirst NullsOrder = "NULLS FIRST"
+	// NullsLast sorts NULLs last, i.e. `NULLS LAST`.
+	NullsLast NullsOrder = "NULLS LAST"
+)
+
+// OrderByNulls appends a column to its ORDER BY clause with explicit NULL ordering,
+// e.g. `ORDER BY col ASC NULLS LAST`.
+func (s *SelectStmt) OrderByNulls(col string, dir Direction, nulls NullsOrder) *SelectStmt {
+	var t = *s
+	t.ords = append(append([]*ordering(nil), t.ords...), &ordering{
+		col:   col,
+		dir:   dir,
+		nulls: nulls,
+	})
+	return &t
+}
+
+// DefaultNulls sets NULL ordering used by ORDER BY columns without explicit NULL ordering, per direction.
+func (s *SelectStmt) DefaultNulls(asc, desc NullsOrder) *SelectStmt {
+	var t = *s
+	t.nullsAsc = asc
+	t.nullsDesc = desc
+	return &t
+}
+
+func (s *SelectStmt) withNullsDefault(o *ordering) *ordering {
+	if o.nulls != NullsDefault {
+		return o
+	}
+	var t = *o
+	if o.dir == DESC {
+		t.nulls = s.nullsDesc
+	} else {
+		t.nulls = s.nullsAsc
+	}
+	return &t
+}
+
+// Factory creates statements sharing the same defaults,
+// so that conventions like NULL ordering don't have to be repeated for every query.
+type Factory struct {
+	nullsAsc      NullsOrder
+	nullsDesc     NullsOrder
+	names         NameMapper
+	int64AsString bool
+}
+
+// NewFactory creates a new Factory with no defaults.
+func NewFactory() *Factory {
+	return &Factory{}
+}
+
+// DefaultNulls sets NULL ordering of SELECT statements created by the factory. See SelectStmt.DefaultNulls.
+func (f *Factory) DefaultNulls(asc, desc NullsOrder) *Factory {
+	var t = *f
+	t.nullsAsc = asc
+	t.nullsDesc = desc
+	return &t
+}
+
+// LargeInt64AsString makes INT64 values of statements created by the factory beyond the JavaScript-safe range
+// STRING. See SelectStmt.LargeInt64AsString.
+func (f *Factory) LargeInt64AsString() *Factory {
+	var t = *f
+	t.int64AsString = true
+	return &t
+}
+
+// Select creates a new SelectStmt with the defaults of the factory.
+func (f *Factory) Select(table string, cols []string) *SelectStmt {
+	stmt := Select(table, cols).DefaultNulls(f.nullsAsc, f.nullsDesc)
+	stmt.int64AsString = f.int64AsString
+	return stmt
+}
+
+// SelectFromUnnest creates a new SelectStmt reading rows from UNNEST with the defaults of the factory.
+func (f *Factory) SelectFromUnnest(arr interface{}, as string, cols []string) *SelectStmt {
+	stmt := SelectFromUnnest(arr, as, cols).DefaultNulls(f.nullsAsc, f.nullsDesc)
+	stmt.int64AsString = f.int64AsString
+	return stmt
+}
+
+// NameMapper sets the mapping from names of untagged struct fields to column names,
+// used by INSERT and UPDATE statements of structs created by the factory, e.g. NameMapper(SnakeCase).
+func (f *Factory) NameMapper(m NameMapper) *Factory {
+	var t = *f
+	t.names = m
+	return &t
+}
+
+// Insert creates a new InsertStmt with the defaults of the factory.
+func (f *Factory) Insert(table string, cols []string) *InsertStmt {
+	stmt := Insert(table, cols)
+	stmt.names = f.names
+	stmt.int64AsString = f.int64AsString
+	return stmt
+}
+
+// InsertStruct creates a new InsertStmt inserting structs with the defaults of the factory. See InsertStruct.
+func (f *Factory) InsertStruct(table string, v interface{}) *InsertStmt {
+	stmt := insertStruct(table, v, f.names)
+	stmt.int64AsString = f.int64AsString
+	return stmt
+}
+
+// Update creates a new UpdateStmt with the defaults of the factory, which apply to UpdateStmt.SetStruct.
+func (f *Factory) Update(table string) *UpdateStmt {
+	stmt := Update(table)
+	stmt.names = f.names
+	stmt.int64AsString = f.int64AsString
+	return stmt
+}
+
+// Delete creates a new DeleteStmt with the defaults of the factory.
+func (f *Factory) Delete(table string) *DeleteStmt {
+	stmt := Delete(table)
+	stmt.int64AsString = f.int64AsString
+	return stmt
+}
@@ -0,0 +1,34 @@
+package memeduck_test
+
+import (
+	"testing"
+
+	"github.com/abyssparanoia/memeduck"
+)
+
+func TestNullsOrder(t *testing.T) {
+	testSelect(t,
+		memeduck.Select("hoge", []string{"a"}).
+			OrderByNulls("a", memeduck.ASC, memeduck.NullsLast).
+			OrderBy("b", memeduck.DESC),
+		`SELECT a FROM hoge ORDER BY a ASC NULLS LAST, b DESC`,
+	)
+
+	f := memeduck.NewFactory().DefaultNulls(memeduck.NullsLast, memeduck.NullsFirst)
+	testSelect(t,
+		f.Select("hoge", []string{"a"}).
+			OrderBy("a", memeduck.ASC).
+			OrderBy("b", memeduck.DESC).
+			OrderByNulls("c", memeduck.ASC, memeduck.NullsFirst).
+			OrderByCollate("d", "und:ci", memeduck.ASC),
+		`SELECT a FROM hoge ORDER BY a ASC NULLS LAST, b DESC NULLS FIRST, c ASC NULLS FIRST, d COLLATE "und:ci" ASC NULLS LAST`,
+	)
+	testSelect(t,
+		f.SelectFromUnnest(memeduck.Param("ids"), "id", []string{"id"}).OrderBy("id", memeduck.DESC),
+		`SELECT id FROM UNNEST(@ids) AS id ORDER BY id DESC NULLS FIRST`,
+	)
+	testSelect(t,
+		memeduck.NewFactory().Select("hoge", []string{"a"}).OrderBy("a", memeduck.ASC),
+		`SELECT a FROM hoge ORDER BY a ASC`,
+	)
+}
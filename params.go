@@ -0,0 +1,261 @@
+package memeduck
+
+import (
+	"reflect"
+
+	"cloud.google.com/go/spanner"
+	"github.com/cloudspannerecosystem/memefish/ast"
+	"github.com/pkg/errors"
+
+	"github.com/abyssparanoia/memeduck/internal"
+)
+
+// ParamTypes infers the Spanner type (e.g. "INT64", "ARRAY<STRING>") expected for each query parameter
+// of the SELECT statement from the context it is used in, such as the other side of comparisons or CAST.
+// Parameters whose type can't be inferred are mapped to "".
+// It returns an error when a parameter is used as different types.
+func (s *SelectStmt) ParamTypes() (map[string]string, error) {
+	stmt, err := s.toASTStatement()
+	if err != nil {
+		return nil, err
+	}
+	return internal.ParamTypes(stmt)
+}
+
+// ParamTypes infers the Spanner type expected for each query parameter of the INSERT statement.
+// See SelectStmt.ParamTypes for details.
+func (s *InsertStmt) ParamTypes() (map[string]string, error) {
+	stmt, err := s.toASTStatement()
+	if err != nil {
+		return nil, err
+	}
+	return internal.ParamTypes(stmt)
+}
+
+// ParamTypes infers the Spanner type expected for each query parameter of the UPDATE statement.
+// See SelectStmt.ParamTypes for details.
+func (s *UpdateStmt) ParamTypes() (map[string]string, error) {
+	stmt, err := s.toASTStatement()
+	if err != nil {
+		return nil, err
+	}
+	return internal.ParamTypes(stmt)
+}
+
+// ParamTypes infers the Spanner type expected for each query parameter of the DELETE statement.
+// See SelectStmt.ParamTypes for details.
+func (s *DeleteStmt) ParamTypes() (map[string]string, error) {
+	stmt, err := s.toASTStatement()
+	if err != nil {
+		return nil, err
+	}
+	return internal.ParamTypes(stmt)
+}
+
+// SQLWithParams renders the SELECT statement with every literal replaced by a generated query parameter,
+// and returns the values of the parameters. Binding values as parameters prevents injection,
+// and lets Spanner reuse the query plan for different values.
+// Parameters are named p1, p2, ..., skipping names already used in the statement.
+func (s *SelectStmt) SQLWithParams() (string, map[string]interface{}, error) {
+	stmt, err := s.toASTStatement()
+	if err != nil {
+		return "", nil, err
+	}
+	return sqlWithParams(stmt, s.dialect)
+}
+
+// SQLWithParams renders the INSERT statement with every literal replaced by a generated query parameter.
+// See SelectStmt.SQLWithParams for details.
+func (s *InsertStmt) SQLWithParams() (string, map[string]interface{}, error) {
+	stmt, err := s.toASTStatement()
+	if err != nil {
+		return "", nil, err
+	}
+	return sqlWithParams(stmt, s.dialect)
+}
+
+// SQLWithParams renders the UPDATE statement with every literal replaced by a generated query parameter.
+// See SelectStmt.SQLWithParams for details.
+func (s *UpdateStmt) SQLWithParams() (string, map[string]interface{}, error) {
+	stmt, err := s.toASTStatement()
+	if err != nil {
+		return "", nil, err
+	}
+	return sqlWithParams(stmt, s.dialect)
+}
+
+// SQLWithParams renders the DELETE statement with every literal replaced by a generated query parameter.
+// See SelectStmt.SQLWithParams for details.
+func (s *DeleteStmt) SQLWithParams() (string, map[string]interface{}, error) {
+	stmt, err := s.toASTStatement()
+	if err != nil {
+		return "", nil, err
+	}
+	return sqlWithParams(stmt, s.dialect)
+}
+
+func sqlWithParams(stmt ast.Node, d Dialect) (string, map[string]interface{}, error) {
+	params, err := internal.Parameterize(stmt)
+	if err != nil {
+		return "", nil, err
+	}
+	sql, names, err := d.render(stmt)
+	if err != nil {
+		return "", nil, err
+	}
+	return sql, d.bindParams(names, params), nil
+}
+
+// Params returns the values of parameters created by ParamValue in the SELECT statement, keyed by name.
+// It can be passed as spanner.Statement.Params as it is.
+// It returns an error when a parameter is bound to different values.
+func (s *SelectStmt) Params() (map[string]interface{}, error) {
+	params, err := collectParams(s)
+	if err != nil {
+		return nil, err
+	}
+	return largeInt64AsString(params, s.int64AsString), nil
+}
+
+// Params returns the values of parameters created by ParamValue in the INSERT statement.
+// See SelectStmt.Params for details.
+func (s *InsertStmt) Params() (map[string]interface{}, error) {
+	params, err := collectParams(s)
+	if err != nil {
+		return nil, err
+	}
+	return largeInt64AsString(params, s.int64AsString), nil
+}
+
+// Params returns the values of parameters created by ParamValue in the UPDATE statement.
+// See SelectStmt.Params for details.
+func (s *UpdateStmt) Params() (map[string]interface{}, error) {
+	params, err := collectParams(s)
+	if err != nil {
+		return nil, err
+	}
+	return largeInt64AsString(params, s.int64AsString), nil
+}
+
+// Params returns the values of parameters created by ParamValue in the DELETE statement.
+// See SelectStmt.Params for details.
+func (s *DeleteStmt) Params() (map[string]interface{}, error) {
+	params, err := collectParams(s)
+	if err != nil {
+		return nil, err
+	}
+	return largeInt64AsString(params, s.int64AsString), nil
+}
+
+// Statement returns the SELECT statement as a spanner.Statement, with Params populated by the values of ParamValue.
+func (s *SelectStmt) Statement() (spanner.Statement, error) {
+	return toStatement(s.dialect, s.toASTStatement, s.Params)
+}
+
+// Statement returns the INSERT statement as a spanner.Statement. See SelectStmt.Statement.
+func (s *InsertStmt) Statement() (spanner.Statement, error) {
+	return toStatement(s.dialect, s.toASTStatement, s.Params)
+}
+
+// Statement returns the UPDATE statement as a spanner.Statement. See SelectStmt.Statement.
+func (s *UpdateStmt) Statement() (spanner.Statement, error) {
+	return toStatement(s.dialect, s.toASTStatement, s.Params)
+}
+
+// Statement returns the DELETE statement as a spanner.Statement. See SelectStmt.Statement.
+func (s *DeleteStmt) Statement() (spanner.Statement, error) {
+	return toStatement(s.dialect, s.toASTStatement, s.Params)
+}
+
+func toStatement(d Dialect, stmtFn func() (ast.Node, error), paramsFn func() (map[string]interface{}, error)) (spanner.Statement, error) {
+	stmt, err := stmtFn()
+	if err != nil {
+		return spanner.Statement{}, err
+	}
+	sql, names, err := d.render(stmt)
+	if err != nil {
+		return spanner.Statement{}, err
+	}
+	params, err := paramsFn()
+	if err != nil {
+		return spanner.Statement{}, err
+	}
+	return spanner.Statement{SQL: sql, Params: d.bindParams(names, params)}, nil
+}
+
+var (
+	paramExprType  = reflect.TypeOf(&ParamExpr{})
+	builderPkgPath = paramExprType.Elem().PkgPath()
+)
+
+// collectParams walks the statement builder and collects the values of bound ParamExprs.
+// Only the fields of builder types, i.e. the types of this package, are walked by reflection,
+// with the values and rows which can hold expressions, so that data like []byte and row structs are not walked.
+func collectParams(stmt interface{}) (map[string]interface{}, error) {
+	params := make(map[string]interface{})
+	visited := make(map[uintptr]bool)
+	var err error
+	var walk func(v reflect.Value)
+	walk = func(v reflect.Value) {
+		if err != nil || !mayHoldExpr(v.Type()) {
+			return
+		}
+		switch v.Kind() {
+		case reflect.Ptr:
+			if v.IsNil() || visited[v.Pointer()] {
+				return
+			}
+			visited[v.Pointer()] = true
+			if v.Type() == paramExprType {
+				// Fields of the builder are unexported, so the pointer can't be taken by Interface.
+				p := (*ParamExpr)(v.UnsafePointer())
+				if !p.bound {
+					return
+				}
+				if prev, ok := params[p.name]; ok && !reflect.DeepEqual(prev, p.value) {
+					err = errors.Errorf("parameter %s is bound to different values %v and %v", p.name, prev, p.value)
+					return
+				}
+				params[p.name] = p.value
+				return
+			}
+			walk(v.Elem())
+		case reflect.Interface:
+			if !v.IsNil() {
+				walk(v.Elem())
+			}
+		case reflect.Struct:
+			for i := 0; i < v.NumField(); i++ {
+				walk(v.Field(i))
+			}
+		case reflect.Slice, reflect.Array:
+			for i := 0; i < v.Len(); i++ {
+				walk(v.Index(i))
+			}
+		case reflect.Map:
+			iter := v.MapRange()
+			for iter.Next() {
+				walk(iter.Value())
+			}
+		}
+	}
+	walk(reflect.ValueOf(stmt))
+	if err != nil {
+		return nil, err
+	}
+	return params, nil
+}
+
+// mayHoldExpr reports whether values of t can hold ParamExprs, i.e. t is a builder type of this package,
+// an interface, or a pointer, a slice, an array or a map of them.
+func mayHoldExpr(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Interface:
+		return true
+	case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map:
+		return mayHoldExpr(t.Elem())
+	case reflect.Struct:
+		return t.PkgPath() == builderPkgPath
+	}
+	return false
+}
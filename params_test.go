@@ -0,0 +1,175 @@
+package memeduck_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/civil"
+	"cloud.google.com/go/spanner"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/abyssparanoia/memeduck"
+)
+
+func TestParamTypes(t *testing.T) {
+	types, err := memeduck.Select("hoge", []string{"a"}).Where(
+		memeduck.Eq(memeduck.Ident("a"), memeduck.Param("a")),
+		memeduck.Gt(memeduck.Param("b"), 10),
+		memeduck.Like(memeduck.Ident("c"), memeduck.Param("c")),
+		memeduck.In(memeduck.Param("d"), memeduck.Unnest([]string{"x", "y"})),
+		memeduck.In(3, memeduck.Unnest(memeduck.Param("e"))),
+		memeduck.Between(memeduck.Ident("f"), memeduck.Param("min"), 1.5),
+	).ParamTypes()
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{
+		"a":   "",
+		"b":   "INT64",
+		"c":   "STRING",
+		"d":   "STRING",
+		"e":   "ARRAY<INT64>",
+		"min": "FLOAT64",
+	}, types)
+
+	types, err = memeduck.Select("hoge", []string{"a"}).
+		Hint("USE_ADDITIONAL_PARALLELISM", memeduck.Param("parallel")).
+		Where(memeduck.Eq(memeduck.Ident("a"), memeduck.Param("a"))).
+		ParamTypes()
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"a": "", "parallel": ""}, types)
+
+	types, err = memeduck.Update("hoge").
+		Set(memeduck.Ident("a"), memeduck.Param("a")).
+		Where(memeduck.Eq(memeduck.Param("id"), "foo")).
+		ParamTypes()
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"a": "", "id": "STRING"}, types)
+
+	types, err = memeduck.Delete("hoge").
+		Where(memeduck.Eq(memeduck.Ident("id"), memeduck.Param("id")), memeduck.Ne(memeduck.Param("id"), true)).
+		ParamTypes()
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"id": "BOOL"}, types)
+
+	types, err = memeduck.Insert("hoge", []string{"a"}).Values([][]interface{}{{memeduck.Param("a")}}).ParamTypes()
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"a": ""}, types)
+
+	_, err = memeduck.Delete("hoge").
+		Where(memeduck.Eq(memeduck.Param("id"), 1), memeduck.Eq(memeduck.Param("id"), "1")).
+		ParamTypes()
+	assert.Error(t, err)
+}
+
+func TestSQLWithParams(t *testing.T) {
+	sql, params, err := memeduck.Select("user", []string{"id"}).
+		Where(
+			memeduck.Eq(memeduck.Ident("name"), "foo"),
+			memeduck.Eq(memeduck.Ident("p1"), memeduck.Param("p1")),
+			memeduck.In(memeduck.Ident("status"), memeduck.Unnest([]int64{1, 2, 3})),
+			memeduck.Gt(memeduck.Ident("created_at"), time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)),
+			memeduck.IsNull(memeduck.Ident("deleted_at")),
+		).
+		Limit(10).
+		SQLWithParams()
+	assert.Nil(t, err)
+	assert.Equal(t, `SELECT id FROM user WHERE name = @p2 AND p1 = @p1 AND status IN UNNEST(@p3) AND created_at > @p4 AND deleted_at IS NULL LIMIT 10`, sql)
+	assert.Equal(t, map[string]interface{}{
+		"p2": "foo",
+		"p3": []int64{1, 2, 3},
+		"p4": time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+	}, params)
+
+	sql, params, err = memeduck.Insert("hoge", []string{"a", "b", "c"}).
+		Values([][]interface{}{{1.5, []interface{}{"x", 1}, memeduck.JSON(map[string]int{"k": 1})}}).
+		SQLWithParams()
+	assert.Nil(t, err)
+	assert.Equal(t, `INSERT INTO hoge (a, b, c) VALUES (@p1, ARRAY[@p2, @p3], @p4)`, sql)
+	assert.Equal(t, map[string]interface{}{
+		"p1": 1.5,
+		"p2": "x",
+		"p3": int64(1),
+		"p4": spanner.NullJSON{Value: json.RawMessage(`{"k":1}`), Valid: true},
+	}, params)
+
+	sql, params, err = memeduck.Update("hoge").Set(memeduck.Ident("a"), true).Where(memeduck.Eq(memeduck.Ident("id"), -3)).SQLWithParams()
+	assert.Nil(t, err)
+	assert.Equal(t, `UPDATE hoge SET a = @p1 WHERE id = @p2`, sql)
+	assert.Equal(t, map[string]interface{}{"p1": true, "p2": int64(-3)}, params)
+
+	sql, params, err = memeduck.Delete("hoge").Where(memeduck.Eq(memeduck.Ident("d"), civil.Date{Year: 2024, Month: 1, Day: 2})).SQLWithParams()
+	assert.Nil(t, err)
+	assert.Equal(t, `DELETE FROM hoge WHERE d = @p1`, sql)
+	assert.Equal(t, map[string]interface{}{"p1": civil.Date{Year: 2024, Month: 1, Day: 2}}, params)
+
+	sql, params, err = memeduck.Select("user", []string{"id"}).
+		Where(
+			memeduck.CollateEq(memeduck.Ident("name"), "foo", "und:ci"),
+			memeduck.Eq(memeduck.JSONValueAt(memeduck.Ident("attrs"), "$.color"), "red"),
+		).
+		SQLWithParams()
+	assert.Nil(t, err)
+	assert.Equal(t, `SELECT id FROM user WHERE (COLLATE(name, "und:ci")) = (COLLATE(@p1, "und:ci")) AND JSON_VALUE(attrs, "$.color") = @p2`, sql)
+	assert.Equal(t, map[string]interface{}{"p1": "foo", "p2": "red"}, params)
+}
+
+func TestParams(t *testing.T) {
+	params, err := memeduck.Select("user", []string{"id"}).
+		Where(
+			memeduck.Eq(memeduck.Ident("name"), memeduck.ParamValue("name", "foo")),
+			memeduck.In(memeduck.Ident("id"), memeduck.Unnest(memeduck.ParamValue("ids", []int64{1, 2}))),
+			memeduck.Gt(memeduck.Ident("age"), memeduck.Param("age")),
+			memeduck.Ne(memeduck.Ident("nick"), memeduck.ParamValue("name", "foo")),
+		).
+		Params()
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"name": "foo", "ids": []int64{1, 2}}, params)
+
+	params, err = memeduck.Insert("user", []string{"id", "name"}).
+		Values([][]interface{}{{1, memeduck.ParamValue("name", "foo")}}).
+		Params()
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"name": "foo"}, params)
+
+	params, err = memeduck.Update("user").
+		Set(memeduck.Ident("name"), memeduck.ParamValue("name", "bar")).
+		Where(memeduck.Eq(memeduck.Ident("id"), memeduck.ParamValue("id", int64(1)))).
+		Params()
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"name": "bar", "id": int64(1)}, params)
+
+	params, err = memeduck.Delete("user").Where(memeduck.Eq(memeduck.Ident("id"), 1)).Params()
+	assert.Nil(t, err)
+	assert.Empty(t, params)
+
+	_, err = memeduck.Delete("user").
+		Where(memeduck.Eq(memeduck.Ident("a"), memeduck.ParamValue("p", 1)), memeduck.Eq(memeduck.Ident("b"), memeduck.ParamValue("p", 2))).
+		Params()
+	assert.EqualError(t, err, "parameter p is bound to different values 1 and 2")
+
+	params, err = memeduck.Insert("file", []string{"id", "body", "name"}).
+		Values([]map[string]interface{}{
+			{"id": 1, "body": make([]byte, 1<<20), "name": memeduck.Func("LOWER", memeduck.ParamValue("name", "foo"))},
+		}).
+		Params()
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"name": "foo"}, params)
+}
+
+func TestStatement(t *testing.T) {
+	stmt, err := memeduck.Select("user", []string{"id"}).
+		Where(memeduck.Eq(memeduck.Ident("name"), memeduck.ParamValue("name", "foo"))).
+		Statement()
+	assert.Nil(t, err)
+	assert.Equal(t, spanner.Statement{
+		SQL:    "SELECT id FROM user WHERE name = @name",
+		Params: map[string]interface{}{"name": "foo"},
+	}, stmt)
+
+	stmt, err = memeduck.Delete("user").Where(memeduck.Eq(memeduck.Ident("id"), 1)).Statement()
+	assert.Nil(t, err)
+	assert.Equal(t, spanner.Statement{SQL: "DELETE FROM user WHERE id = 1", Params: map[string]interface{}{}}, stmt)
+
+	_, err = memeduck.Update("user").Statement()
+	assert.Error(t, err)
+}
@@ -0,0 +1,88 @@
+package memeduck
+
+import (
+	"context"
+
+	"cloud.google.com/go/spanner"
+	"github.com/cloudspannerecosystem/memefish/ast"
+	"github.com/pkg/errors"
+
+	"github.com/abyssparanoia/memeduck/internal"
+)
+
+// PartitionedDML marks the UPDATE statement to be executed as Partitioned DML by Run.
+// Building the statement fails if it isn't eligible, i.e. it has THEN RETURN or reads other tables by subqueries.
+func (s *UpdateStmt) PartitionedDML() *UpdateStmt {
+	var t = *s
+	t.partitioned = true
+	return &t
+}
+
+// IsPartitionedDML reports whether the UPDATE statement is marked by PartitionedDML.
+func (s *UpdateStmt) IsPartitionedDML() bool {
+	return s.partitioned
+}
+
+// PartitionedDML marks the DELETE statement to be executed as Partitioned DML by Run.
+// Building the statement fails if it isn't eligible, i.e. it has THEN RETURN or reads other tables by subqueries.
+func (s *DeleteStmt) PartitionedDML() *DeleteStmt {
+	var t = *s
+	t.partitioned = true
+	return &t
+}
+
+// IsPartitionedDML reports whether the DELETE statement is marked by PartitionedDML.
+func (s *DeleteStmt) IsPartitionedDML() bool {
+	return s.partitioned
+}
+
+// checkPartitionedDML returns an error if the DML statement can't be executed as Partitioned DML.
+// Partitioned DML runs on each partition of the table independently,
+// so the statement must touch only the target table and can't return rows.
+// Subqueries over UNNEST of columns or parameters, like ArrayContains, are allowed as they read no tables.
+func checkPartitionedDML(dml ast.Node, returning thenReturn) error {
+	if len(returning.cols) > 0 || len(returning.items) > 0 {
+		return errors.New("partitioned DML can't have THEN RETURN")
+	}
+	var err error
+	internal.Walk(dml, func(n ast.Node) bool {
+		if _, ok := n.(*ast.TableName); ok {
+			err = errors.New("partitioned DML can't have subqueries reading tables, as it must be fully partitionable")
+		}
+		return err == nil
+	})
+	return err
+}
+
+// Run executes the UPDATE statement on client, by PartitionedUpdate if it is marked by PartitionedDML,
+// or in a read-write transaction otherwise. It returns the number of modified rows,
+// which is a lower bound for Partitioned DML.
+func (s *UpdateStmt) Run(ctx context.Context, client *spanner.Client) (int64, error) {
+	stmt, err := s.Statement()
+	if err != nil {
+		return 0, err
+	}
+	return runDML(ctx, client, stmt, s.partitioned)
+}
+
+// Run executes the DELETE statement on client. See UpdateStmt.Run.
+func (s *DeleteStmt) Run(ctx context.Context, client *spanner.Client) (int64, error) {
+	stmt, err := s.Statement()
+	if err != nil {
+		return 0, err
+	}
+	return runDML(ctx, client, stmt, s.partitioned)
+}
+
+func runDML(ctx context.Context, client *spanner.Client, stmt spanner.Statement, partitioned bool) (int64, error) {
+	if partitioned {
+		return client.PartitionedUpdate(ctx, stmt)
+	}
+	var count int64
+	_, err := client.ReadWriteTransaction(ctx, func(ctx context.Context, tx *spanner.ReadWriteTransaction) error {
+		var err error
+		count, err = tx.Update(ctx, stmt)
+		return err
+	})
+	return count, err
+}
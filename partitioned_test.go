@@ -0,0 +1,48 @@
+package memeduck_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/abyssparanoia/memeduck"
+)
+
+func TestPartitionedDML(t *testing.T) {
+	update := memeduck.Update("user").
+		Set(memeduck.Ident("active"), false).
+		Where(memeduck.Lt(memeduck.Ident("last_login"), memeduck.Param("since")))
+	assert.False(t, update.IsPartitionedDML())
+	pdml := update.PartitionedDML()
+	assert.True(t, pdml.IsPartitionedDML())
+	assert.False(t, update.IsPartitionedDML())
+	testUpdate(t, pdml, `UPDATE user SET active = FALSE WHERE last_login < @since`)
+
+	_, err := pdml.ThenReturn("id").SQL()
+	assert.EqualError(t, err, "partitioned DML can't have THEN RETURN")
+
+	del := memeduck.Delete("user").
+		Where(memeduck.Exists(memeduck.Select("banned", []string{"id"}))).
+		PartitionedDML()
+	assert.True(t, del.IsPartitionedDML())
+	_, err = del.SQL()
+	assert.EqualError(t, err, "partitioned DML can't have subqueries reading tables, as it must be fully partitionable")
+	_, err = memeduck.Update("user").
+		Set(memeduck.Ident("active"), false).
+		Where(memeduck.Eq(memeduck.Ident("id"), memeduck.Select("banned", []string{"id"}).Limit(1))).
+		PartitionedDML().
+		SQL()
+	assert.EqualError(t, err, "partitioned DML can't have subqueries reading tables, as it must be fully partitionable")
+
+	testDelete(t, memeduck.Delete("user").Where(memeduck.Eq(memeduck.Ident("active"), false)).PartitionedDML(), `DELETE FROM user WHERE active = FALSE`)
+	testDelete(t,
+		memeduck.Delete("user").
+			Where(
+				memeduck.ArrayContainsAny(memeduck.Ident("tags"), []string{"spam"}),
+				memeduck.ArrayContainsAll(memeduck.Ident("tags"), memeduck.Param("tags")),
+			).
+			PartitionedDML(),
+		`DELETE FROM user WHERE EXISTS(SELECT 1 FROM UNNEST(tags) AS memeduck_elem WHERE memeduck_elem IN UNNEST(ARRAY["spam"])) AND `+
+			`NOT EXISTS(SELECT 1 FROM UNNEST(@tags) AS memeduck_value WHERE NOT EXISTS(SELECT 1 FROM UNNEST(tags) AS memeduck_elem WHERE memeduck_elem = memeduck_value))`,
+	)
+}
@@ -0,0 +1,136 @@
+package memeduck
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/abyssparanoia/memeduck/internal"
+)
+
+// refSeparator separates the step name and the column name in parameter names of Ref.
+const refSeparator = "__"
+
+var stepNamePattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9]*(_[A-Za-z0-9]+)*$`)
+
+// Stmt is a statement which can be rendered as SQL.
+type Stmt interface {
+	SQL() (string, error)
+}
+
+// Executor executes sql with the parameters, and returns the rows of its THEN RETURN clause as maps keyed by column.
+// It adapts a Spanner client or a database/sql connection to Pipeline.
+type Executor func(sql string, params map[string]interface{}) ([]map[string]interface{}, error)
+
+// Pipeline is a sequence of DML statements, where a later statement can reference THEN RETURN outputs of earlier ones
+// by Ref, e.g. to insert child rows with the key generated for their parent.
+type Pipeline struct {
+	steps []*pipelineStep
+}
+
+type pipelineStep struct {
+	name string
+	stmt Stmt
+}
+
+// NewPipeline creates an empty Pipeline.
+func NewPipeline() *Pipeline {
+	return &Pipeline{}
+}
+
+// Add appends the statement as the step named name to the pipeline.
+// The name must be unique and consist of alphanumerics separated by single underscores.
+func (p *Pipeline) Add(name string, stmt Stmt) *Pipeline {
+	var t = *p
+	t.steps = append(append([]*pipelineStep(nil), p.steps...), &pipelineStep{name: name, stmt: stmt})
+	return &t
+}
+
+// Ref creates a placeholder of the column col in the first row returned by the earlier step,
+// which is bound to the actual value by Pipeline.Run.
+func Ref(step, col string) *ParamExpr {
+	return Param(step + refSeparator + col)
+}
+
+// Run executes the steps in order with exec, binding the parameters created by Ref.
+// It returns the rows returned by each step keyed by the step name.
+func (p *Pipeline) Run(exec Executor) (map[string][]map[string]interface{}, error) {
+	results := make(map[string][]map[string]interface{}, len(p.steps))
+	for _, step := range p.steps {
+		if !stepNamePattern.MatchString(step.name) {
+			return nil, errors.Errorf("invalid step name %q", step.name)
+		}
+		if _, ok := results[step.name]; ok {
+			return nil, errors.Errorf("duplicate step %s", step.name)
+		}
+		sql, params, err := renderStep(step.stmt, results)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "step %s", step.name)
+		}
+		rows, err := exec(sql, params)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "step %s", step.name)
+		}
+		results[step.name] = rows
+	}
+	return results, nil
+}
+
+// renderStep renders stmt in its dialect, and binds the parameters created by Ref with the results of earlier steps
+// to the names Spanner expects in the dialect.
+func renderStep(stmt Stmt, results map[string][]map[string]interface{}) (string, map[string]interface{}, error) {
+	s, ok := stmt.(dialectStmt)
+	if !ok {
+		sql, err := stmt.SQL()
+		if err != nil {
+			return "", nil, err
+		}
+		names, err := internal.ParamNames(sql)
+		if err != nil {
+			return "", nil, err
+		}
+		params, err := bindRefs(names, results)
+		return sql, params, err
+	}
+	node, err := s.toASTStatement()
+	if err != nil {
+		return "", nil, err
+	}
+	d := s.sqlDialect()
+	sql, names, err := d.render(node)
+	if err != nil {
+		return "", nil, err
+	}
+	params, err := bindRefs(internal.NodeParamNames(node), results)
+	if err != nil {
+		return "", nil, err
+	}
+	return sql, d.bindParams(names, params), nil
+}
+
+// bindRefs resolves the parameters named names created by Ref with the results of earlier steps.
+// Other parameters are left to the caller.
+func bindRefs(names []string, results map[string][]map[string]interface{}) (map[string]interface{}, error) {
+	params := make(map[string]interface{})
+	for _, name := range names {
+		i := strings.Index(name, refSeparator)
+		if i < 0 {
+			continue
+		}
+		step, col := name[:i], name[i+len(refSeparator):]
+		rows, ok := results[step]
+		if !ok {
+			return nil, errors.Errorf("@%s references step %s, which is not executed before", name, step)
+		}
+		if len(rows) == 0 {
+			return nil, errors.Errorf("@%s references step %s, which returned no rows", name, step)
+		}
+		v, ok := rows[0][col]
+		if !ok {
+			return nil, errors.Errorf("@%s references column %s, which step %s didn't return", name, col, step)
+		}
+		params[name] = v
+	}
+	return params, nil
+}
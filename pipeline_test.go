@@ -0,0 +1,74 @@
+package memeduck_test
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/abyssparanoia/memeduck"
+)
+
+func TestPipeline(t *testing.T) {
+	p := memeduck.NewPipeline().
+		Add("singer", memeduck.Insert("singer", []string{"name"}).Values([][]interface{}{{"foo"}}).ThenReturn("id")).
+		Add("album", memeduck.Insert("album", []string{"singer_id", "title"}).
+			Values([][]interface{}{{memeduck.Ref("singer", "id"), "bar"}}).
+			ThenReturn("id"))
+	var sqls []string
+	var params []map[string]interface{}
+	results, err := p.Run(func(sql string, ps map[string]interface{}) ([]map[string]interface{}, error) {
+		sqls = append(sqls, sql)
+		params = append(params, ps)
+		return []map[string]interface{}{{"id": int64(len(sqls))}}, nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{
+		`INSERT INTO singer (name) VALUES ("foo") THEN RETURN id`,
+		`INSERT INTO album (singer_id, title) VALUES (@singer__id, "bar") THEN RETURN id`,
+	}, sqls)
+	assert.Equal(t, []map[string]interface{}{{}, {"singer__id": int64(1)}}, params)
+	assert.Equal(t, map[string][]map[string]interface{}{
+		"singer": {{"id": int64(1)}},
+		"album":  {{"id": int64(2)}},
+	}, results)
+
+	noRows := func(string, map[string]interface{}) ([]map[string]interface{}, error) { return nil, nil }
+	_, err = p.Run(noRows)
+	assert.EqualError(t, err, "step album: @singer__id references step singer, which returned no rows")
+	_, err = memeduck.NewPipeline().
+		Add("a", memeduck.Delete("t").Where(memeduck.Eq(memeduck.Ident("id"), memeduck.Ref("b", "id")))).
+		Run(noRows)
+	assert.EqualError(t, err, "step a: @b__id references step b, which is not executed before")
+	_, err = memeduck.NewPipeline().Add("a", memeduck.Delete("t")).Run(noRows)
+	assert.Error(t, err)
+	_, err = memeduck.NewPipeline().Add("a__b", memeduck.Delete("t").Where(memeduck.Bool(true))).Run(noRows)
+	assert.EqualError(t, err, `invalid step name "a__b"`)
+	_, err = memeduck.NewPipeline().Add("a", memeduck.Delete("t").Where(memeduck.Bool(true))).
+		Run(func(string, map[string]interface{}) ([]map[string]interface{}, error) {
+			return nil, errors.New("aborted")
+		})
+	assert.EqualError(t, err, "step a: aborted")
+}
+
+func TestPipelinePostgreSQL(t *testing.T) {
+	p := memeduck.NewPipeline().
+		Add("singer", memeduck.Insert("singer", []string{"name"}).Values([][]interface{}{{"foo"}}).ThenReturn("id").Dialect(memeduck.DialectPostgreSQL)).
+		Add("album", memeduck.Insert("album", []string{"singer_id", "title"}).
+			Values([][]interface{}{{memeduck.Ref("singer", "id"), memeduck.Param("title")}}).
+			ThenReturn("id").
+			Dialect(memeduck.DialectPostgreSQL))
+	var sqls []string
+	var params []map[string]interface{}
+	_, err := p.Run(func(sql string, ps map[string]interface{}) ([]map[string]interface{}, error) {
+		sqls = append(sqls, sql)
+		params = append(params, ps)
+		return []map[string]interface{}{{"id": int64(len(sqls))}}, nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{
+		`INSERT INTO singer (name) VALUES ('foo') RETURNING id`,
+		`INSERT INTO album (singer_id, title) VALUES ($1, $2) RETURNING id`,
+	}, sqls)
+	assert.Equal(t, []map[string]interface{}{{}, {"p1": int64(1)}}, params)
+}
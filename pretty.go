@@ -0,0 +1,52 @@
+package memeduck
+
+import (
+	"github.com/cloudspannerecosystem/memefish/ast"
+
+	"github.com/abyssparanoia/memeduck/internal"
+)
+
+// PrettySQL renders the SELECT statement as multi-line SQL for logging and code review,
+// with clauses on their own lines and list items indented.
+func (s *SelectStmt) PrettySQL() (string, error) {
+	stmt, err := s.toASTStatement()
+	if err != nil {
+		return "", err
+	}
+	return prettySQL(stmt, s.dialect)
+}
+
+// PrettySQL renders the INSERT statement as multi-line SQL, with VALUES rows on their own lines
+// and values of each column aligned.
+func (s *InsertStmt) PrettySQL() (string, error) {
+	stmt, err := s.toASTStatement()
+	if err != nil {
+		return "", err
+	}
+	return prettySQL(stmt, s.dialect)
+}
+
+// PrettySQL renders the UPDATE statement as multi-line SQL. See SelectStmt.PrettySQL.
+func (s *UpdateStmt) PrettySQL() (string, error) {
+	stmt, err := s.toASTStatement()
+	if err != nil {
+		return "", err
+	}
+	return prettySQL(stmt, s.dialect)
+}
+
+// PrettySQL renders the DELETE statement as multi-line SQL. See SelectStmt.PrettySQL.
+func (s *DeleteStmt) PrettySQL() (string, error) {
+	stmt, err := s.toASTStatement()
+	if err != nil {
+		return "", err
+	}
+	return prettySQL(stmt, s.dialect)
+}
+
+func prettySQL(stmt ast.Node, d Dialect) (string, error) {
+	if err := d.check(internal.FeaturePrettySQL, true); err != nil {
+		return "", err
+	}
+	return internal.Pretty(stmt), nil
+}
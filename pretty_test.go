@@ -0,0 +1,80 @@
+package memeduck_test
+
+import (
+	"testing"
+
+	"github.com/cloudspannerecosystem/memefish"
+	"github.com/cloudspannerecosystem/memefish/token"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/abyssparanoia/memeduck"
+)
+
+type prettyStmt interface {
+	SQL() (string, error)
+	PrettySQL() (string, error)
+}
+
+func testPrettySQL(t *testing.T, stmt prettyStmt, expected string) {
+	actual, err := stmt.PrettySQL()
+	assert.Nil(t, err, expected)
+	assert.Equal(t, expected, actual)
+
+	// The pretty SQL is the same statement as SQL.
+	p := &memefish.Parser{Lexer: &memefish.Lexer{File: &token.File{Buffer: actual}}}
+	parsed, err := p.ParseStatement()
+	assert.Nil(t, err)
+	sql, err := stmt.SQL()
+	assert.Nil(t, err)
+	assert.Equal(t, sql, parsed.SQL())
+}
+
+func TestPrettySQL(t *testing.T) {
+	testPrettySQL(t,
+		memeduck.Select("user", []string{"id", "name"}).
+			Where(
+				memeduck.Eq(memeduck.Ident("name"), "foo"),
+				memeduck.Or(memeduck.Gt(memeduck.Ident("age"), 20), memeduck.IsNull(memeduck.Ident("age"))),
+			).
+			OrderBy("id", memeduck.DESC).
+			Limit(10),
+		`SELECT
+  id,
+  name
+FROM user
+WHERE
+  name = "foo"
+  AND (age > 20 OR age IS NULL)
+ORDER BY id DESC
+LIMIT 10`,
+	)
+	testPrettySQL(t,
+		memeduck.Insert("user", []string{"id", "name"}).Values([][]interface{}{{1, "a"}, {100, "bcd"}}),
+		`INSERT INTO user (id, name)
+VALUES
+  (1,   "a"),
+  (100, "bcd")`,
+	)
+	testPrettySQL(t,
+		memeduck.Update("user").Set(memeduck.Ident("name"), "foo").Set(memeduck.Ident("age"), 20).Where(memeduck.Eq(memeduck.Ident("id"), 1)),
+		`UPDATE user
+SET
+  name = "foo",
+  age = 20
+WHERE
+  id = 1`,
+	)
+	testPrettySQL(t,
+		memeduck.Delete("user").Where(memeduck.Eq(memeduck.Ident("id"), 1)),
+		`DELETE FROM user
+WHERE
+  id = 1`,
+	)
+
+	sql, err := memeduck.Insert("user", []string{"id"}).Values([][]interface{}{{1}}).OrUpdate().ThenReturn("id").PrettySQL()
+	assert.Nil(t, err)
+	assert.Equal(t, "INSERT OR UPDATE INTO user (id)\nVALUES\n  (1)\nTHEN RETURN id", sql)
+
+	_, err = memeduck.Delete("user").Where(memeduck.Bool(true)).Dialect(memeduck.DialectPostgreSQL).PrettySQL()
+	assert.EqualError(t, err, "PrettySQL is not supported in PostgreSQL dialect")
+}
@@ -0,0 +1,26 @@
+package memeduck
+
+import (
+	"github.com/cloudspannerecosystem/memefish/ast"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/abyssparanoia/memeduck/internal"
+)
+
+// Proto messages and enums can be passed as values as they are.
+// A message is rendered as its serialized bytes cast to the message type, like `CAST(B"\b\x01" AS examples.Singer)`,
+// and an enum as its name cast to the enum type, like `CAST("ROCK" AS examples.Genre)`.
+
+// ProtoEnumNumberValue is a proto enum rendered by its number instead of its name.
+type ProtoEnumNumberValue struct {
+	e protoreflect.Enum
+}
+
+// ProtoEnumNumber wraps e to be rendered by its number like `CAST(1 AS examples.Genre)`.
+func ProtoEnumNumber(e protoreflect.Enum) *ProtoEnumNumberValue {
+	return &ProtoEnumNumberValue{e: e}
+}
+
+func (v *ProtoEnumNumberValue) ToASTExpr() (ast.Expr, error) {
+	return internal.ProtoEnumExpr(v.e, true), nil
+}
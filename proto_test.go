@@ -0,0 +1,33 @@
+package memeduck_test
+
+import (
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/abyssparanoia/memeduck"
+)
+
+func TestProto(t *testing.T) {
+	ts := timestamppb.New(time.Unix(1, 2))
+	testExpr(t, memeduck.ArrayOf("google.protobuf.Timestamp", ts), `ARRAY<google.protobuf.Timestamp>[CAST(B"\b\x01\x10\x02" AS google.protobuf.Timestamp)]`)
+	testInsert(t,
+		memeduck.Insert("hoge", []string{"a", "b"}).Values([][]interface{}{{ts, (*timestamppb.Timestamp)(nil)}}),
+		`INSERT INTO hoge (a, b) VALUES (CAST(B"\b\x01\x10\x02" AS google.protobuf.Timestamp), NULL)`,
+	)
+	testWhere(t,
+		memeduck.Eq(memeduck.Ident("type"), descriptorpb.FieldDescriptorProto_TYPE_STRING),
+		`type = CAST("TYPE_STRING" AS google.protobuf.FieldDescriptorProto.Type)`,
+	)
+	testWhere(t,
+		memeduck.Eq(memeduck.Ident("type"), memeduck.ProtoEnumNumber(descriptorpb.FieldDescriptorProto_TYPE_STRING)),
+		`type = CAST(9 AS google.protobuf.FieldDescriptorProto.Type)`,
+	)
+	testWhere(t,
+		memeduck.Eq(memeduck.Ident("type"), descriptorpb.FieldDescriptorProto_Type(100)),
+		`type = CAST(100 AS google.protobuf.FieldDescriptorProto.Type)`,
+	)
+	testExpr(t, memeduck.Cast(memeduck.Param("p"), "examples.music.Singer"), `CAST(@p AS examples.music.Singer)`)
+}
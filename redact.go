@@ -0,0 +1,99 @@
+package memeduck
+
+import (
+	"reflect"
+
+	"github.com/cloudspannerecosystem/memefish/ast"
+
+	"github.com/abyssparanoia/memeduck/internal"
+)
+
+// RedactedSQL renders the SELECT statement with literals replaced by `?`, so that it can be logged
+// without leaking values while still showing the shape of the query.
+// If sensitive columns are given, only literals compared with the columns are replaced.
+func (s *SelectStmt) RedactedSQL(sensitive ...string) (string, error) {
+	stmt, err := s.toASTStatement()
+	if err != nil {
+		return "", err
+	}
+	internal.Redact(stmt, sensitive)
+	return redactedSQL(stmt, s.dialect)
+}
+
+// RedactedSQL renders the INSERT statement with literals replaced by `?`.
+// If sensitive columns are given, only values inserted into the columns, or the columns tagged as PII
+// in struct values, are replaced.
+func (s *InsertStmt) RedactedSQL(sensitive ...string) (string, error) {
+	stmt, err := s.toASTStatement()
+	if err != nil {
+		return "", err
+	}
+	if len(sensitive) > 0 {
+		pii, err := s.piiColumns()
+		if err != nil {
+			return "", err
+		}
+		sensitive = append(pii, sensitive...)
+	}
+	internal.Redact(stmt, sensitive)
+	return redactedSQL(stmt, s.dialect)
+}
+
+// RedactedSQL renders the UPDATE statement with literals replaced by `?`.
+// If sensitive columns are given, only literals assigned to or compared with the columns,
+// or the columns tagged as PII in structs given to SetStruct, are replaced.
+func (s *UpdateStmt) RedactedSQL(sensitive ...string) (string, error) {
+	stmt, err := s.toASTStatement()
+	if err != nil {
+		return "", err
+	}
+	if len(sensitive) > 0 {
+		sensitive = append(append([]string(nil), s.pii...), sensitive...)
+	}
+	internal.Redact(stmt, sensitive)
+	return redactedSQL(stmt, s.dialect)
+}
+
+// RedactedSQL renders the DELETE statement with literals replaced by `?`.
+// If sensitive columns are given, only literals compared with the columns are replaced.
+func (s *DeleteStmt) RedactedSQL(sensitive ...string) (string, error) {
+	stmt, err := s.toASTStatement()
+	if err != nil {
+		return "", err
+	}
+	internal.Redact(stmt, sensitive)
+	return redactedSQL(stmt, s.dialect)
+}
+
+// redactedSQL renders the redacted statement in the dialect d.
+// In GoogleSQL dialect, it is rendered without verification, as `?` placeholders can't be parsed back.
+func redactedSQL(stmt ast.Node, d Dialect) (string, error) {
+	if d.orDefault() == DialectPostgreSQL {
+		sql, _, err := d.render(stmt)
+		return sql, err
+	}
+	return stmt.SQL(), nil
+}
+
+// piiColumns returns the columns tagged as PII in struct values of the INSERT statement.
+func (s *InsertStmt) piiColumns() ([]string, error) {
+	rowsV := reflect.ValueOf(s.values)
+	if rowsV.Kind() != reflect.Slice {
+		return nil, nil
+	}
+	var cols []string
+	seen := make(map[reflect.Type]bool)
+	for i := 0; i < rowsV.Len(); i++ {
+		rowV := reflect.Indirect(reflect.ValueOf(rowsV.Index(i).Interface()))
+		if rowV.Kind() != reflect.Struct || seen[rowV.Type()] {
+			continue
+		}
+		seen[rowV.Type()] = true
+		pii, err := piiColumns(rowV.Interface(), s.names)
+		if err != nil {
+			return nil, err
+		}
+		cols = append(cols, pii...)
+	}
+	return cols, nil
+}
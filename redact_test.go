@@ -0,0 +1,126 @@
+package memeduck_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/abyssparanoia/memeduck"
+)
+
+func TestRedactedSQL(t *testing.T) {
+	sel := memeduck.Select("users", []string{"id", "name"}).
+		Where(
+			memeduck.Eq(memeduck.Ident("email"), "foo@example.com"),
+			memeduck.In(memeduck.Ident("status"), memeduck.Unnest([]string{"active", "paused"})),
+			memeduck.Between(memeduck.Ident("age"), 20, 30),
+			memeduck.IsNull(memeduck.Ident("deleted_at")),
+		).
+		Hint("USE_ADDITIONAL_PARALLELISM", true).
+		Limit(10)
+	sql, err := sel.RedactedSQL()
+	assert.Nil(t, err)
+	assert.Equal(t, `@{USE_ADDITIONAL_PARALLELISM=TRUE} SELECT id, name FROM users WHERE email = ? AND status IN UNNEST(ARRAY[?, ?]) AND age BETWEEN ? AND ? AND deleted_at IS NULL LIMIT 10`, sql)
+	sql, err = sel.RedactedSQL("EMAIL")
+	assert.Nil(t, err)
+	assert.Equal(t, `@{USE_ADDITIONAL_PARALLELISM=TRUE} SELECT id, name FROM users WHERE email = ? AND status IN UNNEST(ARRAY["active", "paused"]) AND age BETWEEN 20 AND 30 AND deleted_at IS NULL LIMIT 10`, sql)
+
+	ins := memeduck.Insert("users", []string{"id", "email", "created_at"}).
+		Values([][]interface{}{
+			{1, "foo@example.com", time.Date(2021, 8, 9, 0, 0, 0, 0, time.UTC)},
+			{2, "bar@example.com", nil},
+		})
+	sql, err = ins.RedactedSQL()
+	assert.Nil(t, err)
+	assert.Equal(t, `INSERT INTO users (id, email, created_at) VALUES (?, ?, ?), (?, ?, NULL)`, sql)
+	sql, err = ins.RedactedSQL("email")
+	assert.Nil(t, err)
+	assert.Equal(t, `INSERT INTO users (id, email, created_at) VALUES (1, ?, TIMESTAMP "2021-08-09T00:00:00Z"), (2, ?, NULL)`, sql)
+
+	upd := memeduck.Update("users").
+		Set(memeduck.Ident("email"), "foo@example.com").
+		Set(memeduck.Ident("age"), 20).
+		Where(memeduck.Eq(memeduck.Ident("id"), 1))
+	sql, err = upd.RedactedSQL("email")
+	assert.Nil(t, err)
+	assert.Equal(t, `UPDATE users SET email = ?, age = 20 WHERE id = 1`, sql)
+
+	del := memeduck.Delete("users").Where(memeduck.Eq("foo@example.com", memeduck.Ident("email")))
+	sql, err = del.RedactedSQL("email")
+	assert.Nil(t, err)
+	assert.Equal(t, `DELETE FROM users WHERE ? = email`, sql)
+
+	// The statement itself is not modified.
+	sql, err = del.SQL()
+	assert.Nil(t, err)
+	assert.Equal(t, `DELETE FROM users WHERE "foo@example.com" = email`, sql)
+}
+
+type testPIIUser struct {
+	ID    int64  `spanner:"id"`
+	Email string `spanner:"email,pii"`
+	Name  string `spanner:"name, pii"`
+	Age   int64  `spanner:"age"`
+}
+
+func TestRedactedSQLWithPIITags(t *testing.T) {
+	cols, err := memeduck.PIIColumns(&testPIIUser{})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"email", "name"}, cols)
+	_, err = memeduck.PIIColumns(1)
+	assert.Error(t, err)
+
+	ins := memeduck.Insert("users", []string{"id", "email", "name", "age"}).
+		Values([]*testPIIUser{{ID: 1, Email: "foo@example.com", Name: "Foo", Age: 20}})
+	sql, err := ins.SQL()
+	assert.Nil(t, err)
+	assert.Equal(t, `INSERT INTO users (id, email, name, age) VALUES (1, "foo@example.com", "Foo", 20)`, sql)
+	sql, err = ins.RedactedSQL("age")
+	assert.Nil(t, err)
+	assert.Equal(t, `INSERT INTO users (id, email, name, age) VALUES (1, ?, ?, ?)`, sql)
+	sql, err = memeduck.Insert("users", []string{"id", "email", "name", "age"}).
+		Values([]*testPIIUser{{ID: 1, Email: "foo@example.com", Name: "Foo", Age: 20}, {ID: 2, Email: "bar@example.com", Name: "Bar", Age: 30}}).
+		IdempotencyKeyUnused("processed_event", "event_id", "e1").
+		RedactedSQL("age")
+	assert.Nil(t, err)
+	assert.Equal(t, `INSERT INTO users (id, email, name, age) SELECT * FROM (SELECT 1 AS id, ? AS email, ? AS name, ? AS age UNION ALL SELECT 2, ?, ?, ?) WHERE NOT EXISTS(SELECT event_id FROM processed_event WHERE event_id = "e1")`, sql)
+
+	sql, err = memeduck.Select("users", []string{"id"}).
+		Where(memeduck.Eq(memeduck.Ident("email"), "foo@example.com"), memeduck.Eq(memeduck.Ident("age"), 20)).
+		RedactedSQL(cols...)
+	assert.Nil(t, err)
+	assert.Equal(t, `SELECT id FROM users WHERE email = ? AND age = 20`, sql)
+
+	upd := memeduck.Update("users").
+		SetStruct(&testPIIUser{Email: "foo@example.com", Name: "Foo", Age: 20}, "email", "name", "age").
+		Set(memeduck.Ident("memo"), "x").
+		Where(memeduck.Eq(memeduck.Ident("id"), 1))
+	sql, err = upd.RedactedSQL("memo")
+	assert.Nil(t, err)
+	assert.Equal(t, `UPDATE users SET email = ?, name = ?, age = 20, memo = ? WHERE id = 1`, sql)
+	sql, err = upd.RedactedSQL()
+	assert.Nil(t, err)
+	assert.Equal(t, `UPDATE users SET email = ?, name = ?, age = ?, memo = ? WHERE id = ?`, sql)
+}
+
+func TestRedactedSQLPostgreSQL(t *testing.T) {
+	sql, err := memeduck.Select("users", []string{"id", "name"}).
+		Where(
+			memeduck.Eq(memeduck.Ident("email"), "foo@example.com"),
+			memeduck.Eq(memeduck.Ident("status"), memeduck.Param("status")),
+		).
+		Dialect(memeduck.DialectPostgreSQL).
+		RedactedSQL()
+	assert.Nil(t, err)
+	assert.Equal(t, `SELECT id, name FROM users WHERE email = ? AND status = $1`, sql)
+
+	sql, err = memeduck.Update("users").
+		Set(memeduck.Ident("email"), "foo@example.com").
+		Set(memeduck.Ident("name"), "foo").
+		Where(memeduck.Eq(memeduck.Ident("id"), 1)).
+		Dialect(memeduck.DialectPostgreSQL).
+		RedactedSQL("email")
+	assert.Nil(t, err)
+	assert.Equal(t, `UPDATE users SET email = ?, name = 'foo' WHERE id = 1`, sql)
+}
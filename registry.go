@@ -0,0 +1,98 @@
+package memeduck
+
+import (
+	"reflect"
+	"sync"
+
+	"github.com/cloudspannerecosystem/memefish/ast"
+	"github.com/pkg/errors"
+
+	"github.com/abyssparanoia/memeduck/internal"
+)
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]reflect.Value)
+
+	astExprType = reflect.TypeOf((*internal.ASTExpr)(nil)).Elem()
+	errorType   = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+// RegisterFunc registers fn as a custom expression constructor named name, which can be called by CallFunc.
+// fn must be a function returning a value with ToASTExpr method, optionally with an error as the second result.
+// It returns an error if fn has a wrong signature or name is already registered.
+// It is safe to be called concurrently.
+func RegisterFunc(name string, fn interface{}) error {
+	fnV := reflect.ValueOf(fn)
+	if fnV.Kind() != reflect.Func {
+		return errors.Errorf("%T is not a function", fn)
+	}
+	fnT := fnV.Type()
+	if fnT.NumOut() < 1 || 2 < fnT.NumOut() ||
+		!fnT.Out(0).Implements(astExprType) ||
+		(fnT.NumOut() == 2 && fnT.Out(1) != errorType) {
+		return errors.Errorf("%s must return an expression and optionally an error", fnT.String())
+	}
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if _, ok := registry[name]; ok {
+		return errors.Errorf("function %s is already registered", name)
+	}
+	registry[name] = fnV
+	return nil
+}
+
+// RegisteredFuncExpr is a call of a custom expression constructor registered by RegisterFunc.
+type RegisteredFuncExpr struct {
+	name string
+	args []interface{}
+}
+
+// CallFunc creates a call of the custom expression constructor named name with args.
+// The constructor is looked up and called when the expression is converted into SQL.
+func CallFunc(name string, args ...interface{}) *RegisteredFuncExpr {
+	return &RegisteredFuncExpr{name: name, args: args}
+}
+
+func (e *RegisteredFuncExpr) ToASTExpr() (ast.Expr, error) {
+	registryMu.RLock()
+	fnV, ok := registry[e.name]
+	registryMu.RUnlock()
+	if !ok {
+		return nil, errors.Errorf("function %s is not registered", e.name)
+	}
+	fnT := fnV.Type()
+	if (!fnT.IsVariadic() && len(e.args) != fnT.NumIn()) || (fnT.IsVariadic() && len(e.args) < fnT.NumIn()-1) {
+		return nil, errors.Errorf("function %s takes %d arguments but %d given", e.name, fnT.NumIn(), len(e.args))
+	}
+	in := make([]reflect.Value, 0, len(e.args))
+	for i, arg := range e.args {
+		var argT reflect.Type
+		if fnT.IsVariadic() && i >= fnT.NumIn()-1 {
+			argT = fnT.In(fnT.NumIn() - 1).Elem()
+		} else {
+			argT = fnT.In(i)
+		}
+		if arg == nil {
+			switch argT.Kind() {
+			case reflect.Interface, reflect.Ptr, reflect.Slice, reflect.Map:
+				in = append(in, reflect.Zero(argT))
+				continue
+			}
+			return nil, errors.Errorf("argument %d of function %s can't be nil", i, e.name)
+		}
+		argV := reflect.ValueOf(arg)
+		if !argV.Type().AssignableTo(argT) {
+			return nil, errors.Errorf("argument %d of function %s must be %s, but got %T", i, e.name, argT.String(), arg)
+		}
+		in = append(in, argV)
+	}
+	out := fnV.Call(in)
+	if len(out) == 2 && !out[1].IsNil() {
+		return nil, out[1].Interface().(error)
+	}
+	if k := out[0].Kind(); (k == reflect.Ptr || k == reflect.Interface) && out[0].IsNil() {
+		return nil, errors.Errorf("function %s returned nil", e.name)
+	}
+	return out[0].Interface().(internal.ASTExpr).ToASTExpr()
+}
@@ -0,0 +1,49 @@
+package memeduck_test
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/abyssparanoia/memeduck"
+)
+
+func TestRegisterFunc(t *testing.T) {
+	assert.Nil(t, memeduck.RegisterFunc("test_normalize_email", func(x interface{}) *memeduck.FuncExpr {
+		return memeduck.Func("LOWER", memeduck.Func("TRIM", x))
+	}))
+	assert.Nil(t, memeduck.RegisterFunc("test_tenant_scope", func(tenant string, cols ...string) (*memeduck.FuncExpr, error) {
+		if tenant == "" {
+			return nil, errors.New("empty tenant")
+		}
+		args := []interface{}{tenant}
+		for _, c := range cols {
+			args = append(args, memeduck.Ident(c))
+		}
+		return memeduck.Func("CONCAT", args...), nil
+	}))
+
+	assert.Error(t, memeduck.RegisterFunc("test_normalize_email", func() *memeduck.FuncExpr { return nil }), "already registered")
+	assert.Error(t, memeduck.RegisterFunc("test_not_func", 1))
+	assert.Error(t, memeduck.RegisterFunc("test_bad_result", func() string { return "" }))
+	assert.Error(t, memeduck.RegisterFunc("test_bad_error", func() (*memeduck.FuncExpr, string) { return nil, "" }))
+
+	testWhere(t,
+		memeduck.Eq(memeduck.CallFunc("test_normalize_email", memeduck.Ident("email")), memeduck.Param("email")),
+		`LOWER(TRIM(email)) = @email`,
+	)
+	testExpr(t, memeduck.CallFunc("test_tenant_scope", "t1", "a", "b"), `CONCAT("t1", a, b)`)
+	testExpr(t, memeduck.CallFunc("test_tenant_scope", "t1"), `CONCAT("t1")`)
+
+	for _, e := range []*memeduck.RegisteredFuncExpr{
+		memeduck.CallFunc("test_unknown"),
+		memeduck.CallFunc("test_normalize_email"),
+		memeduck.CallFunc("test_tenant_scope", 1),
+		memeduck.CallFunc("test_tenant_scope", ""),
+		memeduck.CallFunc("test_tenant_scope", nil),
+	} {
+		_, err := e.ToASTExpr()
+		assert.Error(t, err)
+	}
+}
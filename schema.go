@@ -0,0 +1,408 @@
+package memeduck
+
+import (
+	"context"
+	"strings"
+
+	"cloud.google.com/go/spanner"
+	"github.com/cloudspannerecosystem/memefish/ast"
+	"github.com/pkg/errors"
+
+	"github.com/abyssparanoia/memeduck/internal"
+)
+
+// ParseSchema parses CREATE TABLE and CREATE INDEX statements in ddl into tables. Other DDL statements are ignored.
+func ParseSchema(ddl string) ([]*Table, error) {
+	ddls, err := internal.ParseDDLs(ddl)
+	if err != nil {
+		return nil, errors.WithMessage(err, "can't parse schema")
+	}
+	var tables []*Table
+	var indexes []*ast.CreateIndex
+	for _, d := range ddls {
+		switch d := d.(type) {
+		case *ast.CreateTable:
+			tables = append(tables, fromASTCreateTable(d))
+		case *ast.CreateIndex:
+			indexes = append(indexes, d)
+		}
+	}
+	for _, ci := range indexes {
+		t := findTable(tables, ci.TableName.Name)
+		if t == nil {
+			return nil, errors.Errorf("index %s is on unknown table %s", ci.Name.Name, ci.TableName.Name)
+		}
+		t.Indexes = append(t.Indexes, fromASTCreateIndex(ci))
+	}
+	return tables, nil
+}
+
+func fromASTCreateTable(ct *ast.CreateTable) *Table {
+	t := &Table{Name: ct.Name.Name}
+	for _, c := range ct.Columns {
+		col := &Column{Name: c.Name.Name, Type: c.Type.SQL(), NotNull: c.NotNull}
+		if c.DefaultExpr != nil {
+			col.Default = c.DefaultExpr.Expr.SQL()
+		}
+		if c.Options != nil {
+			col.AllowCommitTimestamp = c.Options.AllowCommitTimestamp
+		}
+		t.Columns = append(t.Columns, col)
+	}
+	for _, k := range ct.PrimaryKeys {
+		t.PrimaryKey = append(t.PrimaryKey, &KeyPart{Column: k.Name.Name, Dir: Direction(k.Dir)})
+	}
+	if ct.Cluster != nil {
+		t.Parent = ct.Cluster.TableName.Name
+		t.OnDelete = OnDeleteAction(ct.Cluster.OnDelete)
+	}
+	return t
+}
+
+func fromASTCreateIndex(ci *ast.CreateIndex) *Index {
+	idx := &Index{Name: ci.Name.Name, Unique: ci.Unique, NullFiltered: ci.NullFiltered}
+	for _, k := range ci.Keys {
+		idx.Keys = append(idx.Keys, &KeyPart{Column: k.Name.Name, Dir: Direction(k.Dir)})
+	}
+	if ci.Storing != nil {
+		for _, c := range ci.Storing.Columns {
+			idx.Storing = append(idx.Storing, c.Name)
+		}
+	}
+	if ci.InterleaveIn != nil {
+		idx.InterleaveIn = ci.InterleaveIn.TableName.Name
+	}
+	return idx
+}
+
+// Schema is a set of tables which statements can be validated against by their Validate methods.
+type Schema struct {
+	tables map[string]*Table
+}
+
+// NewSchema creates a new Schema of tables.
+func NewSchema(tables ...*Table) *Schema {
+	s := &Schema{tables: make(map[string]*Table, len(tables))}
+	for _, t := range tables {
+		s.tables[strings.ToLower(t.Name)] = t
+	}
+	return s
+}
+
+// SchemaFromDDL creates a new Schema from CREATE TABLE statements in ddl. See ParseSchema.
+func SchemaFromDDL(ddl string) (*Schema, error) {
+	tables, err := ParseSchema(ddl)
+	if err != nil {
+		return nil, err
+	}
+	return NewSchema(tables...), nil
+}
+
+const (
+	informationSchemaColumnsSQL = "SELECT TABLE_NAME, COLUMN_NAME, SPANNER_TYPE, IS_NULLABLE FROM INFORMATION_SCHEMA.COLUMNS " +
+		"WHERE TABLE_CATALOG = '' AND TABLE_SCHEMA = '' ORDER BY TABLE_NAME, ORDINAL_POSITION"
+	informationSchemaKeysSQL = "SELECT TABLE_NAME, COLUMN_NAME, COLUMN_ORDERING FROM INFORMATION_SCHEMA.INDEX_COLUMNS " +
+		"WHERE TABLE_CATALOG = '' AND TABLE_SCHEMA = '' AND INDEX_TYPE = 'PRIMARY_KEY' ORDER BY TABLE_NAME, ORDINAL_POSITION"
+)
+
+// LoadSchema creates a new Schema from INFORMATION_SCHEMA of the database of client.
+func LoadSchema(ctx context.Context, client *spanner.Client) (*Schema, error) {
+	txn := client.ReadOnlyTransaction()
+	defer txn.Close()
+	var tables []*Table
+	err := txn.Query(ctx, spanner.Statement{SQL: informationSchemaColumnsSQL}).Do(func(r *spanner.Row) error {
+		var table, col, typ, nullable string
+		if err := r.Columns(&table, &col, &typ, &nullable); err != nil {
+			return err
+		}
+		t := findTable(tables, table)
+		if t == nil {
+			t = &Table{Name: table}
+			tables = append(tables, t)
+		}
+		t.Columns = append(t.Columns, &Column{Name: col, Type: typ, NotNull: nullable == "NO"})
+		return nil
+	})
+	if err != nil {
+		return nil, errors.WithMessage(err, "can't load columns")
+	}
+	err = txn.Query(ctx, spanner.Statement{SQL: informationSchemaKeysSQL}).Do(func(r *spanner.Row) error {
+		var table, col string
+		var ordering spanner.NullString
+		if err := r.Columns(&table, &col, &ordering); err != nil {
+			return err
+		}
+		if t := findTable(tables, table); t != nil {
+			t.PrimaryKey = append(t.PrimaryKey, &KeyPart{Column: col, Dir: Direction(ordering.StringVal)})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.WithMessage(err, "can't load primary keys")
+	}
+	return NewSchema(tables...), nil
+}
+
+// Table returns the table named name, or nil if the schema has no such table.
+// Names are compared case-insensitively as Spanner does.
+func (s *Schema) Table(name string) *Table {
+	return s.tables[strings.ToLower(name)]
+}
+
+// DiffSchema returns the DDL statements migrating the schema from the tables from to the tables to.
+// Removed and changed indexes are dropped first, then new tables are created with parents before their children,
+// columns are added, altered and dropped, new indexes are created, and removed tables are dropped last,
+// with children before their parents. Names are compared case-insensitively as Spanner does.
+// It returns an error for changes Spanner can't apply, such as changing a primary key or a parent table.
+func DiffSchema(from, to []*Table) ([]string, error) {
+	var dropIndexes, creates, alters, createIndexes, drops []string
+	for _, t := range orderByParent(to) {
+		old := findTable(from, t.Name)
+		if old == nil {
+			ct, err := t.toASTCreateTable()
+			if err != nil {
+				return nil, err
+			}
+			creates = append(creates, ct.SQL())
+			for _, idx := range t.Indexes {
+				createIndexes = append(createIndexes, idx.toASTCreateIndex(t.Name).SQL())
+			}
+			continue
+		}
+		stmts, err := diffTable(old, t)
+		if err != nil {
+			return nil, err
+		}
+		alters = append(alters, stmts...)
+		for _, idx := range old.Indexes {
+			if newIdx := findIndex(t.Indexes, idx.Name); newIdx == nil || !sameIndex(idx, newIdx) {
+				dropIndexes = append(dropIndexes, (&ast.DropIndex{Name: &ast.Ident{Name: idx.Name}}).SQL())
+			}
+		}
+		for _, idx := range t.Indexes {
+			if oldIdx := findIndex(old.Indexes, idx.Name); oldIdx == nil || !sameIndex(oldIdx, idx) {
+				createIndexes = append(createIndexes, idx.toASTCreateIndex(t.Name).SQL())
+			}
+		}
+	}
+	// Tables are dropped in reverse order, so that interleaved tables are dropped before their parents.
+	ordered := orderByParent(from)
+	for i := len(ordered) - 1; i >= 0; i-- {
+		t := ordered[i]
+		if findTable(to, t.Name) != nil {
+			continue
+		}
+		// Indexes must be dropped before the table.
+		for _, idx := range t.Indexes {
+			drops = append(drops, (&ast.DropIndex{Name: &ast.Ident{Name: idx.Name}}).SQL())
+		}
+		drops = append(drops, (&ast.DropTable{Name: &ast.Ident{Name: t.Name}}).SQL())
+	}
+	var stmts []string
+	for _, ss := range [][]string{dropIndexes, creates, alters, createIndexes, drops} {
+		stmts = append(stmts, ss...)
+	}
+	return stmts, nil
+}
+
+func findTable(tables []*Table, name string) *Table {
+	for _, t := range tables {
+		if strings.EqualFold(t.Name, name) {
+			return t
+		}
+	}
+	return nil
+}
+
+func findIndex(indexes []*Index, name string) *Index {
+	for _, idx := range indexes {
+		if strings.EqualFold(idx.Name, name) {
+			return idx
+		}
+	}
+	return nil
+}
+
+// orderByParent returns tables ordered so that parents precede their children, keeping the order otherwise.
+func orderByParent(tables []*Table) []*Table {
+	ordered := make([]*Table, 0, len(tables))
+	added := make(map[*Table]bool, len(tables))
+	var add func(t *Table)
+	add = func(t *Table) {
+		if added[t] {
+			return
+		}
+		added[t] = true
+		if p := findTable(tables, t.Parent); t.Parent != "" && p != nil {
+			add(p)
+		}
+		ordered = append(ordered, t)
+	}
+	for _, t := range tables {
+		add(t)
+	}
+	return ordered
+}
+
+func diffTable(from, to *Table) ([]string, error) {
+	if !sameKeys(from.PrimaryKeyOrder(), to.PrimaryKeyOrder()) {
+		return nil, errors.Errorf("primary key of table %s can't be changed", to.Name)
+	}
+	if !strings.EqualFold(from.Parent, to.Parent) {
+		return nil, errors.Errorf("parent table of table %s can't be changed", to.Name)
+	}
+	name := &ast.Ident{Name: to.Name}
+	var stmts []string
+	if to.Parent != "" && onDeleteOrDefault(from.OnDelete) != onDeleteOrDefault(to.OnDelete) {
+		stmts = append(stmts, (&ast.AlterTable{Name: name, TableAlteration: &ast.SetOnDelete{
+			OnDelete: ast.OnDeleteAction(onDeleteOrDefault(to.OnDelete)),
+		}}).SQL())
+	}
+	for _, c := range to.Columns {
+		def, err := c.toASTColumnDef()
+		if err != nil {
+			return nil, errors.WithMessagef(err, "in table %s", to.Name)
+		}
+		old := findColumn(from, c.Name)
+		if old == nil {
+			stmts = append(stmts, (&ast.AlterTable{Name: name, TableAlteration: &ast.AddColumn{Column: def}}).SQL())
+			continue
+		}
+		oldDef, err := old.toASTColumnDef()
+		if err != nil {
+			return nil, errors.WithMessagef(err, "in table %s", from.Name)
+		}
+		if oldDef.Type.SQL() != def.Type.SQL() || old.NotNull != c.NotNull {
+			stmts = append(stmts, (&ast.AlterTable{Name: name, TableAlteration: &ast.AlterColumn{
+				Name:        def.Name,
+				Type:        def.Type,
+				NotNull:     def.NotNull,
+				DefaultExpr: def.DefaultExpr,
+			}}).SQL())
+		} else if def.DefaultExpr != nil && (oldDef.DefaultExpr == nil || oldDef.DefaultExpr.SQL() != def.DefaultExpr.SQL()) {
+			stmts = append(stmts, (&ast.AlterTable{Name: name, TableAlteration: &ast.AlterColumnSet{
+				Name:        def.Name,
+				DefaultExpr: def.DefaultExpr,
+			}}).SQL())
+		}
+		if oldDef.DefaultExpr != nil && def.DefaultExpr == nil {
+			// memefish has no node for DROP DEFAULT.
+			stmts = append(stmts, "ALTER TABLE "+name.SQL()+" ALTER COLUMN "+def.Name.SQL()+" DROP DEFAULT")
+		}
+		if old.AllowCommitTimestamp != c.AllowCommitTimestamp {
+			stmts = append(stmts, (&ast.AlterTable{Name: name, TableAlteration: &ast.AlterColumnSet{
+				Name:    def.Name,
+				Options: &ast.ColumnDefOptions{AllowCommitTimestamp: c.AllowCommitTimestamp},
+			}}).SQL())
+		}
+	}
+	for _, c := range from.Columns {
+		if findColumn(to, c.Name) == nil {
+			stmts = append(stmts, (&ast.AlterTable{Name: name, TableAlteration: &ast.DropColumn{Name: &ast.Ident{Name: c.Name}}}).SQL())
+		}
+	}
+	return stmts, nil
+}
+
+func onDeleteOrDefault(a OnDeleteAction) OnDeleteAction {
+	if a == "" {
+		return OnDeleteNoAction
+	}
+	return a
+}
+
+func sameIndex(a, b *Index) bool {
+	if a.Unique != b.Unique || a.NullFiltered != b.NullFiltered || !strings.EqualFold(a.InterleaveIn, b.InterleaveIn) ||
+		len(a.Keys) != len(b.Keys) || len(a.Storing) != len(b.Storing) {
+		return false
+	}
+	for i := range a.Keys {
+		if !strings.EqualFold(a.Keys[i].Column, b.Keys[i].Column) || directionOrDefault(a.Keys[i].Dir) != directionOrDefault(b.Keys[i].Dir) {
+			return false
+		}
+	}
+	for i := range a.Storing {
+		if !strings.EqualFold(a.Storing[i], b.Storing[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func directionOrDefault(d Direction) Direction {
+	if d == "" {
+		return ASC
+	}
+	return d
+}
+
+func sameKeys(a, b []*KeyPart) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !strings.EqualFold(a[i].Column, b[i].Column) || a[i].Dir != b[i].Dir {
+			return false
+		}
+	}
+	return true
+}
+
+func (t *Table) toASTCreateTable() (*ast.CreateTable, error) {
+	ct := &ast.CreateTable{Name: &ast.Ident{Name: t.Name}}
+	for _, c := range t.Columns {
+		def, err := c.toASTColumnDef()
+		if err != nil {
+			return nil, errors.WithMessagef(err, "in table %s", t.Name)
+		}
+		ct.Columns = append(ct.Columns, def)
+	}
+	for _, k := range t.PrimaryKey {
+		ct.PrimaryKeys = append(ct.PrimaryKeys, &ast.IndexKey{Name: &ast.Ident{Name: k.Column}, Dir: ast.Direction(k.Dir)})
+	}
+	if t.Parent != "" {
+		ct.Cluster = &ast.Cluster{TableName: &ast.Ident{Name: t.Parent}, OnDelete: ast.OnDeleteAction(t.OnDelete)}
+	}
+	return ct, nil
+}
+
+func (c *Column) toASTColumnDef() (*ast.ColumnDef, error) {
+	typ, err := internal.ParseSchemaType(c.Type)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "column %s", c.Name)
+	}
+	def := &ast.ColumnDef{Name: &ast.Ident{Name: c.Name}, Type: typ, NotNull: c.NotNull}
+	if c.Default != "" {
+		expr, err := internal.ParseExpr(c.Default)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "default of column %s", c.Name)
+		}
+		def.DefaultExpr = &ast.ColumnDefaultExpr{Expr: expr}
+	}
+	if c.AllowCommitTimestamp {
+		def.Options = &ast.ColumnDefOptions{AllowCommitTimestamp: true}
+	}
+	return def, nil
+}
+
+func (idx *Index) toASTCreateIndex(table string) *ast.CreateIndex {
+	ci := &ast.CreateIndex{
+		Name:         &ast.Ident{Name: idx.Name},
+		TableName:    &ast.Ident{Name: table},
+		Unique:       idx.Unique,
+		NullFiltered: idx.NullFiltered,
+	}
+	for _, k := range idx.Keys {
+		ci.Keys = append(ci.Keys, &ast.IndexKey{Name: &ast.Ident{Name: k.Column}, Dir: ast.Direction(k.Dir)})
+	}
+	if len(idx.Storing) > 0 {
+		ci.Storing = &ast.Storing{}
+		for _, c := range idx.Storing {
+			ci.Storing.Columns = append(ci.Storing.Columns, &ast.Ident{Name: c})
+		}
+	}
+	if idx.InterleaveIn != "" {
+		ci.InterleaveIn = &ast.InterleaveIn{TableName: &ast.Ident{Name: idx.InterleaveIn}}
+	}
+	return ci
+}
@@ -0,0 +1,160 @@
+package memeduck_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/abyssparanoia/memeduck"
+)
+
+func TestParseSchema(t *testing.T) {
+	tables, err := memeduck.ParseSchema(`
+		CREATE TABLE user (
+			id STRING(36) NOT NULL,
+			name string(max),
+			tags ARRAY<STRING(16)>,
+			score INT64 NOT NULL DEFAULT (0),
+			updated_at TIMESTAMP OPTIONS (allow_commit_timestamp = true),
+		) PRIMARY KEY (id);
+		CREATE TABLE user_item (user_id STRING(36) NOT NULL, item_id INT64 NOT NULL) PRIMARY KEY (user_id, item_id),
+			INTERLEAVE IN PARENT user ON DELETE CASCADE;
+		CREATE INDEX user_name ON user (name);
+		CREATE UNIQUE NULL_FILTERED INDEX user_item_by_item ON user_item (user_id, item_id DESC) STORING (user_id), INTERLEAVE IN user;
+	`)
+	assert.Nil(t, err)
+	assert.Equal(t, []*memeduck.Table{
+		{
+			Name: "user",
+			Columns: []*memeduck.Column{
+				{Name: "id", Type: "STRING(36)", NotNull: true},
+				{Name: "name", Type: "STRING(MAX)"},
+				{Name: "tags", Type: "ARRAY<STRING(16)>"},
+				{Name: "score", Type: "INT64", NotNull: true, Default: "0"},
+				{Name: "updated_at", Type: "TIMESTAMP", AllowCommitTimestamp: true},
+			},
+			PrimaryKey: []*memeduck.KeyPart{{Column: "id"}},
+			Indexes:    []*memeduck.Index{{Name: "user_name", Keys: []*memeduck.KeyPart{{Column: "name"}}}},
+		},
+		{
+			Name: "user_item",
+			Columns: []*memeduck.Column{
+				{Name: "user_id", Type: "STRING(36)", NotNull: true},
+				{Name: "item_id", Type: "INT64", NotNull: true},
+			},
+			PrimaryKey: []*memeduck.KeyPart{{Column: "user_id"}, {Column: "item_id"}},
+			Parent:     "user",
+			OnDelete:   memeduck.OnDeleteCascade,
+			Indexes: []*memeduck.Index{{
+				Name:         "user_item_by_item",
+				Keys:         []*memeduck.KeyPart{{Column: "user_id"}, {Column: "item_id", Dir: memeduck.DESC}},
+				Storing:      []string{"user_id"},
+				Unique:       true,
+				NullFiltered: true,
+				InterleaveIn: "user",
+			}},
+		},
+	}, tables)
+	_, err = memeduck.ParseSchema(`CREATE TABLE`)
+	assert.Error(t, err)
+	_, err = memeduck.ParseSchema(`CREATE INDEX a ON nope (a)`)
+	assert.EqualError(t, err, "index a is on unknown table nope")
+}
+
+func TestDiffSchema(t *testing.T) {
+	from, err := memeduck.ParseSchema(`
+		CREATE TABLE user (id STRING(36) NOT NULL, name STRING(MAX), age INT64) PRIMARY KEY (id);
+		CREATE TABLE user_log (id STRING(36) NOT NULL, seq INT64 NOT NULL) PRIMARY KEY (id, seq DESC);
+		CREATE TABLE legacy (id INT64 NOT NULL) PRIMARY KEY (id);
+	`)
+	assert.Nil(t, err)
+	to := []*memeduck.Table{
+		{
+			Name: "user",
+			Columns: []*memeduck.Column{
+				{Name: "id", Type: "STRING(36)", NotNull: true},
+				{Name: "name", Type: "STRING(255)", NotNull: true},
+				{Name: "email", Type: "STRING(MAX)"},
+			},
+			PrimaryKey: []*memeduck.KeyPart{{Column: "id", Dir: memeduck.ASC}},
+		},
+		{
+			Name:       "user_log",
+			Columns:    []*memeduck.Column{{Name: "id", Type: "string(36)", NotNull: true}, {Name: "seq", Type: "INT64", NotNull: true}},
+			PrimaryKey: []*memeduck.KeyPart{{Column: "ID"}, {Column: "Seq", Dir: memeduck.DESC}},
+		},
+		{
+			Name:       "item",
+			Columns:    []*memeduck.Column{{Name: "id", Type: "INT64", NotNull: true}, {Name: "data", Type: "JSON"}},
+			PrimaryKey: []*memeduck.KeyPart{{Column: "id"}},
+		},
+	}
+	ddls, err := memeduck.DiffSchema(from, to)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{
+		`CREATE TABLE item (id INT64 NOT NULL, data JSON) PRIMARY KEY (id)`,
+		`ALTER TABLE user ALTER COLUMN name STRING(255) NOT NULL`,
+		`ALTER TABLE user ADD COLUMN email STRING(MAX)`,
+		`ALTER TABLE user DROP COLUMN age`,
+		`DROP TABLE legacy`,
+	}, ddls)
+
+	ddls, err = memeduck.DiffSchema(to, to)
+	assert.Nil(t, err)
+	assert.Empty(t, ddls)
+
+	changed := []*memeduck.Table{{Name: "user", Columns: to[0].Columns, PrimaryKey: []*memeduck.KeyPart{{Column: "email"}}}}
+	_, err = memeduck.DiffSchema(to, changed)
+	assert.EqualError(t, err, "primary key of table user can't be changed")
+	_, err = memeduck.DiffSchema(nil, []*memeduck.Table{{Name: "t", Columns: []*memeduck.Column{{Name: "a", Type: "NOPE"}}}})
+	assert.Error(t, err)
+}
+
+func TestDiffSchemaInterleaveAndIndexes(t *testing.T) {
+	from, err := memeduck.ParseSchema(`
+		CREATE TABLE User (id STRING(36) NOT NULL, name STRING(MAX), score INT64 DEFAULT (0), seen_at TIMESTAMP) PRIMARY KEY (id);
+		CREATE INDEX user_name ON User (name);
+		CREATE INDEX user_score ON User (score);
+		CREATE TABLE legacy (id INT64 NOT NULL) PRIMARY KEY (id);
+		CREATE TABLE legacy_child (id INT64 NOT NULL, seq INT64 NOT NULL) PRIMARY KEY (id, seq), INTERLEAVE IN PARENT legacy;
+		CREATE INDEX legacy_child_seq ON legacy_child (seq);
+	`)
+	assert.Nil(t, err)
+	to, err := memeduck.ParseSchema(`
+		CREATE TABLE user_item_log (user_id STRING(36) NOT NULL, item_id INT64 NOT NULL, seq INT64 NOT NULL)
+			PRIMARY KEY (user_id, item_id, seq), INTERLEAVE IN PARENT user_item ON DELETE CASCADE;
+		CREATE TABLE user_item (user_id STRING(36) NOT NULL, item_id INT64 NOT NULL DEFAULT (1))
+			PRIMARY KEY (user_id, item_id), INTERLEAVE IN PARENT user ON DELETE CASCADE;
+		CREATE TABLE user (id STRING(36) NOT NULL, name STRING(MAX), score INT64, seen_at TIMESTAMP OPTIONS (allow_commit_timestamp = true)) PRIMARY KEY (id);
+		CREATE INDEX user_name ON user (name DESC);
+		CREATE INDEX user_score ON user (score);
+		CREATE INDEX user_item_by_item ON user_item (item_id) STORING (user_id);
+	`)
+	assert.Nil(t, err)
+	ddls, err := memeduck.DiffSchema(from, to)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{
+		`DROP INDEX user_name`,
+		`CREATE TABLE user_item (user_id STRING(36) NOT NULL, item_id INT64 NOT NULL DEFAULT (1)) PRIMARY KEY (user_id, item_id), INTERLEAVE IN PARENT user ON DELETE CASCADE`,
+		`CREATE TABLE user_item_log (user_id STRING(36) NOT NULL, item_id INT64 NOT NULL, seq INT64 NOT NULL) PRIMARY KEY (user_id, item_id, seq), INTERLEAVE IN PARENT user_item ON DELETE CASCADE`,
+		`ALTER TABLE user ALTER COLUMN score DROP DEFAULT`,
+		`ALTER TABLE user ALTER COLUMN seen_at SET OPTIONS(allow_commit_timestamp = true)`,
+		`CREATE INDEX user_name ON user (name DESC)`,
+		`CREATE INDEX user_item_by_item ON user_item (item_id) STORING (user_id)`,
+		`DROP INDEX legacy_child_seq`,
+		`DROP TABLE legacy_child`,
+		`DROP TABLE legacy`,
+	}, ddls)
+
+	ddls, err = memeduck.DiffSchema(to, to)
+	assert.Nil(t, err)
+	assert.Empty(t, ddls)
+
+	moved := []*memeduck.Table{to[0], {Name: "user_item", Columns: to[1].Columns, PrimaryKey: to[1].PrimaryKey, Parent: "user", OnDelete: memeduck.OnDeleteNoAction, Indexes: to[1].Indexes}, to[2]}
+	ddls, err = memeduck.DiffSchema(to, moved)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{`ALTER TABLE user_item SET ON DELETE NO ACTION`}, ddls)
+	moved[1] = &memeduck.Table{Name: "user_item", Columns: to[1].Columns, PrimaryKey: to[1].PrimaryKey}
+	_, err = memeduck.DiffSchema(to, moved)
+	assert.EqualError(t, err, "parent table of table user_item can't be changed")
+}
@@ -1,6 +1,7 @@
 package memeduck_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -166,3 +167,152 @@ func TestSelectWithSubQuery(t *testing.T) {
 		`SELECT a, b, ARRAY(SELECT AS STRUCT c, d FROM fuga WHERE 3 = 4) AS fuga FROM hoge WHERE 1 = 2`,
 	)
 }
+
+func TestSelectWithTotal(t *testing.T) {
+	page, total := memeduck.Select("hoge", []string{"a", "b"}).
+		Where(memeduck.Eq(memeduck.Ident("a"), 1)).
+		ForceIndex("idx_a").
+		OrderBy("b", memeduck.DESC).
+		LimitOffset(10, 20).
+		WithTotal()
+	testSelect(t, page, `SELECT a, b FROM hoge @{FORCE_INDEX=idx_a} WHERE a = 1 ORDER BY b DESC LIMIT 10 OFFSET 20`)
+	testSelect(t, total, `SELECT COUNT(*) FROM hoge @{FORCE_INDEX=idx_a} WHERE a = 1`)
+
+	page, total = memeduck.Select("hoge", []string{"a"}).Limit(5).WithTotal()
+	testSelect(t, page, `SELECT a FROM hoge LIMIT 5`)
+	testSelect(t, total, `SELECT COUNT(*) FROM hoge`)
+
+	_, total = memeduck.SelectFromUnnest(memeduck.Param("ids"), "id", []string{"id"}).
+		Hint("USE_ADDITIONAL_PARALLELISM", true).
+		OrderBy("id", memeduck.ASC).
+		WithTotal()
+	testSelect(t, total, `@{USE_ADDITIONAL_PARALLELISM=TRUE} SELECT COUNT(*) FROM UNNEST(@ids) AS id`)
+
+	_, total = memeduck.Select("hoge", []string{"a"}).
+		Where(memeduck.Eq(memeduck.Ident("a"), memeduck.Param("a"))).
+		Dialect(memeduck.DialectPostgreSQL).
+		Limit(5).
+		WithTotal()
+	testSelect(t, total, `SELECT COUNT(*) FROM hoge WHERE a = $1`)
+}
+
+func TestSelectFromUnnest(t *testing.T) {
+	testSelect(t,
+		memeduck.SelectFromUnnest(memeduck.Param("ids"), "id", []string{"id"}),
+		`SELECT id FROM UNNEST(@ids) AS id`,
+	)
+	testSelect(t,
+		memeduck.SelectFromUnnest([]int64{1, 2}, "", []string{"COUNT(*)"}),
+		`SELECT COUNT(*) FROM UNNEST(ARRAY[1, 2])`,
+	)
+	testSelect(t,
+		memeduck.SelectFromUnnest(memeduck.Ident("tags"), "tag", []string{"tag", "pos"}).
+			WithOffset("pos").
+			Where(memeduck.Ne(memeduck.Ident("tag"), "")).
+			OrderBy("pos", memeduck.ASC),
+		`SELECT tag, pos FROM UNNEST(tags) AS tag WITH OFFSET AS pos WHERE tag != "" ORDER BY pos ASC`,
+	)
+	testSelect(t,
+		memeduck.SelectFromUnnest(memeduck.Param("ids"), "id", []string{"id"}).WithOffset(""),
+		`SELECT id FROM UNNEST(@ids) AS id WITH OFFSET`,
+	)
+	_, err := memeduck.Select("hoge", []string{"a"}).WithOffset("pos").SQL()
+	assert.Error(t, err)
+	_, err = memeduck.SelectFromUnnest(memeduck.Param("ids"), "id", []string{"id"}).ForceIndex("idx").SQL()
+	assert.Error(t, err)
+}
+
+func TestSelectAsScalarSubQuery(t *testing.T) {
+	sub := memeduck.Select("fuga", []string{"MAX(c)"}).Where(memeduck.Eq(memeduck.Ident("d"), 1))
+	testExpr(t, memeduck.Select("fuga", []string{"c"}), `(SELECT c FROM fuga)`)
+	testSelect(t,
+		memeduck.Select("hoge", []string{"a"}).Where(
+			memeduck.Eq(memeduck.Ident("b"), memeduck.Select("fuga", []string{"c"}).Limit(1)),
+		),
+		`SELECT a FROM hoge WHERE b = (SELECT c FROM fuga LIMIT 1)`,
+	)
+	testUpdate(t,
+		memeduck.Update("hoge").
+			Set(memeduck.Ident("a"), memeduck.Select("fuga", []string{"COUNT(*)"})).
+			Where(memeduck.Bool(true)),
+		`UPDATE hoge SET a = (SELECT COUNT(*) FROM fuga) WHERE TRUE`,
+	)
+	testExpr(t, memeduck.ScalarSubQuery(sub), "(SELECT `MAX(c)` FROM fuga WHERE d = 1)")
+}
+
+func TestSelectWithColumns(t *testing.T) {
+	detail := memeduck.Select("hoge", []string{"a", "b"}).
+		Items(memeduck.Count(memeduck.Ident("c")).As("cnt")).
+		Where(memeduck.Eq(memeduck.Ident("a"), memeduck.Param("a"))).
+		OrderBy("b", memeduck.DESC).
+		Limit(10)
+	summary := detail.WithColumns([]string{"a"})
+	testSelect(t, detail, `SELECT a, b, COUNT(c) AS cnt FROM hoge WHERE a = @a ORDER BY b DESC LIMIT 10`)
+	testSelect(t, summary, `SELECT a FROM hoge WHERE a = @a ORDER BY b DESC LIMIT 10`)
+}
+
+func TestSelectNameValidation(t *testing.T) {
+	testSelect(t,
+		memeduck.Select("hoge", []string{"a"}).
+			Items(memeduck.Count(memeduck.Ident("b")).As("cnt")).
+			OrderBy("cnt", memeduck.DESC).
+			OrderBy("c", memeduck.ASC),
+		`SELECT a, COUNT(b) AS cnt FROM hoge ORDER BY cnt DESC, c ASC`,
+	)
+	_, err := memeduck.Select("hoge", []string{"a"}).
+		Items(memeduck.Count(memeduck.Ident("b")).As("A")).
+		SQL()
+	assert.Error(t, err, "alias conflicts with column")
+	_, err = memeduck.Select("hoge", []string{}).
+		Items(memeduck.Max(memeduck.Ident("a")).As("x"), memeduck.Min(memeduck.Ident("a")).As("x")).
+		SQL()
+	assert.Error(t, err, "duplicate aliases")
+	_, err = memeduck.Select("hoge", []string{"a", "a"}).OrderBy("a", memeduck.ASC).SQL()
+	assert.Error(t, err, "ambiguous ORDER BY")
+
+	testSelect(t,
+		memeduck.SelectFromUnnest(memeduck.Param("ids"), "id", []string{"id"}).
+			WithOffset("").
+			OrderBy("offset", memeduck.ASC),
+		`SELECT id FROM UNNEST(@ids) AS id WITH OFFSET ORDER BY offset ASC`,
+	)
+	_, err = memeduck.SelectFromUnnest(memeduck.Param("ids"), "id", []string{"id"}).OrderBy("name", memeduck.ASC).SQL()
+	assert.Error(t, err, "unknown ORDER BY on UNNEST")
+}
+
+func TestSelectReplicaEligible(t *testing.T) {
+	stmt := memeduck.Select("hoge", []string{"a"})
+	replica := stmt.ReplicaEligible()
+	assert.False(t, stmt.IsReplicaEligible())
+	assert.True(t, replica.IsReplicaEligible())
+	assert.True(t, replica.Where(memeduck.Eq(memeduck.Ident("a"), 1)).IsReplicaEligible())
+	testSelect(t, replica, `SELECT a FROM hoge`)
+}
+
+func TestSelectWithReservedIdents(t *testing.T) {
+	testSelect(t,
+		memeduck.Select("order", []string{"group", "a-b", "id"}).
+			Where(memeduck.Eq(memeduck.IdentPath("t.from"), 1)).
+			OrderBy("select", memeduck.ASC),
+		"SELECT `group`, `a-b`, id FROM `order` WHERE t.`from` = 1 ORDER BY `select` ASC",
+	)
+	testUpdate(t,
+		memeduck.Update("group").Set(memeduck.Ident("order"), 1).Where(memeduck.Bool(true)),
+		"UPDATE `group` SET `order` = 1 WHERE TRUE",
+	)
+	testInsert(t,
+		memeduck.Insert("group", []string{"order"}).Values([][]interface{}{{1}}),
+		"INSERT INTO `group` (`order`) VALUES (1)",
+	)
+}
+
+func TestSelectWithInvalidIdents(t *testing.T) {
+	_, err := memeduck.Select("hoge", []string{""}).SQL()
+	assert.EqualError(t, err, "empty identifier")
+	_, err = memeduck.Select(strings.Repeat("a", 129), []string{"a"}).SQL()
+	assert.EqualError(t, err, "identifier "+strings.Repeat("a", 129)+" is longer than 128 characters")
+	_, err = memeduck.Delete("hoge").Where(memeduck.Eq(memeduck.Ident("\xff"), 1)).SQL()
+	assert.EqualError(t, err, `identifier "\xff" is not valid UTF-8`)
+	_, err = memeduck.Select("hoge", []string{"a"}).OrderBy("", memeduck.ASC).SQL()
+	assert.EqualError(t, err, "empty identifier")
+}
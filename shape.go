@@ -0,0 +1,74 @@
+package memeduck
+
+import (
+	"strings"
+
+	"github.com/cloudspannerecosystem/memefish/ast"
+
+	"github.com/abyssparanoia/memeduck/internal"
+)
+
+// ResultColumn describes a column of query results.
+type ResultColumn struct {
+	// Name is the column name or alias. It is empty for anonymous columns like `SELECT a + 1`.
+	Name string
+	// Type is the column type if it is evident from the expression, e.g. for literals, CAST and COUNT.
+	// Otherwise it is empty.
+	Type string
+}
+
+// ResultShape returns the columns of the query results in order.
+// A query with AsStruct has a single anonymous STRUCT column.
+func (s *SelectStmt) ResultShape() ([]*ResultColumn, error) {
+	items, err := s.toASTResults()
+	if err != nil {
+		return nil, err
+	}
+	if s.asStruct {
+		return []*ResultColumn{{Type: "STRUCT"}}, nil
+	}
+	cols := make([]*ResultColumn, 0, len(items))
+	for _, item := range items {
+		col := &ResultColumn{Name: selectItemName(item)}
+		switch i := item.(type) {
+		case *ast.Alias:
+			col.Type = exprType(i.Expr)
+		case *ast.ExprSelectItem:
+			col.Type = exprType(i.Expr)
+		}
+		cols = append(cols, col)
+	}
+	return cols, nil
+}
+
+func exprType(e ast.Expr) string {
+	switch e := e.(type) {
+	case *ast.CountStarExpr:
+		return "INT64"
+	case *ast.CallExpr:
+		if strings.EqualFold(e.Func.Name, "COUNT") {
+			return "INT64"
+		}
+	case *ast.CastExpr:
+		return e.Type.SQL()
+	case *internal.SafeCastExpr:
+		return e.Type.SQL()
+	case *ast.BoolLiteral:
+		return "BOOL"
+	case *ast.IntLiteral:
+		return "INT64"
+	case *ast.FloatLiteral:
+		return "FLOAT64"
+	case *ast.StringLiteral:
+		return "STRING"
+	case *ast.BytesLiteral:
+		return "BYTES"
+	case *ast.DateLiteral:
+		return "DATE"
+	case *ast.TimestampLiteral:
+		return "TIMESTAMP"
+	case *ast.ParenExpr:
+		return exprType(e.Expr)
+	}
+	return ""
+}
@@ -0,0 +1,36 @@
+package memeduck_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/abyssparanoia/memeduck"
+)
+
+func TestResultShape(t *testing.T) {
+	shape, err := memeduck.Select("hoge", []string{"a", "COUNT(*)"}).
+		Items(
+			memeduck.Count(memeduck.Ident("b")).As("cnt"),
+			memeduck.Cast(memeduck.Ident("c"), "STRING").As("c_str"),
+			memeduck.SafeCast(memeduck.Ident("d"), "INT64"),
+			memeduck.Max(memeduck.Ident("e")).As("max_e"),
+		).
+		ResultShape()
+	assert.Nil(t, err)
+	assert.Equal(t, []*memeduck.ResultColumn{
+		{Name: "a"},
+		{Type: "INT64"},
+		{Name: "cnt", Type: "INT64"},
+		{Name: "c_str", Type: "STRING"},
+		{Type: "INT64"},
+		{Name: "max_e"},
+	}, shape)
+
+	shape, err = memeduck.Select("hoge", []string{"a", "b"}).AsStruct().ResultShape()
+	assert.Nil(t, err)
+	assert.Equal(t, []*memeduck.ResultColumn{{Type: "STRUCT"}}, shape)
+
+	_, err = memeduck.Select("hoge", []string{}).ResultShape()
+	assert.Error(t, err)
+}
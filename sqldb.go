@@ -0,0 +1,82 @@
+package memeduck
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+
+	"cloud.google.com/go/spanner"
+)
+
+// SQLDB is the subset of *sql.DB used by Exec and Query, also implemented by *sql.Tx and *sql.Conn.
+type SQLDB interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// namedArgs converts the query parameters into named args, which the go-sql-spanner driver binds to @name,
+// sorted by name so that the args are deterministic.
+func namedArgs(params map[string]interface{}) []interface{} {
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	args := make([]interface{}, 0, len(names))
+	for _, name := range names {
+		args = append(args, sql.Named(name, params[name]))
+	}
+	return args
+}
+
+func execSQL(ctx context.Context, db SQLDB, stmtFn func() (spanner.Statement, error)) (sql.Result, error) {
+	stmt, err := stmtFn()
+	if err != nil {
+		return nil, err
+	}
+	return db.ExecContext(ctx, stmt.SQL, namedArgs(stmt.Params)...)
+}
+
+func querySQL(ctx context.Context, db SQLDB, stmtFn func() (spanner.Statement, error)) (*sql.Rows, error) {
+	stmt, err := stmtFn()
+	if err != nil {
+		return nil, err
+	}
+	return db.QueryContext(ctx, stmt.SQL, namedArgs(stmt.Params)...)
+}
+
+// Query runs the SELECT statement on db, such as a *sql.DB of the go-sql-spanner driver,
+// with the values of ParamValue passed as named args.
+func (s *SelectStmt) Query(ctx context.Context, db SQLDB) (*sql.Rows, error) {
+	return querySQL(ctx, db, s.Statement)
+}
+
+// Exec runs the INSERT statement on db with the values of ParamValue passed as named args.
+func (s *InsertStmt) Exec(ctx context.Context, db SQLDB) (sql.Result, error) {
+	return execSQL(ctx, db, s.Statement)
+}
+
+// Query runs the INSERT statement with THEN RETURN on db, returning the rows.
+func (s *InsertStmt) Query(ctx context.Context, db SQLDB) (*sql.Rows, error) {
+	return querySQL(ctx, db, s.Statement)
+}
+
+// Exec runs the UPDATE statement on db with the values of ParamValue passed as named args.
+func (s *UpdateStmt) Exec(ctx context.Context, db SQLDB) (sql.Result, error) {
+	return execSQL(ctx, db, s.Statement)
+}
+
+// Query runs the UPDATE statement with THEN RETURN on db, returning the rows.
+func (s *UpdateStmt) Query(ctx context.Context, db SQLDB) (*sql.Rows, error) {
+	return querySQL(ctx, db, s.Statement)
+}
+
+// Exec runs the DELETE statement on db with the values of ParamValue passed as named args.
+func (s *DeleteStmt) Exec(ctx context.Context, db SQLDB) (sql.Result, error) {
+	return execSQL(ctx, db, s.Statement)
+}
+
+// Query runs the DELETE statement with THEN RETURN on db, returning the rows.
+func (s *DeleteStmt) Query(ctx context.Context, db SQLDB) (*sql.Rows, error) {
+	return querySQL(ctx, db, s.Statement)
+}
@@ -0,0 +1,57 @@
+package memeduck_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/abyssparanoia/memeduck"
+)
+
+type fakeSQLDB struct {
+	query string
+	args  []interface{}
+}
+
+func (db *fakeSQLDB) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	db.query, db.args = query, args
+	return nil, nil
+}
+
+func (db *fakeSQLDB) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	db.query, db.args = query, args
+	return nil, nil
+}
+
+func TestSQLDB(t *testing.T) {
+	ctx := context.Background()
+	db := &fakeSQLDB{}
+
+	_, err := memeduck.Select("user", []string{"id"}).
+		Where(
+			memeduck.Eq(memeduck.Ident("name"), memeduck.ParamValue("name", "foo")),
+			memeduck.Gt(memeduck.Ident("age"), memeduck.ParamValue("age", int64(20))),
+		).
+		Query(ctx, db)
+	assert.Nil(t, err)
+	assert.Equal(t, "SELECT id FROM user WHERE name = @name AND age > @age", db.query)
+	assert.Equal(t, []interface{}{sql.Named("age", int64(20)), sql.Named("name", "foo")}, db.args)
+
+	_, err = memeduck.Update("user").
+		Set(memeduck.Ident("name"), memeduck.ParamValue("name", "bar")).
+		Where(memeduck.Eq(memeduck.Ident("id"), 1)).
+		Exec(ctx, db)
+	assert.Nil(t, err)
+	assert.Equal(t, "UPDATE user SET name = @name WHERE id = 1", db.query)
+	assert.Equal(t, []interface{}{sql.Named("name", "bar")}, db.args)
+
+	_, err = memeduck.Delete("user").Where(memeduck.Eq(memeduck.Ident("id"), 1)).ThenReturn("id").Query(ctx, db)
+	assert.Nil(t, err)
+	assert.Equal(t, "DELETE FROM user WHERE id = 1 THEN RETURN id", db.query)
+	assert.Empty(t, db.args)
+
+	_, err = memeduck.Insert("user", []string{"id"}).Exec(ctx, db)
+	assert.Error(t, err)
+}
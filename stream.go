@@ -0,0 +1,58 @@
+package memeduck
+
+import (
+	"github.com/pkg/errors"
+)
+
+// RowIterator is a source of rows for INSERT statements.
+// Next returns the next row and true, or false when no rows remain.
+// A row is a slice, a struct or a pointer to a struct, as accepted by InsertStmt.Values.
+type RowIterator interface {
+	Next() (row interface{}, ok bool)
+}
+
+type chanRows <-chan interface{}
+
+func (c chanRows) Next() (interface{}, bool) {
+	row, ok := <-c
+	return row, ok
+}
+
+// ChanRows creates a RowIterator reading rows from ch until it is closed.
+func ChanRows(ch <-chan interface{}) RowIterator {
+	return chanRows(ch)
+}
+
+// SQLChunks renders INSERT statements of the rows read from it, at most size rows for each,
+// and calls fn with each SQL in order. The rows given by Values are ignored.
+// Only one chunk of rows is held in memory at once, so very large imports can be rendered.
+func (s *InsertStmt) SQLChunks(it RowIterator, size int, fn func(sql string) error) error {
+	if size <= 0 {
+		return errors.Errorf("chunk size must be positive, but got %d", size)
+	}
+	chunk := make([]interface{}, 0, size)
+	flush := func() error {
+		sql, err := s.Values(chunk).SQL()
+		if err != nil {
+			return err
+		}
+		chunk = chunk[:0]
+		return fn(sql)
+	}
+	for {
+		row, ok := it.Next()
+		if !ok {
+			break
+		}
+		chunk = append(chunk, row)
+		if len(chunk) == size {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	if len(chunk) > 0 {
+		return flush()
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package memeduck_test
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/abyssparanoia/memeduck"
+)
+
+type testRowIterator struct {
+	n, i int
+}
+
+func (it *testRowIterator) Next() (interface{}, bool) {
+	if it.i >= it.n {
+		return nil, false
+	}
+	it.i++
+	return []interface{}{int64(it.i), "x"}, true
+}
+
+func TestInsertSQLChunks(t *testing.T) {
+	stmt := memeduck.Insert("hoge", []string{"a", "b"})
+	var sqls []string
+	err := stmt.SQLChunks(&testRowIterator{n: 5}, 2, func(sql string) error {
+		sqls = append(sqls, sql)
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{
+		`INSERT INTO hoge (a, b) VALUES (1, "x"), (2, "x")`,
+		`INSERT INTO hoge (a, b) VALUES (3, "x"), (4, "x")`,
+		`INSERT INTO hoge (a, b) VALUES (5, "x")`,
+	}, sqls)
+
+	ch := make(chan interface{})
+	go func() {
+		defer close(ch)
+		ch <- &testInsertGoStruct{A: "a", B: "b", C: "c"}
+		ch <- testInsertGoStruct{A: "d", B: "e", C: "f"}
+	}()
+	sqls = nil
+	err = memeduck.Insert("hoge", []string{"A", "C"}).SQLChunks(memeduck.ChanRows(ch), 10, func(sql string) error {
+		sqls = append(sqls, sql)
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{`INSERT INTO hoge (A, C) VALUES ("a", "c"), ("d", "f")`}, sqls)
+
+	err = stmt.SQLChunks(&testRowIterator{n: 0}, 2, func(sql string) error {
+		t.Fatal("no chunk expected")
+		return nil
+	})
+	assert.Nil(t, err)
+	err = stmt.SQLChunks(&testRowIterator{n: 3}, 1, func(sql string) error {
+		return errors.New("stop")
+	})
+	assert.EqualError(t, err, "stop")
+	assert.Error(t, stmt.SQLChunks(&testRowIterator{n: 3}, 0, nil))
+}
@@ -0,0 +1,303 @@
+package memeduck
+
+import (
+	"reflect"
+	"strings"
+	"unicode"
+
+	"cloud.google.com/go/spanner"
+	"github.com/pkg/errors"
+)
+
+// structField is a field of a Go struct mapped to a column.
+type structField struct {
+	name  string
+	path  string
+	index []int
+	pii   bool
+	// omitEmpty makes zero values not written, i.e. DEFAULT in INSERT and skipped in UPDATE.
+	omitEmpty bool
+	// commitTimestamp makes PENDING_COMMIT_TIMESTAMP() written instead of the value.
+	commitTimestamp bool
+	// readOnly makes the field never written.
+	readOnly bool
+	// key marks the field as a primary key column, which UPDATE statements can't set.
+	key bool
+}
+
+func (f *structField) matches(colName string) bool {
+	return strings.EqualFold(f.name, colName)
+}
+
+// writeValue returns the value written to the column for the field value fv, following the tag options.
+// It returns false if nothing should be written.
+func (f *structField) writeValue(fv reflect.Value) (interface{}, bool) {
+	if f.commitTimestamp {
+		return spanner.CommitTimestamp, true
+	}
+	if f.readOnly || f.omitEmpty && fv.IsZero() {
+		return nil, false
+	}
+	return fv.Interface(), true
+}
+
+// NameMapper maps a name of a struct field without a column name in its tag to the column name.
+type NameMapper func(field string) string
+
+// SnakeCase maps a CamelCase field name to a snake_case column name, e.g. UserID to user_id.
+func SnakeCase(field string) string {
+	rs := []rune(field)
+	var b strings.Builder
+	for i, r := range rs {
+		if unicode.IsUpper(r) {
+			prevLower := i > 0 && (unicode.IsLower(rs[i-1]) || unicode.IsDigit(rs[i-1]))
+			acronymEnd := i > 0 && unicode.IsUpper(rs[i-1]) && i+1 < len(rs) && unicode.IsLower(rs[i+1])
+			if prevLower || acronymEnd {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// CamelCase maps a snake_case field name to a CamelCase column name, e.g. User_id to UserId.
+func CamelCase(field string) string {
+	var b strings.Builder
+	for _, part := range strings.Split(field, "_") {
+		rs := []rune(part)
+		if len(rs) == 0 {
+			continue
+		}
+		rs[0] = unicode.ToUpper(rs[0])
+		b.WriteString(string(rs))
+	}
+	return b.String()
+}
+
+// structFields lists fields of the struct type t mapped to columns.
+// Untagged embedded structs are flattened into their parent, like the Spanner client does.
+// Fields without column names in their tags are named by names, or by the field names if names is nil.
+// It returns an error if two fields are mapped to the same column, reporting both field paths.
+func structFields(t reflect.Type, names NameMapper) ([]*structField, error) {
+	fields := collectStructFields(t, nil, "", names)
+	seen := make(map[string]*structField, len(fields))
+	for _, f := range fields {
+		key := strings.ToLower(f.name)
+		if prev, ok := seen[key]; ok {
+			return nil, errors.Errorf("type %s has duplicate column %s in fields %s and %s", t.String(), f.name, prev.path, f.path)
+		}
+		seen[key] = f
+	}
+	return fields, nil
+}
+
+func collectStructFields(t reflect.Type, index []int, prefix string, names NameMapper) []*structField {
+	var fields []*structField
+	for i := 0; i < t.NumField(); i++ {
+		ft := t.Field(i)
+		tag, opts := parseStructTag(ft.Tag.Get("spanner"))
+		if tag == "-" {
+			continue
+		}
+		idx := append(append([]int{}, index...), i)
+		path := prefix + ft.Name
+		if ft.Anonymous && tag == "" {
+			et := ft.Type
+			if et.Kind() == reflect.Ptr {
+				et = et.Elem()
+			}
+			if et.Kind() == reflect.Struct {
+				fields = append(fields, collectStructFields(et, idx, path+".", names)...)
+				continue
+			}
+		}
+		if !ft.IsExported() {
+			continue
+		}
+		name := tag
+		if name == "" && names != nil {
+			name = names(ft.Name)
+		} else if name == "" {
+			name = ft.Name
+		}
+		fields = append(fields, &structField{
+			name:  name,
+			path:  path,
+			index: idx,
+			pii:   opts["pii"],
+
+			omitEmpty:       opts["omitempty"],
+			commitTimestamp: opts["commit_timestamp"],
+			readOnly:        opts["readonly"],
+			key:             opts["key"],
+		})
+	}
+	return fields
+}
+
+// parseStructTag splits a `spanner` tag like "email,pii" into the column name and its options.
+func parseStructTag(tag string) (string, map[string]bool) {
+	parts := strings.Split(tag, ",")
+	opts := make(map[string]bool, len(parts)-1)
+	for _, o := range parts[1:] {
+		opts[strings.TrimSpace(o)] = true
+	}
+	return parts[0], opts
+}
+
+// PIIColumns returns the columns of the struct v, or the struct v points to, tagged as PII like `spanner:"email,pii"`.
+// They can be passed to RedactedSQL to mask values of the columns.
+func PIIColumns(v interface{}) ([]string, error) {
+	return piiColumns(v, nil)
+}
+
+func piiColumns(v interface{}, names NameMapper) ([]string, error) {
+	t := reflect.TypeOf(v)
+	if t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, errors.Errorf("%T is not a struct", v)
+	}
+	fields, err := structFields(t, names)
+	if err != nil {
+		return nil, err
+	}
+	var cols []string
+	for _, f := range fields {
+		if f.pii {
+			cols = append(cols, f.name)
+		}
+	}
+	return cols, nil
+}
+
+// InsertStruct creates a new InsertStmt inserting v, a struct, a pointer to a struct or a slice of them,
+// with the columns derived from the fields of the struct in order.
+func InsertStruct(table string, v interface{}) *InsertStmt {
+	return insertStruct(table, v, nil)
+}
+
+func insertStruct(table string, v interface{}, names NameMapper) *InsertStmt {
+	stmt := &InsertStmt{table: table, names: names}
+	t := reflect.TypeOf(v)
+	values := v
+	if t != nil && t.Kind() == reflect.Slice {
+		t = t.Elem()
+	} else {
+		values = []interface{}{v}
+	}
+	if t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		stmt.err = errors.Errorf("%T is not a struct", v)
+		return stmt
+	}
+	fields, err := structFields(t, names)
+	if err != nil {
+		stmt.err = err
+		return stmt
+	}
+	for _, f := range fields {
+		if !f.readOnly {
+			stmt.cols = append(stmt.cols, f.name)
+		}
+	}
+	stmt.values = values
+	return stmt
+}
+
+// SetStruct adds assignment clauses setting columns to the fields of v, a struct or a pointer to a struct,
+// in order of the fields. If cols are given, only the columns are set.
+// Primary key columns must be tagged key like `spanner:"id,key"`, as Spanner doesn't allow to update them;
+// they are skipped, and it is an error to give them in cols.
+// Fields tagged readonly are skipped too, and it is an error to give them in cols.
+// Fields tagged omitempty with zero values are not set.
+// Columns of fields tagged pii are masked by RedactedSQL.
+func (s *UpdateStmt) SetStruct(v interface{}, cols ...string) *UpdateStmt {
+	var t = *s
+	if t.err != nil {
+		return &t
+	}
+	rowV := reflect.ValueOf(v)
+	if rowV.Kind() == reflect.Ptr && !rowV.IsNil() {
+		rowV = rowV.Elem()
+	}
+	if rowV.Kind() != reflect.Struct {
+		t.err = errors.Errorf("%T is not a struct", v)
+		return &t
+	}
+	if len(cols) == 0 {
+		fields, err := structFields(rowV.Type(), t.names)
+		if err != nil {
+			t.err = err
+			return &t
+		}
+		for _, f := range fields {
+			if !f.key && !f.readOnly {
+				cols = append(cols, f.name)
+			}
+		}
+	}
+	t.items = append([]*updateItem(nil), t.items...)
+	for _, col := range cols {
+		f, fv, err := structColumn(rowV.Interface(), col, t.names)
+		if err != nil {
+			t.err = err
+			return &t
+		}
+		if f.key {
+			t.err = errors.Errorf("primary key column %s can't be set", f.name)
+			return &t
+		}
+		if f.readOnly {
+			t.err = errors.Errorf("column %s of type %s is read-only", col, rowV.Type().String())
+			return &t
+		}
+		if v, ok := f.writeValue(fv); ok {
+			t.items = append(t.items, &updateItem{ident: Ident(f.name), value: v})
+			if f.pii {
+				t.pii = append(append([]string(nil), t.pii...), f.name)
+			}
+		}
+	}
+	return &t
+}
+
+// structColumnValue returns the value of the field of row mapped to the column colName.
+func structColumnValue(row interface{}, colName string) (interface{}, error) {
+	_, fv, err := structColumn(row, colName, nil)
+	if err != nil {
+		return nil, err
+	}
+	return fv.Interface(), nil
+}
+
+// structColumn returns the field of row mapped to the column colName and its value.
+func structColumn(row interface{}, colName string, names NameMapper) (*structField, reflect.Value, error) {
+	rowV := reflect.ValueOf(row)
+	if rowV.Kind() == reflect.Ptr && !rowV.IsNil() {
+		rowV = rowV.Elem()
+	}
+	if rowV.Kind() != reflect.Struct {
+		return nil, reflect.Value{}, errors.Errorf("%T is not a struct", row)
+	}
+	fields, err := structFields(rowV.Type(), names)
+	if err != nil {
+		return nil, reflect.Value{}, err
+	}
+	for _, f := range fields {
+		if !f.matches(colName) {
+			continue
+		}
+		fv, err := rowV.FieldByIndexErr(f.index)
+		if err != nil {
+			return nil, reflect.Value{}, errors.WithMessagef(err, "can't get field %s of type %s", f.path, rowV.Type().String())
+		}
+		return f, fv, nil
+	}
+	return nil, reflect.Value{}, errors.Errorf("type %s does not have column %s", rowV.Type().String(), colName)
+}
@@ -23,27 +23,16 @@ func (s *ScalarSubQueryStmt) As(as string) *ScalarSubQueryStmt {
 	return &t
 }
 
+func (s *ScalarSubQueryStmt) ToASTExpr() (ast.Expr, error) {
+	return s.query.ToASTExpr()
+}
+
 func (s *ScalarSubQueryStmt) ToAST() (ast.SelectItem, error) {
-	stmt, err := s.query.toAST()
+	expr, err := s.ToASTExpr()
 	if err != nil {
 		return nil, err
 	}
-	expr := &ast.ScalarSubQuery{
-		Query: stmt,
-	}
-	if s.as == "" {
-		return &ast.ExprSelectItem{
-			Expr: expr,
-		}, nil
-	}
-	return &ast.Alias{
-		Expr: expr,
-		As: &ast.AsAlias{
-			Alias: &ast.Ident{
-				Name: s.as,
-			},
-		},
-	}, nil
+	return toASTSelectItem(expr, s.as), nil
 }
 
 type ArraySubQueryStmt struct {
@@ -68,20 +57,59 @@ func (s *ArraySubQueryStmt) ToAST() (ast.SelectItem, error) {
 	if err != nil {
 		return nil, err
 	}
-	expr := &ast.ArraySubQuery{
+	return toASTSelectItem(&ast.ArraySubQuery{
 		Query: stmt,
-	}
-	if s.as == "" {
+	}, s.as), nil
+}
+
+// toASTSelectItem wraps expr into a select item, aliased if as is not empty.
+func toASTSelectItem(expr ast.Expr, as string) ast.SelectItem {
+	if as == "" {
 		return &ast.ExprSelectItem{
 			Expr: expr,
-		}, nil
+		}
 	}
 	return &ast.Alias{
 		Expr: expr,
 		As: &ast.AsAlias{
 			Alias: &ast.Ident{
-				Name: s.as,
+				Name: as,
 			},
 		},
+	}
+}
+
+// ExistsCond is `EXISTS(subquery)` condition.
+type ExistsCond struct {
+	query *SelectStmt
+	not   bool
+}
+
+// Exists creates `EXISTS(stmt)` condition.
+func Exists(stmt *SelectStmt) *ExistsCond {
+	return &ExistsCond{query: stmt}
+}
+
+// NotExists creates `NOT EXISTS(stmt)` condition.
+func NotExists(stmt *SelectStmt) *ExistsCond {
+	return &ExistsCond{query: stmt, not: true}
+}
+
+func (c *ExistsCond) ToASTWhere() (*ast.Where, error) {
+	stmt, err := c.query.toAST()
+	if err != nil {
+		return nil, err
+	}
+	var expr ast.Expr = &ast.ExistsSubQuery{
+		Query: stmt,
+	}
+	if c.not {
+		expr = &ast.UnaryExpr{
+			Op:   ast.OpNot,
+			Expr: expr,
+		}
+	}
+	return &ast.Where{
+		Expr: expr,
 	}, nil
 }
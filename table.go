@@ -0,0 +1,83 @@
+package memeduck
+
+// Table describes the schema of a table.
+type Table struct {
+	Name       string
+	Columns    []*Column
+	PrimaryKey []*KeyPart
+	// Parent is the table the table is interleaved in, or empty if it isn't interleaved.
+	Parent   string
+	OnDelete OnDeleteAction
+	Indexes  []*Index
+}
+
+// Column describes a column of a table.
+type Column struct {
+	Name string
+	// Type is the column type as written in DDL, e.g. "INT64", "STRING(64)" or "ARRAY<STRING(MAX)>".
+	Type    string
+	NotNull bool
+	// Default is the DEFAULT expression as written in DDL, e.g. "0" or "CURRENT_TIMESTAMP()", or empty if it has none.
+	Default              string
+	AllowCommitTimestamp bool
+}
+
+// Index describes a secondary index of a table.
+type Index struct {
+	Name         string
+	Keys         []*KeyPart
+	Storing      []string
+	Unique       bool
+	NullFiltered bool
+	// InterleaveIn is the table the index is interleaved in, or empty if it isn't interleaved.
+	InterleaveIn string
+}
+
+// KeyPart is a column of a primary key or an index key.
+type KeyPart struct {
+	Column string
+	Dir    Direction
+}
+
+// Column returns the column named name, or nil if the table has no such column.
+func (t *Table) Column(name string) *Column {
+	for _, c := range t.Columns {
+		if c.Name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+// ColumnNames returns the names of all columns in order.
+func (t *Table) ColumnNames() []string {
+	names := make([]string, 0, len(t.Columns))
+	for _, c := range t.Columns {
+		names = append(names, c.Name)
+	}
+	return names
+}
+
+// IsKey reports whether the column named name is a part of the primary key.
+func (t *Table) IsKey(name string) bool {
+	for _, k := range t.PrimaryKey {
+		if k.Column == name {
+			return true
+		}
+	}
+	return false
+}
+
+// PrimaryKeyOrder returns the ordering of the primary key, which can be passed to SelectStmt.OrderByKeys
+// to sort rows in key order. Key parts without a direction are ascending.
+func (t *Table) PrimaryKeyOrder() []*KeyPart {
+	keys := make([]*KeyPart, 0, len(t.PrimaryKey))
+	for _, k := range t.PrimaryKey {
+		dir := k.Dir
+		if dir == "" {
+			dir = ASC
+		}
+		keys = append(keys, &KeyPart{Column: k.Column, Dir: dir})
+	}
+	return keys
+}
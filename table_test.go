@@ -0,0 +1,40 @@
+package memeduck_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/abyssparanoia/memeduck"
+)
+
+func TestTable(t *testing.T) {
+	table := &memeduck.Table{
+		Name: "user",
+		Columns: []*memeduck.Column{
+			{Name: "id", Type: "STRING(36)", NotNull: true},
+			{Name: "name", Type: "STRING(MAX)"},
+		},
+		PrimaryKey: []*memeduck.KeyPart{{Column: "id"}},
+	}
+	assert.Equal(t, []string{"id", "name"}, table.ColumnNames())
+	assert.Equal(t, "STRING(MAX)", table.Column("name").Type)
+	assert.Nil(t, table.Column("email"))
+	assert.True(t, table.IsKey("id"))
+	assert.False(t, table.IsKey("name"))
+}
+
+func TestTablePrimaryKeyOrder(t *testing.T) {
+	table := &memeduck.Table{
+		Name:       "event",
+		PrimaryKey: []*memeduck.KeyPart{{Column: "user_id"}, {Column: "created_at", Dir: memeduck.DESC}},
+	}
+	assert.Equal(t, []*memeduck.KeyPart{
+		{Column: "user_id", Dir: memeduck.ASC},
+		{Column: "created_at", Dir: memeduck.DESC},
+	}, table.PrimaryKeyOrder())
+	testSelect(t,
+		memeduck.Select(table.Name, []string{"user_id", "created_at"}).OrderByKeys(table.PrimaryKeyOrder()).Limit(10),
+		`SELECT user_id, created_at FROM event ORDER BY user_id ASC, created_at DESC LIMIT 10`,
+	)
+}
@@ -0,0 +1,47 @@
+package memeduck
+
+import (
+	"regexp"
+
+	"github.com/pkg/errors"
+)
+
+// TableRef validates table names supplied at runtime, e.g. for table-per-tenant schemas like tenant_123_events.
+// A resolved name must fully match the pattern and, if any names are allowed by Allow, be one of them.
+type TableRef struct {
+	pattern *regexp.Regexp
+	allowed map[string]bool
+}
+
+// NewTableRef creates a new TableRef accepting table names which fully match the regular expression pattern.
+func NewTableRef(pattern string) (*TableRef, error) {
+	re, err := regexp.Compile(`^(?:` + pattern + `)$`)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "invalid table name pattern %q", pattern)
+	}
+	return &TableRef{pattern: re}, nil
+}
+
+// Allow restricts the table names to the given names and the names allowed so far.
+func (r *TableRef) Allow(names ...string) *TableRef {
+	var t = *r
+	t.allowed = make(map[string]bool, len(r.allowed)+len(names))
+	for name := range r.allowed {
+		t.allowed[name] = true
+	}
+	for _, name := range names {
+		t.allowed[name] = true
+	}
+	return &t
+}
+
+// Resolve validates name and returns it, so that it can be passed to Select, Insert, Update or Delete.
+func (r *TableRef) Resolve(name string) (string, error) {
+	if !r.pattern.MatchString(name) {
+		return "", errors.Errorf("table name %q does not match %s", name, r.pattern.String())
+	}
+	if r.allowed != nil && !r.allowed[name] {
+		return "", errors.Errorf("table name %q is not allowed", name)
+	}
+	return name, nil
+}
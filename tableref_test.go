@@ -0,0 +1,34 @@
+package memeduck_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/abyssparanoia/memeduck"
+)
+
+func TestTableRef(t *testing.T) {
+	ref, err := memeduck.NewTableRef(`tenant_[0-9]+_events`)
+	assert.Nil(t, err)
+
+	table, err := ref.Resolve("tenant_123_events")
+	assert.Nil(t, err)
+	testSelect(t, memeduck.Select(table, []string{"id"}), `SELECT id FROM tenant_123_events`)
+
+	for _, name := range []string{"tenant_x_events", "tenant_1_events; DROP TABLE users", "users", ""} {
+		_, err := ref.Resolve(name)
+		assert.Error(t, err, name)
+	}
+
+	allowed := ref.Allow("tenant_1_events").Allow("tenant_2_events")
+	_, err = allowed.Resolve("tenant_2_events")
+	assert.Nil(t, err)
+	_, err = allowed.Resolve("tenant_3_events")
+	assert.Error(t, err)
+	_, err = ref.Resolve("tenant_3_events")
+	assert.Nil(t, err)
+
+	_, err = memeduck.NewTableRef(`tenant_(`)
+	assert.Error(t, err)
+}
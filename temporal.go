@@ -0,0 +1,79 @@
+package memeduck
+
+import "github.com/pkg/errors"
+
+// TemporalTable describes a history-tracked table,
+// whose rows are versions valid from its valid_from column until its valid_to column.
+// valid_to of the current version is NULL.
+type TemporalTable struct {
+	table     string
+	validFrom string
+	validTo   string
+}
+
+// Temporal creates a new TemporalTable with given table name.
+// It uses valid_from and valid_to as validity columns by default.
+func Temporal(table string) *TemporalTable {
+	return &TemporalTable{
+		table:     table,
+		validFrom: "valid_from",
+		validTo:   "valid_to",
+	}
+}
+
+// ValidityColumns returns a TemporalTable with its validity columns set to given ones.
+func (t *TemporalTable) ValidityColumns(validFrom, validTo string) *TemporalTable {
+	var tt = *t
+	tt.validFrom = validFrom
+	tt.validTo = validTo
+	return &tt
+}
+
+// AsOf creates a predicate selecting versions valid at ts,
+// i.e. `valid_from <= ts AND (valid_to IS NULL OR valid_to > ts)`.
+func (t *TemporalTable) AsOf(ts interface{}) WhereCond {
+	return And(
+		Le(Ident(t.validFrom), ts),
+		Or(
+			IsNull(Ident(t.validTo)),
+			Gt(Ident(t.validTo), ts),
+		),
+	)
+}
+
+// Current creates a predicate selecting current versions, i.e. `valid_to IS NULL`.
+func (t *TemporalTable) Current() WhereCond {
+	return IsNull(Ident(t.validTo))
+}
+
+// Revise returns a pair of statements recording a new version of a row at ts:
+// an UPDATE closing the current version matching keys,
+// and an INSERT adding the new version valid from ts.
+// cols and values describe the new version and must not contain validity columns.
+func (t *TemporalTable) Revise(ts interface{}, cols []string, values []interface{}, keys ...WhereCond) (*UpdateStmt, *InsertStmt, error) {
+	if len(keys) <= 0 {
+		return nil, nil, errors.New("no key conditions specified")
+	}
+	if len(cols) != len(values) {
+		return nil, nil, errors.Errorf("%d columns specified but %d values given", len(cols), len(values))
+	}
+	for _, col := range cols {
+		if col == t.validFrom || col == t.validTo {
+			return nil, nil, errors.Errorf("validity column %s must not be specified", col)
+		}
+	}
+	closeStmt := Update(t.table).
+		Set(Ident(t.validTo), ts).
+		Where(keys...).
+		Where(t.Current())
+
+	insertCols := make([]string, 0, len(cols)+2)
+	insertCols = append(insertCols, cols...)
+	insertCols = append(insertCols, t.validFrom, t.validTo)
+	row := make([]interface{}, 0, len(values)+2)
+	row = append(row, values...)
+	row = append(row, ts, nil)
+	insertStmt := Insert(t.table, insertCols).Values([][]interface{}{row})
+
+	return closeStmt, insertStmt, nil
+}
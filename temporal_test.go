@@ -0,0 +1,52 @@
+package memeduck_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/abyssparanoia/memeduck"
+)
+
+func TestTemporalAsOf(t *testing.T) {
+	testSelect(t,
+		memeduck.Select("price_history", []string{"price"}).Where(
+			memeduck.Eq(memeduck.Ident("item_id"), memeduck.Param("item_id")),
+			memeduck.Temporal("price_history").AsOf(memeduck.Param("ts")),
+		),
+		`SELECT price FROM price_history WHERE item_id = @item_id AND valid_from <= @ts AND (valid_to IS NULL OR valid_to > @ts)`,
+	)
+	testSelect(t,
+		memeduck.Select("price_history", []string{"price"}).Where(
+			memeduck.Temporal("price_history").ValidityColumns("start_at", "end_at").AsOf(memeduck.Param("ts")),
+		),
+		`SELECT price FROM price_history WHERE start_at <= @ts AND (end_at IS NULL OR end_at > @ts)`,
+	)
+	testSelect(t,
+		memeduck.Select("price_history", []string{"price"}).Where(
+			memeduck.Temporal("price_history").Current(),
+		),
+		`SELECT price FROM price_history WHERE valid_to IS NULL`,
+	)
+}
+
+func TestTemporalRevise(t *testing.T) {
+	closeStmt, insertStmt, err := memeduck.Temporal("price_history").Revise(
+		memeduck.Param("ts"),
+		[]string{"item_id", "price"},
+		[]interface{}{memeduck.Param("item_id"), 100},
+		memeduck.Eq(memeduck.Ident("item_id"), memeduck.Param("item_id")),
+	)
+	assert.Nil(t, err)
+	testUpdate(t, closeStmt, `UPDATE price_history SET valid_to = @ts WHERE item_id = @item_id AND valid_to IS NULL`)
+	testInsert(t, insertStmt, `INSERT INTO price_history (item_id, price, valid_from, valid_to) VALUES (@item_id, 100, @ts, NULL)`)
+
+	_, _, err = memeduck.Temporal("price_history").Revise(memeduck.Param("ts"), []string{"price"}, []interface{}{100})
+	assert.Error(t, err)
+	_, _, err = memeduck.Temporal("price_history").Revise(memeduck.Param("ts"), []string{"price"}, []interface{}{},
+		memeduck.Eq(memeduck.Ident("item_id"), 1))
+	assert.Error(t, err)
+	_, _, err = memeduck.Temporal("price_history").Revise(memeduck.Param("ts"), []string{"valid_to"}, []interface{}{nil},
+		memeduck.Eq(memeduck.Ident("item_id"), 1))
+	assert.Error(t, err)
+}
@@ -0,0 +1,91 @@
+package memeduck
+
+import (
+	"github.com/cloudspannerecosystem/memefish/ast"
+
+	"github.com/abyssparanoia/memeduck/internal"
+)
+
+// thenReturn is the result columns of `THEN RETURN` clause of DML statements.
+type thenReturn struct {
+	cols  []string
+	items []SelectItem
+}
+
+func (r thenReturn) withCols(cols []string) thenReturn {
+	r.cols = append(append([]string(nil), r.cols...), cols...)
+	return r
+}
+
+func (r thenReturn) withItems(items []SelectItem) thenReturn {
+	r.items = append(append([]SelectItem(nil), r.items...), items...)
+	return r
+}
+
+// wrap adds `THEN RETURN` clause to the DML statement if any column is specified.
+func (r thenReturn) wrap(dml ast.Node) (ast.Node, error) {
+	if len(r.cols) == 0 && len(r.items) == 0 {
+		return dml, nil
+	}
+	items := make([]ast.SelectItem, 0, len(r.cols)+len(r.items))
+	for _, col := range r.cols {
+		if col == "*" {
+			items = append(items, &ast.Star{})
+			continue
+		}
+		items = append(items, &ast.ExprSelectItem{Expr: &ast.Ident{Name: col}})
+	}
+	for _, i := range r.items {
+		item, err := i.ToAST()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return &internal.ThenReturnStmt{DML: dml, Items: items}, nil
+}
+
+// ThenReturn appends columns to `THEN RETURN` clause, so that the inserted rows can be read back, e.g. generated keys.
+// "*" returns all columns.
+func (s *InsertStmt) ThenReturn(cols ...string) *InsertStmt {
+	var t = *s
+	t.returning = t.returning.withCols(cols)
+	return &t
+}
+
+// ThenReturnExpr appends expressions like Func(...).As(...) to `THEN RETURN` clause.
+func (s *InsertStmt) ThenReturnExpr(items ...SelectItem) *InsertStmt {
+	var t = *s
+	t.returning = t.returning.withItems(items)
+	return &t
+}
+
+// ThenReturn appends columns to `THEN RETURN` clause, so that the updated rows can be read back.
+// "*" returns all columns.
+func (s *UpdateStmt) ThenReturn(cols ...string) *UpdateStmt {
+	var t = *s
+	t.returning = t.returning.withCols(cols)
+	return &t
+}
+
+// ThenReturnExpr appends expressions like Func(...).As(...) to `THEN RETURN` clause.
+func (s *UpdateStmt) ThenReturnExpr(items ...SelectItem) *UpdateStmt {
+	var t = *s
+	t.returning = t.returning.withItems(items)
+	return &t
+}
+
+// ThenReturn appends columns to `THEN RETURN` clause, so that the deleted rows can be read back.
+// "*" returns all columns.
+func (s *DeleteStmt) ThenReturn(cols ...string) *DeleteStmt {
+	var t = *s
+	t.returning = t.returning.withCols(cols)
+	return &t
+}
+
+// ThenReturnExpr appends expressions like Func(...).As(...) to `THEN RETURN` clause.
+func (s *DeleteStmt) ThenReturnExpr(items ...SelectItem) *DeleteStmt {
+	var t = *s
+	t.returning = t.returning.withItems(items)
+	return &t
+}
@@ -0,0 +1,38 @@
+package memeduck_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/abyssparanoia/memeduck"
+)
+
+func TestThenReturn(t *testing.T) {
+	testInsert(t,
+		memeduck.Insert("user", []string{"name"}).Values([][]interface{}{{"foo"}}).ThenReturn("id", "created_at"),
+		`INSERT INTO user (name) VALUES ("foo") THEN RETURN id, created_at`,
+	)
+	testInsert(t,
+		memeduck.Insert("user", []string{"id", "name"}).OrUpdate().Values([][]interface{}{{1, "foo"}}).ThenReturn("*"),
+		`INSERT OR UPDATE INTO user (id, name) VALUES (1, "foo") THEN RETURN *`,
+	)
+	testUpdate(t,
+		memeduck.Update("user").
+			Set(memeduck.Ident("name"), "bar").
+			Where(memeduck.Eq(memeduck.Ident("id"), 1)).
+			ThenReturn("id").
+			ThenReturnExpr(memeduck.Func("UPPER", memeduck.Ident("name")).As("upper_name")),
+		`UPDATE user SET name = "bar" WHERE id = 1 THEN RETURN id, UPPER(name) AS upper_name`,
+	)
+	testDelete(t,
+		memeduck.Delete("user").Where(memeduck.Eq(memeduck.Ident("id"), 1)).ThenReturn("name"),
+		`DELETE FROM user WHERE id = 1 THEN RETURN name`,
+	)
+
+	stmt := memeduck.Delete("user").Where(memeduck.Eq(memeduck.Ident("id"), 1))
+	testDelete(t, stmt, `DELETE FROM user WHERE id = 1`)
+	sql, err := stmt.ThenReturn("email").RedactedSQL()
+	assert.Nil(t, err)
+	assert.Equal(t, `DELETE FROM user WHERE id = ? THEN RETURN email`, sql)
+}
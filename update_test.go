@@ -91,3 +91,65 @@ func TestUpdateWithNoWhere(t *testing.T) {
 		SQL()
 	assert.Error(t, err, "UPDATE without WHERE clause")
 }
+
+type testSetStructRow struct {
+	ID       int64  `spanner:"id,readonly"`
+	Name     string `spanner:"name"`
+	Nickname string `spanner:"nickname,omitempty"`
+	Age      int64  `spanner:"age"`
+}
+
+func TestUpdateSetStruct(t *testing.T) {
+	row := &testSetStructRow{ID: 1, Name: "foo", Age: 20}
+	where := memeduck.Eq(memeduck.Ident("id"), 1)
+	testUpdate(t,
+		memeduck.Update("hoge").SetStruct(row).Where(where),
+		`UPDATE hoge SET name = "foo", age = 20 WHERE id = 1`,
+	)
+	testUpdate(t,
+		memeduck.Update("hoge").SetStruct(*row, "Age").Set(memeduck.Ident("b"), 2).Where(where),
+		`UPDATE hoge SET age = 20, b = 2 WHERE id = 1`,
+	)
+	_, err := memeduck.Update("hoge").SetStruct(row, "x").Where(where).SQL()
+	assert.EqualError(t, err, "type memeduck_test.testSetStructRow does not have column x")
+	_, err = memeduck.Update("hoge").SetStruct(row, "name", "id").Where(where).SQL()
+	assert.EqualError(t, err, "column id of type memeduck_test.testSetStructRow is read-only")
+	_, err = memeduck.Update("hoge").SetStruct(1).Where(where).SQL()
+	assert.EqualError(t, err, "int is not a struct")
+}
+
+type testSetStructKeyRow struct {
+	UserID   int64 `spanner:"user_id,key"`
+	ItemID   int64 `spanner:",key"`
+	Quantity int64
+}
+
+func TestUpdateSetStructKey(t *testing.T) {
+	row := &testSetStructKeyRow{UserID: 1, ItemID: 2, Quantity: 3}
+	testUpdate(t,
+		memeduck.Update("user_item").SetStruct(row).Where(memeduck.Bool(true)),
+		`UPDATE user_item SET Quantity = 3 WHERE TRUE`,
+	)
+	testUpdate(t,
+		memeduck.NewFactory().NameMapper(memeduck.SnakeCase).Update("user_item").SetStruct(row, "quantity").Where(memeduck.Bool(true)),
+		`UPDATE user_item SET quantity = 3 WHERE TRUE`,
+	)
+	testUpdate(t,
+		memeduck.NewFactory().NameMapper(memeduck.SnakeCase).Update("user_item").SetStruct(row).Where(memeduck.Bool(true)),
+		`UPDATE user_item SET quantity = 3 WHERE TRUE`,
+	)
+	_, err := memeduck.Update("user_item").SetStruct(row, "user_id", "Quantity").Where(memeduck.Bool(true)).SQL()
+	assert.EqualError(t, err, "primary key column user_id can't be set")
+}
+
+func TestUpdateSetMap(t *testing.T) {
+	testUpdate(t,
+		memeduck.Update("hoge").
+			Set(memeduck.Ident("a"), 1).
+			SetMap(map[string]interface{}{"name": "foo", "age": 20, "memo": nil}).
+			Where(memeduck.Eq(memeduck.Ident("id"), 1)),
+		`UPDATE hoge SET a = 1, age = 20, memo = NULL, name = "foo" WHERE id = 1`,
+	)
+	_, err := memeduck.Update("hoge").SetMap(map[string]interface{}{}).Where(memeduck.Bool(true)).SQL()
+	assert.Error(t, err)
+}
@@ -0,0 +1,52 @@
+package memeduck
+
+import (
+	"github.com/pkg/errors"
+)
+
+// UpsertIfNewer returns a pair of statements merging a row by last-write-wins on the timestamp column tsCol:
+// an UPDATE overwriting the row matching keyCols only if its tsCol is older than the incoming one,
+// and an INSERT adding the row.
+// cols and values describe the whole incoming row and must contain keyCols and tsCol.
+//
+// Execute the UPDATE first, and the INSERT only if the UPDATE affected no rows.
+// The INSERT fails with AlreadyExists when a newer row exists, which means the incoming row is stale and can be discarded.
+func UpsertIfNewer(table string, keyCols []string, tsCol string, cols []string, values []interface{}) (*UpdateStmt, *InsertStmt, error) {
+	if len(keyCols) <= 0 {
+		return nil, nil, errors.New("no key columns specified")
+	}
+	if len(cols) != len(values) {
+		return nil, nil, errors.Errorf("%d columns specified but %d values given", len(cols), len(values))
+	}
+	isKey := make(map[string]bool, len(keyCols))
+	for _, k := range keyCols {
+		isKey[k] = true
+	}
+	valueOf := make(map[string]interface{}, len(cols))
+	for i, col := range cols {
+		valueOf[col] = values[i]
+	}
+	ts, ok := valueOf[tsCol]
+	if !ok {
+		return nil, nil, errors.Errorf("timestamp column %s is not specified", tsCol)
+	}
+
+	updateStmt := Update(table)
+	for i, col := range cols {
+		if !isKey[col] {
+			updateStmt = updateStmt.Set(Ident(col), values[i])
+		}
+	}
+	for _, k := range keyCols {
+		v, ok := valueOf[k]
+		if !ok {
+			return nil, nil, errors.Errorf("key column %s is not specified", k)
+		}
+		updateStmt = updateStmt.Where(Eq(Ident(k), v))
+	}
+	updateStmt = updateStmt.Where(Lt(Ident(tsCol), ts))
+
+	insertStmt := Insert(table, cols).Values([][]interface{}{values})
+
+	return updateStmt, insertStmt, nil
+}
@@ -0,0 +1,28 @@
+package memeduck_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/abyssparanoia/memeduck"
+)
+
+func TestUpsertIfNewer(t *testing.T) {
+	update, insert, err := memeduck.UpsertIfNewer("item", []string{"id"}, "updated_at",
+		[]string{"id", "name", "updated_at"},
+		[]interface{}{memeduck.Param("id"), memeduck.Param("name"), memeduck.Param("incoming_ts")},
+	)
+	assert.Nil(t, err)
+	testUpdate(t, update, `UPDATE item SET name = @name, updated_at = @incoming_ts WHERE id = @id AND updated_at < @incoming_ts`)
+	testInsert(t, insert, `INSERT INTO item (id, name, updated_at) VALUES (@id, @name, @incoming_ts)`)
+
+	_, _, err = memeduck.UpsertIfNewer("item", []string{"id"}, "updated_at", []string{"id", "name"}, []interface{}{1, "a"})
+	assert.Error(t, err, "no timestamp column")
+	_, _, err = memeduck.UpsertIfNewer("item", []string{"id"}, "updated_at", []string{"name", "updated_at"}, []interface{}{"a", 1})
+	assert.Error(t, err, "no key column")
+	_, _, err = memeduck.UpsertIfNewer("item", nil, "updated_at", []string{"id", "updated_at"}, []interface{}{1, 1})
+	assert.Error(t, err, "no keys")
+	_, _, err = memeduck.UpsertIfNewer("item", []string{"id"}, "updated_at", []string{"id", "updated_at"}, []interface{}{1})
+	assert.Error(t, err, "length mismatch")
+}
@@ -0,0 +1,379 @@
+package memeduck
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/cloudspannerecosystem/memefish/ast"
+	"github.com/pkg/errors"
+
+	"github.com/abyssparanoia/memeduck/internal"
+)
+
+// Validate checks the SELECT statement against schema. It reports unknown tables and columns,
+// and Go values whose types don't match the columns they are compared with, all of them joined.
+// Values of parameters created by ParamValue are checked as well as literals.
+// Columns of UNNEST and subquery sources are unknown, so unresolved names are not reported if the statement reads them.
+func (s *SelectStmt) Validate(schema *Schema) error {
+	stmt, err := s.toASTStatement()
+	if err != nil {
+		return err
+	}
+	params, err := s.Params()
+	if err != nil {
+		return err
+	}
+	return schema.validate(stmt, params)
+}
+
+// Validate checks the INSERT statement against schema, including the types of inserted values.
+// See SelectStmt.Validate.
+func (s *InsertStmt) Validate(schema *Schema) error {
+	stmt, err := s.toASTStatement()
+	if err != nil {
+		return err
+	}
+	params, err := s.Params()
+	if err != nil {
+		return err
+	}
+	return schema.validate(stmt, params)
+}
+
+// Validate checks the UPDATE statement against schema, including the types of updated values.
+// See SelectStmt.Validate.
+func (s *UpdateStmt) Validate(schema *Schema) error {
+	stmt, err := s.toASTStatement()
+	if err != nil {
+		return err
+	}
+	params, err := s.Params()
+	if err != nil {
+		return err
+	}
+	return schema.validate(stmt, params)
+}
+
+// Validate checks the DELETE statement against schema. See SelectStmt.Validate.
+func (s *DeleteStmt) Validate(schema *Schema) error {
+	stmt, err := s.toASTStatement()
+	if err != nil {
+		return err
+	}
+	params, err := s.Params()
+	if err != nil {
+		return err
+	}
+	return schema.validate(stmt, params)
+}
+
+type validator struct {
+	schema *Schema
+	// tables maps the names and aliases of tables in the statement to them.
+	tables map[string]*Table
+	// scope is the tables in the statement in order of appearance.
+	scope []*Table
+	// names are the names which aren't columns of tables, e.g. aliases of results and UNNEST.
+	names map[string]bool
+	// opaque is set if the statement reads sources whose columns are unknown.
+	opaque bool
+	// params are the values of bound parameters, whose types are checked like literals.
+	params   map[string]interface{}
+	errs     errorList
+	reported map[string]bool
+}
+
+func (s *Schema) validate(stmt ast.Node, params map[string]interface{}) error {
+	v := &validator{
+		schema:   s,
+		tables:   make(map[string]*Table),
+		names:    make(map[string]bool),
+		params:   params,
+		reported: make(map[string]bool),
+	}
+	internal.Walk(stmt, v.collect)
+	internal.Walk(stmt, v.check)
+	return v.errs.err()
+}
+
+func (v *validator) errorf(format string, args ...interface{}) {
+	err := errors.Errorf(format, args...)
+	if !v.reported[err.Error()] {
+		v.reported[err.Error()] = true
+		v.errs.add(err)
+	}
+}
+
+// collect finds the tables and the names defined in the statement, as they can be referred before defined.
+func (v *validator) collect(n ast.Node) bool {
+	switch n := n.(type) {
+	case *ast.CTE:
+		v.names[strings.ToLower(n.Name.Name)] = true
+	case *ast.Alias:
+		v.addName(n.As)
+	case *ast.Unnest:
+		v.opaque = true
+		v.addName(n.As)
+		if n.WithOffset != nil {
+			v.addName(n.WithOffset.As)
+		}
+	case *ast.SubQueryTableExpr:
+		v.opaque = true
+		v.addName(n.As)
+	}
+	return true
+}
+
+func (v *validator) addName(as *ast.AsAlias) {
+	if as != nil {
+		v.names[strings.ToLower(as.Alias.Name)] = true
+	}
+}
+
+func (v *validator) addTable(name *ast.Ident, as *ast.AsAlias) *Table {
+	t := v.schema.Table(name.Name)
+	if t == nil {
+		if !v.names[strings.ToLower(name.Name)] {
+			v.errorf("unknown table %s", name.Name)
+		}
+		// Columns of the table are unknown.
+		v.opaque = true
+		return nil
+	}
+	if v.tables[strings.ToLower(name.Name)] == nil {
+		v.scope = append(v.scope, t)
+	}
+	v.tables[strings.ToLower(name.Name)] = t
+	if as != nil {
+		v.tables[strings.ToLower(as.Alias.Name)] = t
+	}
+	return t
+}
+
+func (v *validator) check(n ast.Node) bool {
+	switch n := n.(type) {
+	case *ast.Select:
+		// Resolve the tables in FROM first, as the results refer to them.
+		if n.From != nil {
+			internal.Walk(n.From, v.check)
+		}
+		for _, r := range n.Results {
+			internal.Walk(r, v.check)
+		}
+		for _, c := range []ast.Node{n.Where, n.GroupBy, n.Having, n.OrderBy} {
+			internal.Walk(c, v.check)
+		}
+		return false
+	case *ast.TableName:
+		v.addTable(n.Table, n.As)
+		return false
+	case *ast.Insert:
+		t := v.addTable(n.TableName, nil)
+		if t == nil {
+			return false
+		}
+		cols := make([]*Column, len(n.Columns))
+		for i, c := range n.Columns {
+			cols[i] = v.column(t, c.Name)
+		}
+		if values, ok := n.Input.(*ast.ValuesInput); ok {
+			for _, row := range values.Rows {
+				for i, e := range row.Exprs {
+					if i < len(cols) && !e.Default {
+						v.checkType(t, cols[i], e.Expr)
+					}
+				}
+			}
+		}
+		internal.Walk(n.Input, v.check)
+		return false
+	case *ast.Update:
+		t := v.addTable(n.TableName, n.As)
+		if t == nil {
+			return false
+		}
+		for _, item := range n.Updates {
+			col := v.column(t, item.Path[0].Name)
+			if len(item.Path) == 1 {
+				v.checkType(t, col, item.Expr)
+			}
+			internal.Walk(item.Expr, v.check)
+		}
+		internal.Walk(n.Where, v.check)
+		return false
+	case *ast.Delete:
+		if v.addTable(n.TableName, n.As) != nil {
+			internal.Walk(n.Where, v.check)
+		}
+		return false
+	case *ast.Hint, *ast.AsAlias, *ast.StructField:
+		return false
+	case *ast.CallExpr:
+		// Skip the function name.
+		for _, arg := range n.Args {
+			internal.Walk(arg, v.check)
+		}
+		return false
+	case *ast.SelectorExpr:
+		internal.Walk(n.Expr, v.check)
+		return false
+	case *ast.ExtractExpr:
+		internal.Walk(n.Expr, v.check)
+		return false
+	case *ast.Ident:
+		v.resolve(n.Name)
+	case *ast.Path:
+		if t := v.tables[strings.ToLower(n.Idents[0].Name)]; t != nil && len(n.Idents) > 1 {
+			v.column(t, n.Idents[1].Name)
+		} else {
+			v.resolve(n.Idents[0].Name)
+		}
+		return false
+	case *ast.BinaryExpr:
+		switch n.Op {
+		case ast.OpEqual, ast.OpNotEqual, ast.OpLess, ast.OpGreater, ast.OpLessEqual, ast.OpGreaterEqual:
+			v.compare(n.Left, n.Right)
+			v.compare(n.Right, n.Left)
+		}
+	case *ast.BetweenExpr:
+		v.compare(n.Left, n.RightStart)
+		v.compare(n.Left, n.RightEnd)
+	case *ast.InExpr:
+		switch r := n.Right.(type) {
+		case *ast.ValuesInCondition:
+			for _, e := range r.Exprs {
+				v.compare(n.Left, e)
+			}
+		case *ast.UnnestInCondition:
+			if t, col := v.columnOf(n.Left); col != nil {
+				if typ := v.valueType(r.Expr); strings.HasPrefix(typ, "ARRAY<") && !typeMatches("ARRAY<"+col.Type+">", typ) {
+					v.errorf("%s value can't be compared with column %s.%s of type %s", typ, t.Name, col.Name, col.Type)
+				}
+			}
+		}
+	}
+	return true
+}
+
+// resolve reports name if it is neither a column of the tables in the statement nor a name defined in it.
+func (v *validator) resolve(name string) {
+	if v.names[strings.ToLower(name)] || v.tables[strings.ToLower(name)] != nil {
+		return
+	}
+	if _, c := v.findColumn(name); c == nil && !v.opaque {
+		v.errorf("unknown column %s", name)
+	}
+}
+
+// column returns the column named name of t, reporting it if t has no such column.
+func (v *validator) column(t *Table, name string) *Column {
+	c := findColumn(t, name)
+	if c == nil {
+		v.errorf("unknown column %s.%s", t.Name, name)
+	}
+	return c
+}
+
+// columnOf returns the column e refers to, or nil if e is not a column reference.
+func (v *validator) columnOf(e ast.Expr) (*Table, *Column) {
+	switch e := e.(type) {
+	case *ast.ParenExpr:
+		return v.columnOf(e.Expr)
+	case *ast.Ident:
+		return v.findColumn(e.Name)
+	case *ast.Path:
+		if len(e.Idents) == 1 {
+			return v.findColumn(e.Idents[0].Name)
+		}
+		if t := v.tables[strings.ToLower(e.Idents[0].Name)]; t != nil && len(e.Idents) == 2 {
+			return t, findColumn(t, e.Idents[1].Name)
+		}
+	}
+	return nil, nil
+}
+
+// findColumn returns the first column named name of the tables in the statement.
+func (v *validator) findColumn(name string) (*Table, *Column) {
+	if v.names[strings.ToLower(name)] {
+		return nil, nil
+	}
+	for _, t := range v.scope {
+		if c := findColumn(t, name); c != nil {
+			return t, c
+		}
+	}
+	return nil, nil
+}
+
+func (v *validator) compare(col, value ast.Expr) {
+	if t, c := v.columnOf(col); c != nil {
+		if typ := v.valueType(value); typ != "" && !typeMatches(c.Type, typ) {
+			v.errorf("%s value can't be compared with column %s.%s of type %s", typ, t.Name, c.Name, c.Type)
+		}
+	}
+}
+
+func (v *validator) checkType(t *Table, c *Column, value ast.Expr) {
+	if c == nil {
+		return
+	}
+	if typ := v.valueType(value); typ != "" && !typeMatches(c.Type, typ) {
+		v.errorf("%s value can't be assigned to column %s.%s of type %s", typ, t.Name, c.Name, c.Type)
+	}
+}
+
+// valueType returns the type of the literal e, or the type of the Go value bound to the parameter e.
+// It returns "" if the type is unknown.
+func (v *validator) valueType(e ast.Expr) string {
+	if typ := internal.LiteralType(e); typ != "" {
+		return typ
+	}
+	for {
+		p, ok := e.(*ast.ParenExpr)
+		if !ok {
+			break
+		}
+		e = p.Expr
+	}
+	p, ok := e.(*ast.Param)
+	if !ok || v.params[p.Name] == nil {
+		return ""
+	}
+	if typ := internal.TypeOf(reflect.TypeOf(v.params[p.Name])); typ != nil {
+		return typ.SQL()
+	}
+	return ""
+}
+
+func findColumn(t *Table, name string) *Column {
+	for _, c := range t.Columns {
+		if strings.EqualFold(c.Name, name) {
+			return c
+		}
+	}
+	return nil
+}
+
+var typeLengthRe = regexp.MustCompile(`\(\s*\w+\s*\)|\s+`)
+
+// typeMatches reports whether a value of the type typ can be used as a value of the column type col.
+// Lengths like STRING(MAX) are ignored, and literals coercible by Spanner, e.g. INT64 into FLOAT64
+// and STRING into DATE, are allowed.
+func typeMatches(col, typ string) bool {
+	col = strings.ToUpper(typeLengthRe.ReplaceAllString(col, ""))
+	typ = strings.ToUpper(typeLengthRe.ReplaceAllString(typ, ""))
+	if col == typ {
+		return true
+	}
+	if strings.HasPrefix(col, "ARRAY<") && strings.HasPrefix(typ, "ARRAY<") {
+		return typeMatches(col[len("ARRAY<"):len(col)-1], typ[len("ARRAY<"):len(typ)-1])
+	}
+	switch typ {
+	case "INT64":
+		return col == "FLOAT64" || col == "NUMERIC"
+	case "STRING":
+		return col == "DATE" || col == "TIMESTAMP"
+	}
+	return false
+}
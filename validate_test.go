@@ -0,0 +1,86 @@
+package memeduck_test
+
+import (
+	"testing"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/abyssparanoia/memeduck"
+)
+
+func TestValidate(t *testing.T) {
+	schema, err := memeduck.SchemaFromDDL(`
+		CREATE TABLE user (
+			id STRING(36) NOT NULL,
+			name STRING(MAX),
+			age INT64,
+			score FLOAT64,
+			tags ARRAY<STRING(16)>,
+			created_at TIMESTAMP NOT NULL,
+		) PRIMARY KEY (id);
+		CREATE TABLE user_item (user_id STRING(36) NOT NULL, item_id INT64 NOT NULL) PRIMARY KEY (user_id, item_id);
+	`)
+	assert.Nil(t, err)
+	assert.NotNil(t, schema.Table("USER"))
+	assert.Nil(t, schema.Table("item"))
+
+	assert.Nil(t, memeduck.Select("user", []string{"id", "name"}).
+		Where(
+			memeduck.Eq(memeduck.Ident("age"), 20),
+			memeduck.Eq(memeduck.Ident("score"), 1),
+			memeduck.In(memeduck.Ident("name"), memeduck.Unnest([]string{"a", "b"})),
+			memeduck.Between(memeduck.Ident("created_at"), time.Now(), "2020-01-01T00:00:00Z"),
+			memeduck.Exists(memeduck.Select("user_item", []string{"item_id"}).Where(
+				memeduck.Eq(memeduck.Ident("user_id"), memeduck.IdentPath("user.id")),
+			)),
+		).
+		OrderBy("age", memeduck.DESC).
+		Validate(schema))
+	assert.Nil(t, memeduck.Insert("user", []string{"id", "age", "tags", "created_at"}).
+		Values([][]interface{}{{"x", 1, []string{"a"}, time.Now()}, {"y", nil, []string{}, time.Now()}}).
+		Validate(schema))
+	assert.Nil(t, memeduck.Update("user").Set(memeduck.Ident("name"), "foo").
+		Where(memeduck.Eq(memeduck.Ident("id"), "x")).Validate(schema))
+	assert.Nil(t, memeduck.Delete("user_item").Where(memeduck.Eq(memeduck.Ident("item_id"), 1)).Validate(schema))
+
+	err = memeduck.Select("usr", []string{"id"}).Validate(schema)
+	assert.EqualError(t, err, "unknown table usr")
+	err = memeduck.Select("user", []string{"id", "nmae"}).
+		Where(memeduck.Eq(memeduck.Ident("age"), "20")).
+		Validate(schema)
+	assert.EqualError(t, err, "unknown column nmae\nSTRING value can't be compared with column user.age of type INT64")
+	err = memeduck.Insert("user", []string{"id", "agee", "score"}).
+		Values([][]interface{}{{1, 2, "x"}}).
+		Validate(schema)
+	assert.EqualError(t, err, "unknown column user.agee\n"+
+		"INT64 value can't be assigned to column user.id of type STRING(36)\n"+
+		"STRING value can't be assigned to column user.score of type FLOAT64")
+	err = memeduck.Update("user").Set(memeduck.Ident("tags"), []int64{1}).
+		Where(memeduck.In(memeduck.Ident("id"), memeduck.Unnest([]int64{1, 2}))).Validate(schema)
+	assert.EqualError(t, err, "ARRAY<INT64> value can't be assigned to column user.tags of type ARRAY<STRING(16)>\n"+
+		"ARRAY<INT64> value can't be compared with column user.id of type STRING(36)")
+	err = memeduck.Delete("user").Where(memeduck.Eq(memeduck.IdentPath("user.nmae"), "x")).Validate(schema)
+	assert.EqualError(t, err, "unknown column user.nmae")
+
+	assert.Nil(t, memeduck.Select("user", []string{"id"}).
+		Where(
+			memeduck.Eq(memeduck.Ident("age"), memeduck.ParamValue("age", int64(20))),
+			memeduck.Eq(memeduck.Ident("name"), memeduck.Param("name")),
+			memeduck.Eq(memeduck.Ident("score"), memeduck.ParamValue("score", nil)),
+		).
+		Validate(schema))
+	err = memeduck.Select("user", []string{"id"}).
+		Where(memeduck.Eq(memeduck.Ident("age"), memeduck.ParamValue("a", "x"))).
+		Validate(schema)
+	assert.EqualError(t, err, "STRING value can't be compared with column user.age of type INT64")
+	err = memeduck.Insert("user", []string{"id", "age"}).
+		Values([][]interface{}{{memeduck.ParamValue("id", 1), memeduck.ParamValue("age", spanner.NullInt64{})}}).
+		Validate(schema)
+	assert.EqualError(t, err, "INT64 value can't be assigned to column user.id of type STRING(36)")
+	err = memeduck.Delete("user").
+		Where(memeduck.In(memeduck.Ident("id"), memeduck.Unnest(memeduck.ParamValue("ids", []int64{1, 2})))).
+		Validate(schema)
+	assert.EqualError(t, err, "ARRAY<INT64> value can't be compared with column user.id of type STRING(36)")
+}
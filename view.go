@@ -0,0 +1,103 @@
+package memeduck
+
+import (
+	"github.com/cloudspannerecosystem/memefish/ast"
+	"github.com/pkg/errors"
+
+	"github.com/abyssparanoia/memeduck/internal"
+)
+
+// SQLSecurity is the security type of a view, which decides whose privileges are used to read the underlying tables.
+type SQLSecurity ast.SecurityType
+
+const (
+	Invoker SQLSecurity = SQLSecurity(ast.SecurityTypeInvoker)
+	Definer SQLSecurity = SQLSecurity(ast.SecurityTypeDefiner)
+)
+
+// CreateViewStmt is a CREATE VIEW statement.
+type CreateViewStmt struct {
+	name      string
+	security  SQLSecurity
+	query     *SelectStmt
+	orReplace bool
+}
+
+// CreateView creates a new CreateViewStmt with given view name.
+func CreateView(name string) *CreateViewStmt {
+	return &CreateViewStmt{name: name}
+}
+
+// SQLSecurity sets the security type of the view, which Spanner requires.
+func (s *CreateViewStmt) SQLSecurity(security SQLSecurity) *CreateViewStmt {
+	var t = *s
+	t.security = security
+	return &t
+}
+
+// As sets the query of the view. It can't have query parameters, as a view is stored in the schema.
+func (s *CreateViewStmt) As(query *SelectStmt) *CreateViewStmt {
+	var t = *s
+	t.query = query
+	return &t
+}
+
+// OrReplace makes the statement CREATE OR REPLACE VIEW.
+func (s *CreateViewStmt) OrReplace() *CreateViewStmt {
+	var t = *s
+	t.orReplace = true
+	return &t
+}
+
+func (s *CreateViewStmt) SQL() (string, error) {
+	stmt, err := s.toAST()
+	if err != nil {
+		return "", err
+	}
+	return renderSQL(stmt)
+}
+
+func (s *CreateViewStmt) toAST() (*ast.CreateView, error) {
+	name, err := toASTIdent(s.name)
+	if err != nil {
+		return nil, err
+	}
+	if s.security != Invoker && s.security != Definer {
+		return nil, errors.Errorf("SQL SECURITY of view %s must be INVOKER or DEFINER", s.name)
+	}
+	if s.query == nil {
+		return nil, errors.Errorf("view %s has no query", s.name)
+	}
+	query, err := s.query.toAST()
+	if err != nil {
+		return nil, err
+	}
+	internal.Walk(query, func(n ast.Node) bool {
+		if p, ok := n.(*ast.Param); ok && err == nil {
+			err = errors.Errorf("view %s can't have query parameter @%s", s.name, p.Name)
+		}
+		return err == nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &ast.CreateView{Name: name, OrReplace: s.orReplace, SecurityType: ast.SecurityType(s.security), Query: query}, nil
+}
+
+// DropViewStmt is a DROP VIEW statement.
+type DropViewStmt struct {
+	name string
+}
+
+// DropView creates a new DropViewStmt with given view name.
+func DropView(name string) *DropViewStmt {
+	return &DropViewStmt{name: name}
+}
+
+func (s *DropViewStmt) SQL() (string, error) {
+	name, err := toASTIdent(s.name)
+	if err != nil {
+		return "", err
+	}
+	return renderSQL(&internal.DropView{Name: name})
+}
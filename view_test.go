@@ -0,0 +1,34 @@
+package memeduck_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/abyssparanoia/memeduck"
+)
+
+func TestCreateView(t *testing.T) {
+	query := memeduck.Select("user", []string{"id", "name"}).Where(memeduck.Eq(memeduck.Ident("active"), true))
+	sql, err := memeduck.CreateView("active_user").SQLSecurity(memeduck.Invoker).As(query).SQL()
+	assert.Nil(t, err)
+	assert.Equal(t, "CREATE VIEW active_user SQL SECURITY INVOKER AS SELECT id, name FROM user WHERE active = TRUE", sql)
+	sql, err = memeduck.CreateView("active_user").OrReplace().SQLSecurity(memeduck.Definer).As(query).SQL()
+	assert.Nil(t, err)
+	assert.Equal(t, "CREATE OR REPLACE VIEW active_user SQL SECURITY DEFINER AS SELECT id, name FROM user WHERE active = TRUE", sql)
+
+	_, err = memeduck.CreateView("active_user").As(query).SQL()
+	assert.EqualError(t, err, "SQL SECURITY of view active_user must be INVOKER or DEFINER")
+	_, err = memeduck.CreateView("active_user").SQLSecurity(memeduck.Invoker).SQL()
+	assert.EqualError(t, err, "view active_user has no query")
+	_, err = memeduck.CreateView("user_by_id").SQLSecurity(memeduck.Invoker).
+		As(memeduck.Select("user", []string{"id"}).Where(memeduck.Eq(memeduck.Ident("id"), memeduck.Param("id")))).
+		SQL()
+	assert.EqualError(t, err, "view user_by_id can't have query parameter @id")
+}
+
+func TestDropView(t *testing.T) {
+	sql, err := memeduck.DropView("active_user").SQL()
+	assert.Nil(t, err)
+	assert.Equal(t, "DROP VIEW active_user", sql)
+}
@@ -1,6 +1,8 @@
 package memeduck
 
 import (
+	"strings"
+
 	"github.com/cloudspannerecosystem/memefish/ast"
 	"github.com/pkg/errors"
 
@@ -60,8 +62,8 @@ func (c *OpCond) ToASTWhere() (*ast.Where, error) {
 	return &ast.Where{
 		Expr: &ast.BinaryExpr{
 			Op:    ast.BinaryOp(c.op),
-			Left:  lhs,
-			Right: rhs,
+			Left:  internal.Operand(lhs),
+			Right: internal.Operand(rhs),
 		},
 	}, nil
 }
@@ -139,11 +141,23 @@ func (c *NullCond) ToASTWhere() (*ast.Where, error) {
 	return &ast.Where{
 		Expr: &ast.IsNullExpr{
 			Not:  c.not,
-			Left: expr,
+			Left: internal.Operand(expr),
 		},
 	}, nil
 }
 
+// ArrayIsEmpty creates `COALESCE(ARRAY_LENGTH(x), 0) = 0` predicate, which holds if the array x is empty or NULL.
+// Note that `ARRAY_LENGTH(x) = 0` doesn't hold for NULL arrays.
+func ArrayIsEmpty(x interface{}) *OpCond {
+	return Eq(Coalesce(Func("ARRAY_LENGTH", x), 0), 0)
+}
+
+// ArrayIsNotEmpty creates `ARRAY_LENGTH(x) > 0` predicate, which holds if the array x has any element.
+// It doesn't hold for NULL arrays.
+func ArrayIsNotEmpty(x interface{}) *OpCond {
+	return Gt(Func("ARRAY_LENGTH", x), 0)
+}
+
 // InConditionValue is a value expression in IN clauses.
 type InConditionValue interface {
 	ToASTInConditionValue() (ast.InCondition, error)
@@ -200,7 +214,7 @@ func (c *InCond) ToASTWhere() (*ast.Where, error) {
 	return &ast.Where{
 		Expr: &ast.InExpr{
 			Not:   c.not,
-			Left:  lhs,
+			Left:  internal.Operand(lhs),
 			Right: rhs,
 		},
 	}, nil
@@ -240,9 +254,9 @@ func (c *BetweenCond) ToASTWhere() (*ast.Where, error) {
 	return &ast.Where{
 		Expr: &ast.BetweenExpr{
 			Not:        c.not,
-			Left:       arg,
-			RightStart: min,
-			RightEnd:   max,
+			Left:       internal.Operand(arg),
+			RightStart: internal.Operand(min),
+			RightEnd:   internal.Operand(max),
 		},
 	}, nil
 }
@@ -250,6 +264,7 @@ func (c *BetweenCond) ToASTWhere() (*ast.Where, error) {
 // IdentExpr is an identifier.
 type IdentExpr struct {
 	names []string
+	err   error
 }
 
 // Ident creates a new IdentExpr.
@@ -258,22 +273,76 @@ func Ident(names ...string) *IdentExpr {
 	return &IdentExpr{names: names}
 }
 
+// IdentPath creates a new IdentExpr from a dotted path like "a.b.c".
+// A part of the path can be quoted with backquotes to contain dots, e.g. "a.`b.c`".
+// Each part is quoted in the resulting SQL when it is a keyword or not a valid identifier.
+func IdentPath(path string) *IdentExpr {
+	names, err := splitIdentPath(path)
+	return &IdentExpr{names: names, err: err}
+}
+
+func splitIdentPath(path string) ([]string, error) {
+	var names []string
+	for i := 0; i <= len(path); {
+		var name string
+		if i < len(path) && path[i] == '`' {
+			end := strings.IndexByte(path[i+1:], '`')
+			if end < 0 {
+				return nil, errors.Errorf("unterminated quoted identifier in %q", path)
+			}
+			name = path[i+1 : i+1+end]
+			i += end + 2
+			if i < len(path) && path[i] != '.' {
+				return nil, errors.Errorf("unexpected character after quoted identifier in %q", path)
+			}
+		} else {
+			end := strings.IndexByte(path[i:], '.')
+			if end < 0 {
+				end = len(path) - i
+			}
+			name = path[i : i+end]
+			i += end
+		}
+		if name == "" {
+			return nil, errors.Errorf("empty identifier in %q", path)
+		}
+		names = append(names, name)
+		i++
+	}
+	return names, nil
+}
+
 func (e *IdentExpr) ToASTExpr() (ast.Expr, error) {
+	if e.err != nil {
+		return nil, e.err
+	}
 	if len(e.names) <= 0 {
 		return nil, errors.New("empty identifier")
 	}
 	path := &ast.Path{}
 	for _, name := range e.names {
-		path.Idents = append(path.Idents, &ast.Ident{
-			Name: name,
-		})
+		id, err := toASTIdent(name)
+		if err != nil {
+			return nil, err
+		}
+		path.Idents = append(path.Idents, id)
 	}
 	return path, nil
 }
 
+// toASTIdent validates name and converts it into an identifier, quoted by backticks when rendered if needed.
+func toASTIdent(name string) (*ast.Ident, error) {
+	if err := internal.CheckIdent(name); err != nil {
+		return nil, err
+	}
+	return internal.Ident(name), nil
+}
+
 // ParamExpr is a query parameter.
 type ParamExpr struct {
-	name string
+	name  string
+	value interface{}
+	bound bool
 }
 
 // Param createsa new ParamExpr.
@@ -281,6 +350,12 @@ func Param(name string) *ParamExpr {
 	return &ParamExpr{name: name}
 }
 
+// ParamValue creates a new ParamExpr bound to the value v.
+// Values of bound parameters are collected by Params of statements.
+func ParamValue(name string, v interface{}) *ParamExpr {
+	return &ParamExpr{name: name, value: v, bound: true}
+}
+
 func (e *ParamExpr) ToASTExpr() (ast.Expr, error) {
 	return &ast.Param{Name: e.name}, nil
 }
@@ -318,23 +393,105 @@ func (c *LogicalOpCond) ToASTWhere() (*ast.Where, error) {
 	if len(c.conds) <= 0 {
 		return nil, errors.New("no conditions")
 	}
-	where, err := c.conds[0].ToASTWhere()
-	if err != nil {
-		return nil, err
-	}
-	acc := where
-	for _, cond := range c.conds[1:] {
-		where, err = cond.ToASTWhere()
+	var errs errorList
+	var acc *ast.Where
+	for _, cond := range c.conds {
+		where, err := cond.ToASTWhere()
 		if err != nil {
-			return nil, err
+			errs.add(err)
+			continue
+		}
+		if acc == nil {
+			acc = where
+			continue
 		}
 		acc = &ast.Where{
 			Expr: &ast.BinaryExpr{
 				Op:    ast.BinaryOp(c.op),
-				Left:  acc.Expr,
-				Right: where.Expr,
+				Left:  internal.Operand(acc.Expr),
+				Right: internal.Operand(where.Expr),
 			},
 		}
 	}
+	if err := errs.err(); err != nil {
+		return nil, err
+	}
 	return acc, nil
 }
+
+// ArrayContainsCond represents a predicate checking whether an array contains all or any of given values.
+type ArrayContainsCond struct {
+	arr    interface{}
+	values interface{}
+	all    bool
+}
+
+// ArrayContainsAny(arr, values) creates a predicate which is true when arr contains at least one element of values.
+func ArrayContainsAny(arr, values interface{}) *ArrayContainsCond {
+	return &ArrayContainsCond{arr: arr, values: values}
+}
+
+// ArrayContainsAll(arr, values) creates a predicate which is true when arr contains every element of values.
+func ArrayContainsAll(arr, values interface{}) *ArrayContainsCond {
+	return &ArrayContainsCond{arr: arr, values: values, all: true}
+}
+
+const (
+	arrayContainsElemAlias  = "memeduck_elem"
+	arrayContainsValueAlias = "memeduck_value"
+)
+
+// unnestExists builds `EXISTS(SELECT 1 FROM UNNEST(arr) AS alias WHERE cond)`.
+func unnestExists(arr ast.Expr, alias string, cond ast.Expr) *ast.ExistsSubQuery {
+	return &ast.ExistsSubQuery{
+		Query: &ast.Select{
+			Results: []ast.SelectItem{
+				&ast.ExprSelectItem{Expr: internal.IntLit(1)},
+			},
+			From: &ast.From{
+				Source: &ast.Unnest{
+					Expr: arr,
+					As:   &ast.AsAlias{Alias: &ast.Ident{Name: alias}},
+				},
+			},
+			Where: &ast.Where{Expr: cond},
+		},
+	}
+}
+
+func (c *ArrayContainsCond) ToASTWhere() (*ast.Where, error) {
+	arr, err := internal.ToExpr(c.arr)
+	if err != nil {
+		return nil, err
+	}
+	values, err := internal.ToExpr(c.values)
+	if err != nil {
+		return nil, err
+	}
+	elem := &ast.Ident{Name: arrayContainsElemAlias}
+	if !c.all {
+		// EXISTS(SELECT 1 FROM UNNEST(arr) AS e WHERE e IN UNNEST(values))
+		return &ast.Where{
+			Expr: unnestExists(arr, arrayContainsElemAlias, &ast.InExpr{
+				Left:  elem,
+				Right: &ast.UnnestInCondition{Expr: values},
+			}),
+		}, nil
+	}
+	// NOT EXISTS(SELECT 1 FROM UNNEST(values) AS v WHERE NOT EXISTS(SELECT 1 FROM UNNEST(arr) AS e WHERE e = v))
+	// NOT IN is avoided here because it never holds when arr contains NULL.
+	value := &ast.Ident{Name: arrayContainsValueAlias}
+	return &ast.Where{
+		Expr: &ast.UnaryExpr{
+			Op: ast.OpNot,
+			Expr: unnestExists(values, arrayContainsValueAlias, &ast.UnaryExpr{
+				Op: ast.OpNot,
+				Expr: unnestExists(arr, arrayContainsElemAlias, &ast.BinaryExpr{
+					Op:    ast.OpEqual,
+					Left:  elem,
+					Right: value,
+				}),
+			}),
+		},
+	}, nil
+}
@@ -43,6 +43,18 @@ func TestIdent(t *testing.T) {
 	testExpr(t, memeduck.Ident("TRUE", "FALSE"), "`TRUE`.`FALSE`")
 }
 
+func TestIdentPath(t *testing.T) {
+	testExpr(t, memeduck.IdentPath("a"), `a`)
+	testExpr(t, memeduck.IdentPath("a.b.c"), `a.b.c`)
+	testExpr(t, memeduck.IdentPath("a.TRUE"), "a.`TRUE`")
+	testExpr(t, memeduck.IdentPath("a.`b.c`"), "a.`b.c`")
+	testExpr(t, memeduck.IdentPath("`a`.b"), `a.b`)
+	for _, path := range []string{"", "a.", ".a", "a..b", "a.`b", "`a`b"} {
+		_, err := memeduck.IdentPath(path).ToASTExpr()
+		assert.Error(t, err, path)
+	}
+}
+
 func TestParam(t *testing.T) {
 	testExpr(t, memeduck.Param("a"), `@a`)
 	testExpr(t, memeduck.Param("abc"), `@abc`)
@@ -73,6 +85,11 @@ func TestIsNullAndIsNotNull(t *testing.T) {
 	testWhere(t, memeduck.IsNotNull(memeduck.Ident("fuga")), `fuga IS NOT NULL`)
 }
 
+func TestArrayIsEmpty(t *testing.T) {
+	testWhere(t, memeduck.ArrayIsEmpty(memeduck.Ident("tags")), `COALESCE(ARRAY_LENGTH(tags), 0) = 0`)
+	testWhere(t, memeduck.ArrayIsNotEmpty(memeduck.Ident("tags")), `ARRAY_LENGTH(tags) > 0`)
+}
+
 func TestIn(t *testing.T) {
 	testWhere(t, memeduck.In(memeduck.Ident("hoge"), memeduck.Unnest(memeduck.Param("hoge"))), `hoge IN UNNEST(@hoge)`)
 	testWhere(t, memeduck.In(memeduck.Ident("hoge"), memeduck.Unnest([]string{"foo", "bar"})), `hoge IN UNNEST(ARRAY["foo", "bar"])`)
@@ -151,3 +168,53 @@ func TestOr(t *testing.T) {
 	// 	`1 = 1 AND (2 = 2 OR 3 = 3)`,
 	// )
 }
+
+func TestWhereConds(t *testing.T) {
+	sel := memeduck.Select("hoge", []string{"a"}).Where(
+		memeduck.Eq(memeduck.Ident("a"), 1),
+		memeduck.IsNull(memeduck.Ident("b")),
+	)
+	testDelete(t,
+		memeduck.Delete("hoge").Where(sel.WhereConds()...),
+		`DELETE FROM hoge WHERE a = 1 AND b IS NULL`,
+	)
+	testUpdate(t,
+		memeduck.Update("hoge").Set(memeduck.Ident("c"), true).Where(sel.WhereConds()...),
+		`UPDATE hoge SET c = TRUE WHERE a = 1 AND b IS NULL`,
+	)
+	upd := memeduck.Update("hoge").Set(memeduck.Ident("c"), true).Where(memeduck.Eq(memeduck.Ident("d"), 2))
+	testSelect(t,
+		memeduck.Select("hoge", []string{"a"}).Where(upd.WhereConds()...),
+		`SELECT a FROM hoge WHERE d = 2`,
+	)
+	del := memeduck.Delete("hoge").Where(memeduck.Eq(memeduck.Ident("e"), 3))
+	assert.Len(t, del.WhereConds(), 1)
+	assert.Empty(t, memeduck.Select("hoge", []string{"a"}).WhereConds())
+
+	// Modifying returned slice doesn't affect the statement.
+	conds := sel.WhereConds()
+	conds[0] = memeduck.Bool(false)
+	testSelect(t, sel, `SELECT a FROM hoge WHERE a = 1 AND b IS NULL`)
+}
+
+func TestArrayContains(t *testing.T) {
+	testWhere(t,
+		memeduck.ArrayContainsAny(memeduck.Ident("tags"), memeduck.Param("tags")),
+		`EXISTS(SELECT 1 FROM UNNEST(tags) AS memeduck_elem WHERE memeduck_elem IN UNNEST(@tags))`,
+	)
+	testWhere(t,
+		memeduck.ArrayContainsAny(memeduck.Ident("tags"), []string{"a", "b"}),
+		`EXISTS(SELECT 1 FROM UNNEST(tags) AS memeduck_elem WHERE memeduck_elem IN UNNEST(ARRAY["a", "b"]))`,
+	)
+	testWhere(t,
+		memeduck.ArrayContainsAll(memeduck.Ident("tags"), memeduck.Param("tags")),
+		`NOT EXISTS(SELECT 1 FROM UNNEST(@tags) AS memeduck_value WHERE NOT EXISTS(SELECT 1 FROM UNNEST(tags) AS memeduck_elem WHERE memeduck_elem = memeduck_value))`,
+	)
+	testSelect(t,
+		memeduck.Select("hoge", []string{"a"}).Where(
+			memeduck.Eq(memeduck.Ident("a"), 1),
+			memeduck.ArrayContainsAll(memeduck.Ident("tags"), []int64{1, 2}),
+		),
+		`SELECT a FROM hoge WHERE a = 1 AND NOT EXISTS(SELECT 1 FROM UNNEST(ARRAY[1, 2]) AS memeduck_value WHERE NOT EXISTS(SELECT 1 FROM UNNEST(tags) AS memeduck_elem WHERE memeduck_elem = memeduck_value))`,
+	)
+}
@@ -0,0 +1,213 @@
+package memeduck
+
+import (
+	"github.com/cloudspannerecosystem/memefish/ast"
+	"github.com/pkg/errors"
+
+	"github.com/abyssparanoia/memeduck/internal"
+)
+
+// RowNumber creates `ROW_NUMBER()` function call. It must be used with Over.
+func RowNumber() *AggregateExpr {
+	return newAggregate("ROW_NUMBER")
+}
+
+// Rank creates `RANK()` function call. It must be used with Over.
+func Rank() *AggregateExpr {
+	return newAggregate("RANK")
+}
+
+// DenseRank creates `DENSE_RANK()` function call. It must be used with Over.
+func DenseRank() *AggregateExpr {
+	return newAggregate("DENSE_RANK")
+}
+
+// WindowSpec is a window specification used by analytic function calls.
+type WindowSpec struct {
+	name       string
+	partitions []string
+	ords       []*ordering
+	frame      *windowFrame
+}
+
+type windowFrame struct {
+	unit       string
+	start, end *FrameBound
+}
+
+// Window creates a new empty WindowSpec.
+func Window() *WindowSpec {
+	return &WindowSpec{}
+}
+
+// NamedWindow creates a new WindowSpec based on a window defined by SelectStmt.Window.
+// The returned WindowSpec can be refined by adding ORDER BY or frame clauses.
+func NamedWindow(name string) *WindowSpec {
+	return &WindowSpec{name: name}
+}
+
+// PartitionBy appends columns to its PARTITION BY clause.
+func (w *WindowSpec) PartitionBy(cols ...string) *WindowSpec {
+	var t = *w
+	t.partitions = append(append([]string(nil), t.partitions...), cols...)
+	return &t
+}
+
+// OrderBy appends a column to its ORDER BY clause.
+func (w *WindowSpec) OrderBy(col string, dir Direction) *WindowSpec {
+	var t = *w
+	t.ords = append(append([]*ordering(nil), t.ords...), &ordering{
+		col: col,
+		dir: dir,
+	})
+	return &t
+}
+
+// Rows sets a `ROWS BETWEEN start AND end` frame clause.
+// If end is nil, `ROWS start` is used instead.
+// It replaces existing frame clauses.
+func (w *WindowSpec) Rows(start, end *FrameBound) *WindowSpec {
+	var t = *w
+	t.frame = &windowFrame{unit: "ROWS", start: start, end: end}
+	return &t
+}
+
+// Range sets a `RANGE BETWEEN start AND end` frame clause.
+// If end is nil, `RANGE start` is used instead.
+// It replaces existing frame clauses.
+func (w *WindowSpec) Range(start, end *FrameBound) *WindowSpec {
+	var t = *w
+	t.frame = &windowFrame{unit: "RANGE", start: start, end: end}
+	return &t
+}
+
+func (w *WindowSpec) toAST() (*internal.WindowSpec, error) {
+	spec := &internal.WindowSpec{}
+	if w.name != "" {
+		spec.Name = &ast.Ident{Name: w.name}
+	}
+	for _, col := range w.partitions {
+		spec.PartitionBy = append(spec.PartitionBy, &ast.Ident{Name: col})
+	}
+	if len(w.ords) > 0 {
+		items := make([]*ast.OrderByItem, 0, len(w.ords))
+		for _, o := range w.ords {
+			items = append(items, o.toASTOrderByItem())
+		}
+		spec.OrderBy = &ast.OrderBy{
+			Items: items,
+		}
+	}
+	if w.frame != nil {
+		if w.frame.start == nil {
+			return nil, errors.New("no frame start specified")
+		}
+		start, err := w.frame.start.toAST()
+		if err != nil {
+			return nil, err
+		}
+		spec.Frame = &internal.WindowFrame{
+			Unit:  w.frame.unit,
+			Start: start,
+		}
+		if w.frame.end != nil {
+			spec.Frame.End, err = w.frame.end.toAST()
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return spec, nil
+}
+
+// FrameBound is a boundary of window frames.
+type FrameBound struct {
+	offset interface{}
+	kind   string
+}
+
+// UnboundedPreceding creates `UNBOUNDED PRECEDING` frame boundary.
+func UnboundedPreceding() *FrameBound {
+	return &FrameBound{kind: "UNBOUNDED PRECEDING"}
+}
+
+// Preceding creates `n PRECEDING` frame boundary.
+func Preceding(n interface{}) *FrameBound {
+	return &FrameBound{offset: n, kind: "PRECEDING"}
+}
+
+// CurrentRow creates `CURRENT ROW` frame boundary.
+func CurrentRow() *FrameBound {
+	return &FrameBound{kind: "CURRENT ROW"}
+}
+
+// Following creates `n FOLLOWING` frame boundary.
+func Following(n interface{}) *FrameBound {
+	return &FrameBound{offset: n, kind: "FOLLOWING"}
+}
+
+// UnboundedFollowing creates `UNBOUNDED FOLLOWING` frame boundary.
+func UnboundedFollowing() *FrameBound {
+	return &FrameBound{kind: "UNBOUNDED FOLLOWING"}
+}
+
+func (b *FrameBound) toAST() (*internal.FrameBound, error) {
+	bound := &internal.FrameBound{Kind: b.kind}
+	if b.offset != nil {
+		offset, err := internal.ToExpr(b.offset)
+		if err != nil {
+			return nil, err
+		}
+		bound.Offset = offset
+	}
+	return bound, nil
+}
+
+// AnalyticExpr is an analytic function call `fn OVER (window)`.
+type AnalyticExpr struct {
+	fn     interface{}
+	window *WindowSpec
+	as     string
+}
+
+// Over creates an analytic function call which computes fn over the given window.
+// If window is nil, an empty window `OVER ()` is used.
+func Over(fn interface{}, window *WindowSpec) *AnalyticExpr {
+	if window == nil {
+		window = Window()
+	}
+	return &AnalyticExpr{
+		fn:     fn,
+		window: window,
+	}
+}
+
+// As sets an alias used when the analytic function call appears in SELECT results.
+func (e *AnalyticExpr) As(as string) *AnalyticExpr {
+	var t = *e
+	t.as = as
+	return &t
+}
+
+func (e *AnalyticExpr) ToASTExpr() (ast.Expr, error) {
+	fn, err := internal.ToExpr(e.fn)
+	if err != nil {
+		return nil, err
+	}
+	window, err := e.window.toAST()
+	if err != nil {
+		return nil, err
+	}
+	return &internal.AnalyticExpr{
+		Func:   fn,
+		Window: window,
+	}, nil
+}
+
+func (e *AnalyticExpr) ToAST() (ast.SelectItem, error) {
+	expr, err := e.ToASTExpr()
+	if err != nil {
+		return nil, err
+	}
+	return toASTSelectItem(expr, e.as), nil
+}
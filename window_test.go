@@ -0,0 +1,106 @@
+package memeduck_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/abyssparanoia/memeduck"
+)
+
+func TestOver(t *testing.T) {
+	testExpr(t, memeduck.Over(memeduck.RowNumber(), nil), `ROW_NUMBER() OVER ()`)
+	testExpr(t,
+		memeduck.Over(memeduck.RowNumber(), memeduck.Window().
+			PartitionBy("user_id").
+			OrderBy("created_at", memeduck.DESC)),
+		`ROW_NUMBER() OVER (PARTITION BY user_id ORDER BY created_at DESC)`,
+	)
+	testExpr(t,
+		memeduck.Over(memeduck.Rank(), memeduck.Window().
+			PartitionBy("a", "b").
+			OrderBy("c", memeduck.ASC).
+			OrderBy("d", memeduck.DESC)),
+		`RANK() OVER (PARTITION BY a, b ORDER BY c ASC, d DESC)`,
+	)
+	testExpr(t,
+		memeduck.Over(memeduck.DenseRank(), memeduck.Window().OrderBy("score", memeduck.DESC)),
+		`DENSE_RANK() OVER (ORDER BY score DESC)`,
+	)
+}
+
+func TestOverWithFrame(t *testing.T) {
+	testExpr(t,
+		memeduck.Over(memeduck.Sum(memeduck.Ident("amount")), memeduck.Window().
+			PartitionBy("user_id").
+			OrderBy("created_at", memeduck.ASC).
+			Rows(memeduck.UnboundedPreceding(), memeduck.CurrentRow())),
+		`SUM(amount) OVER (PARTITION BY user_id ORDER BY created_at ASC ROWS BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW)`,
+	)
+	testExpr(t,
+		memeduck.Over(memeduck.Avg(memeduck.Ident("amount")), memeduck.Window().
+			OrderBy("day", memeduck.ASC).
+			Rows(memeduck.Preceding(3), memeduck.Following(3))),
+		`AVG(amount) OVER (ORDER BY day ASC ROWS BETWEEN 3 PRECEDING AND 3 FOLLOWING)`,
+	)
+	testExpr(t,
+		memeduck.Over(memeduck.Max(memeduck.Ident("amount")), memeduck.Window().
+			OrderBy("day", memeduck.ASC).
+			Range(memeduck.CurrentRow(), memeduck.UnboundedFollowing())),
+		`MAX(amount) OVER (ORDER BY day ASC RANGE BETWEEN CURRENT ROW AND UNBOUNDED FOLLOWING)`,
+	)
+	testExpr(t,
+		memeduck.Over(memeduck.Count(memeduck.Ident("a")), memeduck.Window().
+			Rows(memeduck.UnboundedPreceding(), nil)),
+		`COUNT(a) OVER (ROWS UNBOUNDED PRECEDING)`,
+	)
+	_, err := memeduck.Over(memeduck.Count(memeduck.Ident("a")), memeduck.Window().Rows(nil, nil)).ToASTExpr()
+	assert.Error(t, err)
+}
+
+func TestOverAsOperand(t *testing.T) {
+	testWhere(t,
+		memeduck.Eq(memeduck.Over(memeduck.RowNumber(), memeduck.Window().OrderBy("a", memeduck.ASC)), 1),
+		`(ROW_NUMBER() OVER (ORDER BY a ASC)) = 1`,
+	)
+}
+
+func TestSelectWithOver(t *testing.T) {
+	testSelect(t,
+		memeduck.Select("hoge", []string{"a"}).Items(
+			memeduck.Over(memeduck.RowNumber(), memeduck.Window().PartitionBy("b").OrderBy("c", memeduck.DESC)).As("rn"),
+		),
+		`SELECT a, ROW_NUMBER() OVER (PARTITION BY b ORDER BY c DESC) AS rn FROM hoge`,
+	)
+}
+
+func TestSelectWithNamedWindow(t *testing.T) {
+	testExpr(t, memeduck.Over(memeduck.RowNumber(), memeduck.NamedWindow("w")), `ROW_NUMBER() OVER w`)
+	testExpr(t,
+		memeduck.Over(memeduck.Sum(memeduck.Ident("a")), memeduck.NamedWindow("w").Rows(memeduck.UnboundedPreceding(), memeduck.CurrentRow())),
+		`SUM(a) OVER (w ROWS BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW)`,
+	)
+	testSelect(t,
+		memeduck.Select("hoge", []string{"a"}).
+			Items(
+				memeduck.Over(memeduck.RowNumber(), memeduck.NamedWindow("w")).As("rn"),
+				memeduck.Over(memeduck.Rank(), memeduck.NamedWindow("w")).As("rk"),
+			).
+			Where(memeduck.Gt(memeduck.Ident("a"), 0)).
+			Window("w", memeduck.Window().PartitionBy("b").OrderBy("c", memeduck.DESC)).
+			OrderBy("a", memeduck.ASC).
+			Limit(10),
+		`SELECT a, ROW_NUMBER() OVER w AS rn, RANK() OVER w AS rk FROM hoge WHERE a > 0 WINDOW w AS (PARTITION BY b ORDER BY c DESC) ORDER BY a ASC LIMIT 10`,
+	)
+	testSelect(t,
+		memeduck.Select("hoge", []string{"a"}).
+			Window("w1", memeduck.Window().PartitionBy("b")).
+			Window("w2", memeduck.NamedWindow("w1").OrderBy("c", memeduck.ASC)),
+		`SELECT a FROM hoge WINDOW w1 AS (PARTITION BY b), w2 AS (w1 ORDER BY c ASC)`,
+	)
+	_, err := memeduck.Select("hoge", []string{"a"}).
+		Window("w", memeduck.Window()).
+		Window("w", memeduck.Window()).
+		SQL()
+	assert.Error(t, err)
+}
@@ -0,0 +1,59 @@
+package memeduck
+
+import (
+	"io"
+
+	"github.com/cloudspannerecosystem/memefish/ast"
+
+	"github.com/abyssparanoia/memeduck/internal"
+)
+
+// WriteSQL writes SQL of the SELECT statement to w.
+func (s *SelectStmt) WriteSQL(w io.Writer) error {
+	stmt, err := s.toASTStatement()
+	if err != nil {
+		return err
+	}
+	return writeSQL(w, stmt, s.dialect)
+}
+
+// WriteSQL writes SQL of the INSERT statement to w. VALUES rows are rendered and written one by one,
+// which avoids building a huge string for statements inserting many rows.
+func (s *InsertStmt) WriteSQL(w io.Writer) error {
+	stmt, err := s.toASTStatement()
+	if err != nil {
+		return err
+	}
+	return writeSQL(w, stmt, s.dialect)
+}
+
+// WriteSQL writes SQL of the UPDATE statement to w.
+func (s *UpdateStmt) WriteSQL(w io.Writer) error {
+	stmt, err := s.toASTStatement()
+	if err != nil {
+		return err
+	}
+	return writeSQL(w, stmt, s.dialect)
+}
+
+// WriteSQL writes SQL of the DELETE statement to w.
+func (s *DeleteStmt) WriteSQL(w io.Writer) error {
+	stmt, err := s.toASTStatement()
+	if err != nil {
+		return err
+	}
+	return writeSQL(w, stmt, s.dialect)
+}
+
+func writeSQL(w io.Writer, stmt ast.Node, d Dialect) error {
+	// Verification and other dialects need the whole SQL.
+	if internal.Debug || d.orDefault() != DialectGoogleSQL {
+		sql, _, err := d.render(stmt)
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(w, sql)
+		return err
+	}
+	return internal.WriteSQL(w, stmt)
+}
@@ -0,0 +1,55 @@
+package memeduck_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/abyssparanoia/memeduck"
+)
+
+func TestWriteSQL(t *testing.T) {
+	rows := make([][]interface{}, 0, 100)
+	for i := 0; i < 100; i++ {
+		rows = append(rows, []interface{}{i, "name"})
+	}
+	for _, stmt := range []interface {
+		SQL() (string, error)
+	}{
+		memeduck.Insert("user", []string{"id", "name"}).Values(rows),
+		memeduck.Insert("user", []string{"id", "name"}).Values(rows).OrIgnore().ThenReturn("id"),
+		memeduck.Insert("user", []string{"id"}).Select(memeduck.Select("other", []string{"id"})),
+		memeduck.Select("user", []string{"id"}).Where(memeduck.Eq(memeduck.Ident("id"), 1)),
+		memeduck.Update("user").Set(memeduck.Ident("name"), "foo").Where(memeduck.Eq(memeduck.Ident("id"), 1)),
+		memeduck.Delete("user").Where(memeduck.Eq(memeduck.Ident("id"), 1)).Dialect(memeduck.DialectPostgreSQL),
+	} {
+		expected, err := stmt.SQL()
+		assert.Nil(t, err)
+		var buf bytes.Buffer
+		switch s := stmt.(type) {
+		case *memeduck.InsertStmt:
+			err = s.WriteSQL(&buf)
+		case *memeduck.SelectStmt:
+			err = s.WriteSQL(&buf)
+		case *memeduck.UpdateStmt:
+			err = s.WriteSQL(&buf)
+		case *memeduck.DeleteStmt:
+			err = s.WriteSQL(&buf)
+		}
+		assert.Nil(t, err)
+		assert.Equal(t, expected, buf.String())
+	}
+
+	err := memeduck.Insert("user", []string{"id", "name"}).Values(rows).WriteSQL(errWriter{})
+	assert.EqualError(t, err, "write error")
+	err = memeduck.Insert("user", []string{"id"}).WriteSQL(&bytes.Buffer{})
+	assert.Error(t, err)
+}
+
+type errWriter struct{}
+
+func (errWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("write error")
+}
@@ -0,0 +1,64 @@
+package memeduck
+
+import (
+	"github.com/pkg/errors"
+)
+
+// YoTable creates a Table from the functions generated by yo (https://github.com/cloudspannerecosystem/yo),
+// e.g. YoTable("User", UserColumns(), UserPrimaryKeys()). Column types are left empty.
+func YoTable(name string, columns, primaryKeys []string) *Table {
+	table := &Table{Name: name}
+	for _, c := range columns {
+		table.Columns = append(table.Columns, &Column{Name: c})
+	}
+	for _, k := range primaryKeys {
+		table.PrimaryKey = append(table.PrimaryKey, &KeyPart{Column: k})
+	}
+	return table
+}
+
+// InsertRows creates an INSERT statement of all columns of table.
+// rows is a slice of structs or pointers to structs, such as ones generated by yo, whose fields are mapped by spanner tags.
+func InsertRows(table *Table, rows interface{}) *InsertStmt {
+	return Insert(table.Name, table.ColumnNames()).Values(rows)
+}
+
+// WhereKey creates conditions matching the primary key of table to the values in row,
+// a struct or a pointer to a struct whose fields are mapped by spanner tags.
+func WhereKey(table *Table, row interface{}) (WhereCond, error) {
+	if len(table.PrimaryKey) <= 0 {
+		return nil, errors.Errorf("table %s has no primary key", table.Name)
+	}
+	conds := make([]WhereCond, 0, len(table.PrimaryKey))
+	for _, k := range table.PrimaryKey {
+		v, err := structColumnValue(row, k.Column)
+		if err != nil {
+			return nil, err
+		}
+		conds = append(conds, Eq(Ident(k.Column), v))
+	}
+	return And(conds...), nil
+}
+
+// UpdateRow creates an UPDATE statement setting all non-key columns of table to the values in row,
+// for the row with the same primary key. Fields tagged readonly, or omitempty with zero values, are not set.
+func UpdateRow(table *Table, row interface{}) (*UpdateStmt, error) {
+	key, err := WhereKey(table, row)
+	if err != nil {
+		return nil, err
+	}
+	stmt := Update(table.Name)
+	for _, c := range table.Columns {
+		if table.IsKey(c.Name) {
+			continue
+		}
+		f, fv, err := structColumn(row, c.Name, nil)
+		if err != nil {
+			return nil, err
+		}
+		if v, ok := f.writeValue(fv); ok {
+			stmt = stmt.Set(Ident(c.Name), v)
+		}
+	}
+	return stmt.Where(key), nil
+}
@@ -0,0 +1,53 @@
+package memeduck_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/abyssparanoia/memeduck"
+)
+
+// testYoUser mimics a struct generated by yo.
+type testYoUser struct {
+	UserID string `spanner:"UserID" json:"UserID"`
+	Name   string `spanner:"Name" json:"Name"`
+	Age    int64  `spanner:"Age" json:"Age"`
+}
+
+func testYoUserPrimaryKeys() []string {
+	return []string{"UserID"}
+}
+
+func testYoUserColumns() []string {
+	return []string{"UserID", "Name", "Age"}
+}
+
+func TestYo(t *testing.T) {
+	table := memeduck.YoTable("User", testYoUserColumns(), testYoUserPrimaryKeys())
+	user := &testYoUser{UserID: "u1", Name: "foo", Age: 20}
+
+	testInsert(t,
+		memeduck.InsertRows(table, []*testYoUser{user}),
+		`INSERT INTO User (UserID, Name, Age) VALUES ("u1", "foo", 20)`,
+	)
+
+	key, err := memeduck.WhereKey(table, user)
+	assert.Nil(t, err)
+	testSelect(t,
+		memeduck.Select(table.Name, table.ColumnNames()).Where(key),
+		`SELECT UserID, Name, Age FROM User WHERE UserID = "u1"`,
+	)
+	testDelete(t, memeduck.Delete(table.Name).Where(key), `DELETE FROM User WHERE UserID = "u1"`)
+
+	update, err := memeduck.UpdateRow(table, *user)
+	assert.Nil(t, err)
+	testUpdate(t, update, `UPDATE User SET Name = "foo", Age = 20 WHERE UserID = "u1"`)
+
+	_, err = memeduck.WhereKey(memeduck.YoTable("User", testYoUserColumns(), nil), user)
+	assert.Error(t, err)
+	_, err = memeduck.UpdateRow(memeduck.YoTable("User", []string{"UserID", "Email"}, testYoUserPrimaryKeys()), user)
+	assert.Error(t, err)
+	_, err = memeduck.WhereKey(table, "u1")
+	assert.Error(t, err)
+}